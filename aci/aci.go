@@ -60,7 +60,7 @@ func createACIContainers(ctx context.Context, aciContext store.AciContext, group
 			return err
 		}
 	} else {
-		return fmt.Errorf("container group %q already exists", *groupDefinition.Name)
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "container group %q", *groupDefinition.Name)
 	}
 
 	return createOrUpdateACIContainers(ctx, aciContext, groupDefinition)
@@ -120,7 +120,7 @@ func createOrUpdateACIContainers(ctx context.Context, aciContext store.AciContex
 		groupDefinition,
 	)
 	if err != nil {
-		return err
+		return toErrdefs(err)
 	}
 
 	w.Event(progress.Event{
@@ -140,7 +140,7 @@ func createOrUpdateACIContainers(ctx context.Context, aciContext store.AciContex
 
 	err = future.WaitForCompletionRef(ctx, containerGroupsClient.Client)
 	if err != nil {
-		return err
+		return toErrdefs(err)
 	}
 
 	for _, c := range *groupDefinition.Containers {
@@ -156,6 +156,25 @@ func createOrUpdateACIContainers(ctx context.Context, aciContext store.AciContex
 	return err
 }
 
+// toErrdefs classifies Azure errors with the errdefs taxonomy
+func toErrdefs(err error) error {
+	if strings.Contains(err.Error(), "QuotaExceeded") {
+		return errors.Wrap(errdefs.ErrQuotaExceeded, err.Error())
+	}
+	var aerr autorest.DetailedError
+	if errors.As(err, &aerr) {
+		switch aerr.StatusCode {
+		case http.StatusNotFound:
+			return errors.Wrap(errdefs.ErrNotFound, err.Error())
+		case http.StatusConflict:
+			return errors.Wrap(errdefs.ErrConflict, err.Error())
+		case http.StatusForbidden:
+			return errors.Wrap(errdefs.ErrForbidden, err.Error())
+		}
+	}
+	return err
+}
+
 func getACIContainerGroup(ctx context.Context, aciContext store.AciContext, containerGroupName string) (containerinstance.ContainerGroup, error) {
 	containerGroupsClient, err := login.NewContainerGroupsClient(aciContext.SubscriptionID)
 	if err != nil {
@@ -308,23 +327,43 @@ func exec(ctx context.Context, address string, password string, request containe
 	}
 }
 
-func getACIContainerLogs(ctx context.Context, aciContext store.AciContext, containerGroupName, containerName string, tail *int32) (string, error) {
+func getACIContainerLogs(ctx context.Context, aciContext store.AciContext, containerGroupName, containerName string, query logsQuery) (string, error) {
 	containerClient, err := login.NewContainerClient(aciContext.SubscriptionID)
 	if err != nil {
 		return "", errors.Wrapf(err, "cannot get container client")
 	}
 
-	logs, err := containerClient.ListLogs(ctx, aciContext.ResourceGroup, containerGroupName, containerName, tail)
+	req, err := containerClient.ListLogsPreparer(ctx, aciContext.ResourceGroup, containerGroupName, containerName, query.tail)
+	if err != nil {
+		return "", fmt.Errorf("cannot get container logs: %v", err)
+	}
+	if query.timeBounded() {
+		// the time range is applied on the timestamps ACI prefixes the lines with
+		values := req.URL.Query()
+		values.Set("api-version", timestampsAPIVersion)
+		values.Set("timestamps", "true")
+		req.URL.RawQuery = values.Encode()
+	}
+	resp, err := containerClient.ListLogsSender(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot get container logs: %v", err)
+	}
+	logs, err := containerClient.ListLogsResponder(resp)
 	if err != nil {
 		return "", fmt.Errorf("cannot get container logs: %v", err)
 	}
 	if logs.Content == nil {
 		return "", nil
 	}
-	return *logs.Content, err
+	if query.timeBounded() {
+		return filterLogsByTime(*logs.Content, query.since, query.until), nil
+	}
+	return *logs.Content, nil
 }
 
-func streamLogs(ctx context.Context, aciContext store.AciContext, containerGroupName, containerName string, req containers.LogsRequest) error {
+// streamLogs polls the logs of a container, only the tail is requested so long lived containers
+// don't have their entire history fetched every time
+func streamLogs(ctx context.Context, aciContext store.AciContext, containerGroupName, containerName string, query logsQuery, req containers.LogsRequest) error {
 	numLines := 0
 	previousLogLines := ""
 	firstDisplay := true // optimization to exit sooner in cases like docker run hello-world, do not wait another 2 secs.
@@ -333,7 +372,7 @@ func streamLogs(ctx context.Context, aciContext store.AciContext, containerGroup
 		case <-ctx.Done():
 			return nil
 		default:
-			logs, err := getACIContainerLogs(ctx, aciContext, containerGroupName, containerName, nil)
+			logs, err := getACIContainerLogs(ctx, aciContext, containerGroupName, containerName, query)
 			if err != nil {
 				return err
 			}
@@ -18,6 +18,7 @@ package aci
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 )
@@ -26,3 +27,53 @@ func TestGetLinesWritten(t *testing.T) {
 	assert.Equal(t, 0, getBacktrackLines([]string{"Hello"}, 10))
 	assert.Equal(t, 3, getBacktrackLines([]string{"Hello", "world"}, 2))
 }
+
+func TestLogsTail(t *testing.T) {
+	tail, err := logsTail("all")
+	assert.NilError(t, err)
+	assert.Assert(t, tail == nil)
+
+	tail, err = logsTail("")
+	assert.NilError(t, err)
+	assert.Assert(t, tail == nil)
+
+	tail, err = logsTail("10")
+	assert.NilError(t, err)
+	assert.Equal(t, *tail, int32(10))
+
+	_, err = logsTail("ten")
+	assert.ErrorContains(t, err, "invalid syntax")
+}
+
+func TestNewLogsQuery(t *testing.T) {
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	query, err := newLogsQuery("20", "1h", "", now)
+	assert.NilError(t, err)
+	assert.Equal(t, *query.tail, int32(20))
+	assert.Equal(t, query.since, now.Add(-time.Hour))
+	assert.Assert(t, query.until.IsZero())
+	assert.Assert(t, query.timeBounded())
+
+	query, err = newLogsQuery("all", "", "", now)
+	assert.NilError(t, err)
+	assert.Assert(t, !query.timeBounded())
+
+	_, err = newLogsQuery("all", "yesterday", "", now)
+	assert.ErrorContains(t, err, `invalid timestamp "yesterday"`)
+}
+
+func TestFilterLogsByTime(t *testing.T) {
+	logs := `2020-11-20T09:00:00.1234567Z starting
+2020-11-20T09:30:00.1234567Z panic: oops
+goroutine 1 [running]:
+2020-11-20T09:45:00.1234567Z restarted
+2020-11-20T10:15:00.1234567Z listening
+`
+	since := time.Date(2020, 11, 20, 9, 15, 0, 0, time.UTC)
+	until := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, filterLogsByTime(logs, since, until), `panic: oops
+goroutine 1 [running]:
+restarted
+`)
+	assert.Equal(t, filterLogsByTime(logs, until, time.Time{}), "listening\n")
+}
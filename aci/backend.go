@@ -18,6 +18,7 @@ package aci
 
 import (
 	"context"
+	"net/http"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
@@ -35,6 +36,7 @@ import (
 	apicontext "github.com/docker/compose-cli/context"
 	"github.com/docker/compose-cli/context/cloud"
 	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
 )
 
 const (
@@ -50,6 +52,7 @@ type LoginParams struct {
 	TenantID     string
 	ClientID     string
 	ClientSecret string
+	CloudName    string
 }
 
 // Validate returns an error if options are not used properly
@@ -59,11 +62,13 @@ func (opts LoginParams) Validate() error {
 			return errors.New("for Service Principal login, 3 options must be specified: --client-id, --client-secret and --tenant-id")
 		}
 	}
-	return nil
+	_, err := login.CloudEnvironment(opts.CloudName)
+	return err
 }
 
 func init() {
 	backend.Register(backendType, backendType, service, getCloudService)
+	compose.RegisterBackendConfig(compose.BackendConfig{Capabilities: capabilities})
 }
 
 func service(ctx context.Context) (backend.Service, error) {
@@ -74,6 +79,7 @@ func service(ctx context.Context) (backend.Service, error) {
 	if err := contextStore.GetEndpoint(currentContext, &aciContext); err != nil {
 		return nil, err
 	}
+	login.SetRetryPolicy(store.ContextRetryPolicy(ctx))
 
 	return getAciAPIService(aciContext), nil
 }
@@ -132,6 +138,19 @@ func (a *aciAPIService) ResourceService() resources.Service {
 	return a.aciResourceService
 }
 
+func (a *aciAPIService) Ping(ctx context.Context) error {
+	aciContext := a.aciResourceService.aciContext
+	groupsClient, err := login.NewGroupsClient(aciContext.SubscriptionID)
+	if err != nil {
+		return errors.Wrap(errdefs.ErrLoginRequired, err.Error())
+	}
+	result, err := groupsClient.Get(ctx, aciContext.ResourceGroup)
+	if result.IsHTTPStatus(http.StatusUnauthorized) {
+		return errors.Wrap(errdefs.ErrLoginRequired, err.Error())
+	}
+	return err
+}
+
 func getContainerID(group containerinstance.ContainerGroup, container containerinstance.Container) string {
 	containerID := *group.Name + composeContainerSeparator + *container.Name
 	if _, ok := group.Tags[singleContainerTag]; ok {
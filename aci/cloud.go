@@ -34,9 +34,9 @@ func (cs *aciCloudService) Login(ctx context.Context, params interface{}) error
 		return errors.New("could not read Azure LoginParams struct from generic parameter")
 	}
 	if opts.ClientID != "" {
-		return cs.loginService.LoginServicePrincipal(opts.ClientID, opts.ClientSecret, opts.TenantID)
+		return cs.loginService.LoginServicePrincipal(opts.ClientID, opts.ClientSecret, opts.TenantID, opts.CloudName)
 	}
-	return cs.loginService.Login(ctx, opts.TenantID)
+	return cs.loginService.Login(ctx, opts.TenantID, opts.CloudName)
 }
 
 func (cs *aciCloudService) Logout(ctx context.Context) error {
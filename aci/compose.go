@@ -21,7 +21,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
 	"github.com/compose-spec/compose-go/types"
 	"github.com/sirupsen/logrus"
 
@@ -30,6 +33,8 @@ import (
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/context/store"
 	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/utils"
 )
 
 type aciComposeService struct {
@@ -44,20 +49,31 @@ func newComposeService(ctx store.AciContext) aciComposeService {
 	}
 }
 
-func (cs *aciComposeService) Up(ctx context.Context, project *types.Project, detach bool) error {
+func (cs *aciComposeService) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
 	logrus.Debugf("Up on project with name %q", project.Name)
 
 	if err := autocreateFileshares(ctx, project); err != nil {
 		return err
 	}
 
+	replicas, err := getReplicas(*project)
+	if err != nil {
+		return err
+	}
+
 	groupDefinition, err := convert.ToContainerGroup(ctx, cs.ctx, *project, cs.storageLogin)
 	if err != nil {
 		return err
 	}
 
 	addTag(&groupDefinition, composeContainerTag)
-	return createOrUpdateACIContainers(ctx, cs.ctx, groupDefinition)
+	for i := 1; i <= replicas; i++ {
+		err := createOrUpdateACIContainers(ctx, cs.ctx, replicaGroup(groupDefinition, project.Name, i))
+		if err != nil {
+			return err
+		}
+	}
+	return removeReplicas(ctx, cs.ctx, project.Name, replicas)
 }
 
 func (cs aciComposeService) warnKeepVolumeOnDown(ctx context.Context, projectName string) error {
@@ -82,6 +98,10 @@ func (cs aciComposeService) warnKeepVolumeOnDown(ctx context.Context, projectNam
 	return nil
 }
 
+func (cs *aciComposeService) Pull(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
 func (cs *aciComposeService) Down(ctx context.Context, project string) error {
 	logrus.Debugf("Down on project with name %q", project)
 
@@ -97,7 +117,15 @@ func (cs *aciComposeService) Down(ctx context.Context, project string) error {
 		return errdefs.ErrNotFound
 	}
 
-	return err
+	return removeReplicas(ctx, cs.ctx, project, 0)
+}
+
+func (cs *aciComposeService) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	return nil, errdefs.ErrNotImplemented
 }
 
 func (cs *aciComposeService) Ps(ctx context.Context, project string) ([]compose.ServiceStatus, error) {
@@ -115,14 +143,24 @@ func (cs *aciComposeService) Ps(ctx context.Context, project string) ([]compose.
 		return nil, fmt.Errorf("no containers found in ACI container group %s", project)
 	}
 
+	replicas, err := getReplicaGroups(ctx, cs.ctx, project)
+	if err != nil {
+		return nil, err
+	}
+
 	res := []compose.ServiceStatus{}
-	for _, container := range *group.Containers {
-		if isContainerVisible(container, group, false) {
+	for _, g := range append([]containerinstance.ContainerGroup{group}, replicas...) {
+		if g.Containers == nil {
 			continue
 		}
-		res = append(res, convert.ContainerGroupToServiceStatus(getContainerID(group, container), group, container, cs.ctx.Location))
+		for _, container := range *g.Containers {
+			if isContainerVisible(container, g, false) {
+				continue
+			}
+			res = append(res, convert.ContainerGroupToServiceStatus(getContainerID(g, container), g, container, cs.ctx.Location))
+		}
 	}
-	return res, nil
+	return aggregateServiceStatus(res), nil
 }
 
 func (cs *aciComposeService) List(ctx context.Context, project string) ([]compose.Stack, error) {
@@ -136,6 +174,9 @@ func (cs *aciComposeService) List(ctx context.Context, project string) ([]compos
 		if _, found := group.Tags[composeContainerTag]; !found {
 			continue
 		}
+		if _, replica := group.Tags[composeReplicaTag]; replica {
+			continue
+		}
 		if project != "" && *group.Name != project {
 			continue
 		}
@@ -156,10 +197,101 @@ func (cs *aciComposeService) List(ctx context.Context, project string) ([]compos
 	return stacks, nil
 }
 
-func (cs *aciComposeService) Logs(ctx context.Context, project string, w io.Writer) error {
-	return errdefs.ErrNotImplemented
+func (cs *aciComposeService) Logs(ctx context.Context, project string, w io.Writer, options compose.LogOptions) error {
+	query, err := newLogsQuery(options.Tail, options.Since, options.Until, time.Now())
+	if err != nil {
+		return err
+	}
+	consumer, err := formatter.NewFilteredLogConsumer(w, options.Filter)
+	if err != nil {
+		return err
+	}
+	group, err := getACIContainerGroup(ctx, cs.ctx, project)
+	if err != nil {
+		return err
+	}
+	replicas, err := getReplicaGroups(ctx, cs.ctx, project)
+	if err != nil {
+		return err
+	}
+
+	for _, g := range append([]containerinstance.ContainerGroup{group}, replicas...) {
+		if g.Containers == nil {
+			continue
+		}
+		for _, container := range *g.Containers {
+			if *container.Name == convert.ComposeDNSSidecarName {
+				continue
+			}
+			if len(options.Services) > 0 && !utils.StringContains(options.Services, *container.Name) {
+				continue
+			}
+			logs, err := getACIContainerLogs(ctx, cs.ctx, *g.Name, *container.Name, query)
+			if err != nil {
+				return err
+			}
+			if logs = strings.TrimSuffix(logs, "\n"); logs != "" {
+				consumer.Log(*container.Name, getContainerID(g, container), logs)
+			}
+		}
+	}
+	return nil
 }
 
 func (cs *aciComposeService) Convert(ctx context.Context, project *types.Project, format string) ([]byte, error) {
 	return nil, errdefs.ErrNotImplemented
 }
+
+func (cs *aciComposeService) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Stop(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Kill(ctx context.Context, projectName string) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *aciComposeService) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+var capabilities = compose.Capabilities{
+	Backend: "aci",
+	Supported: []string{
+		compose.FeatureBuild,
+		compose.FeatureHealthcheck,
+		compose.FeatureSecrets,
+		compose.FeatureVolumes,
+	},
+}
+
+func (cs *aciComposeService) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	return capabilities, nil
+}
@@ -19,9 +19,11 @@ package aci
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
 	"github.com/Azure/go-autorest/autorest"
@@ -167,22 +169,16 @@ func verifyExecCommand(command string) error {
 
 func (cs *aciContainerService) Logs(ctx context.Context, containerName string, req containers.LogsRequest) error {
 	groupName, containerAciName := getGroupAndContainerName(containerName)
-	var tail *int32
-
-	if req.Follow {
-		return streamLogs(ctx, cs.ctx, groupName, containerAciName, req)
+	query, err := newLogsQuery(req.Tail, req.Since, req.Until, time.Now())
+	if err != nil {
+		return err
 	}
 
-	if req.Tail != "all" {
-		reqTail, err := strconv.Atoi(req.Tail)
-		if err != nil {
-			return err
-		}
-		i32 := int32(reqTail)
-		tail = &i32
+	if req.Follow {
+		return streamLogs(ctx, cs.ctx, groupName, containerAciName, query, req)
 	}
 
-	logs, err := getACIContainerLogs(ctx, cs.ctx, groupName, containerAciName, tail)
+	logs, err := getACIContainerLogs(ctx, cs.ctx, groupName, containerAciName, query)
 	if err != nil {
 		return err
 	}
@@ -191,6 +187,19 @@ func (cs *aciContainerService) Logs(ctx context.Context, containerName string, r
 	return err
 }
 
+// logsTail converts the requested tail to the number of lines ACI sends, nil for all lines
+func logsTail(tail string) (*int32, error) {
+	if tail == "" || tail == "all" {
+		return nil, nil
+	}
+	lines, err := strconv.Atoi(tail)
+	if err != nil {
+		return nil, err
+	}
+	i32 := int32(lines)
+	return &i32, nil
+}
+
 func (cs *aciContainerService) Delete(ctx context.Context, containerID string, request containers.DeleteRequest) error {
 	groupName, containerName := getGroupAndContainerName(containerID)
 	if groupName != containerID {
@@ -262,3 +271,35 @@ func (cs *aciContainerService) Inspect(ctx context.Context, containerID string)
 
 	return convert.ContainerGroupToContainer(containerID, cg, cc, cs.ctx.Location), nil
 }
+
+func (cs *aciContainerService) Checkpoint(ctx context.Context, containerID string, request containers.CheckpointRequest) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "checkpoints are not supported by ACI")
+}
+
+func (cs *aciContainerService) ListCheckpoints(ctx context.Context, containerID string, dir string) ([]containers.Checkpoint, error) {
+	return nil, errors.Wrap(errdefs.ErrNotImplemented, "checkpoints are not supported by ACI")
+}
+
+func (cs *aciContainerService) Restore(ctx context.Context, containerID string, request containers.RestoreRequest) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "checkpoints are not supported by ACI")
+}
+
+func (cs *aciContainerService) Commit(ctx context.Context, containerID string, request containers.CommitRequest) (string, error) {
+	return "", errors.Wrap(errdefs.ErrNotImplemented, "commit is not supported by ACI")
+}
+
+func (cs *aciContainerService) Wait(ctx context.Context, containerID string) (int64, error) {
+	return 0, errors.Wrap(errdefs.ErrNotImplemented, "wait is not supported by ACI")
+}
+
+func (cs *aciContainerService) Diff(ctx context.Context, containerID string) ([]containers.FilesystemChange, error) {
+	return nil, errors.Wrap(errdefs.ErrNotImplemented, "diff is not supported by ACI")
+}
+
+func (cs *aciContainerService) Export(ctx context.Context, containerID string, w io.Writer) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "export is not supported by ACI")
+}
+
+func (cs *aciContainerService) Import(ctx context.Context, request containers.ImportRequest) (string, error) {
+	return "", errors.Wrap(errdefs.ErrNotImplemented, "import is not supported by ACI")
+}
@@ -49,12 +49,17 @@ const (
 // ToContainerGroup converts a compose project into a ACI container group
 func ToContainerGroup(ctx context.Context, aciContext store.AciContext, p types.Project, storageHelper login.StorageLogin) (containerinstance.ContainerGroup, error) {
 	project := projectAciHelper(p)
-	containerGroupName := strings.ToLower(project.Name)
+	if project.Name != "" {
+		if err := compose.CheckProjectName(project.Name, false); err != nil {
+			return containerinstance.ContainerGroup{}, err
+		}
+	}
+	containerGroupName := compose.NormalizeProjectName(project.Name)
 	volumesSlice, err := project.getAciFileVolumes(ctx, storageHelper)
 	if err != nil {
 		return containerinstance.ContainerGroup{}, err
 	}
-	secretVolumes, err := project.getAciSecretVolumes()
+	secretVolumes, err := project.getAciSecretVolumes(ctx)
 	if err != nil {
 		return containerinstance.ContainerGroup{}, err
 	}
@@ -97,6 +102,9 @@ func ToContainerGroup(ctx context.Context, aciContext store.AciContext, p types.
 		if service.Labels != nil && len(service.Labels) > 0 {
 			return containerinstance.ContainerGroup{}, errors.New("ACI integration does not support labels in compose applications")
 		}
+		if service.Privileged || len(service.SecurityOpt) > 0 {
+			return containerinstance.ContainerGroup{}, fmt.Errorf("ACI integration does not support privileged mode or security options, found on service %q", service.Name)
+		}
 
 		containerPorts, serviceGroupPorts, serviceDomainName, err := convertPortsToAci(service)
 		if err != nil {
@@ -334,6 +334,36 @@ func TestLabelsErrorMessage(t *testing.T) {
 	assert.Error(t, err, "ACI integration does not support labels in compose applications")
 }
 
+func TestProjectNameWithUnderscore(t *testing.T) {
+	project := types.Project{
+		Name: "my_app",
+		Services: []types.ServiceConfig{
+			{
+				Name:  "service1",
+				Image: "image1",
+			},
+		},
+	}
+
+	_, err := ToContainerGroup(context.TODO(), convertCtx, project, mockStorageHelper)
+	assert.ErrorContains(t, err, `invalid project name "my_app"`)
+}
+
+func TestPrivilegedErrorMessage(t *testing.T) {
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:       "service1",
+				Image:      "image1",
+				Privileged: true,
+			},
+		},
+	}
+
+	_, err := ToContainerGroup(context.TODO(), convertCtx, project, mockStorageHelper)
+	assert.Error(t, err, `ACI integration does not support privileged mode or security options, found on service "service1"`)
+}
+
 func TestComposeContainerGroupToContainerWithDomainName(t *testing.T) {
 	project := types.Project{
 		Services: []types.ServiceConfig{
@@ -127,7 +127,7 @@ func getUsedRegistries(project compose.Project) (map[string]bool, []string) {
 			registry = dockerHub
 		} else if !strings.Contains(registry, ".") {
 			registry = dockerHub
-		} else if strings.HasSuffix(registry, login.AcrRegistrySuffix) {
+		} else if login.IsAcrRegistry(registry) {
 			acrRegistries = append(acrRegistries, registry)
 		}
 		usedRegistries[registry] = true
@@ -17,15 +17,17 @@
 package convert
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
-	"io/ioutil"
 	"path"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/secrets"
 )
 
 const (
@@ -38,12 +40,12 @@ func getServiceSecretKey(serviceName, targetDir string) string {
 		serviceSecretAbsPathPrefix, serviceName, strings.ReplaceAll(targetDir, "/", "-"))
 }
 
-func (p projectAciHelper) getAciSecretVolumes() ([]containerinstance.Volume, error) {
+func (p projectAciHelper) getAciSecretVolumes(ctx context.Context) ([]containerinstance.Volume, error) {
 	var secretVolumes []containerinstance.Volume
 	for _, svc := range p.Services {
 		squashedTargetVolumes := make(map[string]containerinstance.Volume)
 		for _, scr := range svc.Secrets {
-			data, err := ioutil.ReadFile(p.Secrets[scr.Source].File)
+			data, err := secrets.ReadContent(ctx, p.Secrets[scr.Source])
 			if err != nil {
 				return secretVolumes, err
 			}
@@ -17,6 +17,7 @@
 package convert
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -73,7 +74,7 @@ func TestConvertSecrets(t *testing.T) {
 				},
 			},
 		}
-		volumes, err := pSquashedDefaultAndAbs.getAciSecretVolumes()
+		volumes, err := pSquashedDefaultAndAbs.getAciSecretVolumes(context.TODO())
 		assert.NilError(t, err)
 		assert.Equal(t, len(volumes), 2)
 
@@ -123,7 +124,7 @@ func TestConvertSecrets(t *testing.T) {
 				},
 			},
 		}
-		_, err := pInvalidRelativePathTarget.getAciSecretVolumes()
+		_, err := pInvalidRelativePathTarget.getAciSecretVolumes(context.TODO())
 		assert.Equal(t, err.Error(),
 			fmt.Sprintf(`in service %q, secret with source %q cannot have a relative path as target. Only absolute paths are allowed. Found %q`,
 				serviceName, secretName, targetName))
@@ -17,11 +17,14 @@
 package login
 
 import (
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/2019-03-01/resources/mgmt/resources"
 	"github.com/Azure/azure-sdk-for-go/profiles/preview/preview/subscription/mgmt/subscription"
 	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
 	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/pkg/errors"
@@ -33,7 +36,7 @@ import (
 // NewContainerGroupsClient get client toi manipulate containerGrouos
 func NewContainerGroupsClient(subscriptionID string) (containerinstance.ContainerGroupsClient, error) {
 	containerGroupsClient := containerinstance.NewContainerGroupsClient(subscriptionID)
-	err := setupClient(&containerGroupsClient.Client)
+	err := setupClient(&containerGroupsClient.Client, &containerGroupsClient.BaseURI)
 	if err != nil {
 		return containerinstance.ContainerGroupsClient{}, err
 	}
@@ -43,20 +46,39 @@ func NewContainerGroupsClient(subscriptionID string) (containerinstance.Containe
 	return containerGroupsClient, nil
 }
 
-func setupClient(aciClient *autorest.Client) error {
+func setupClient(aciClient *autorest.Client, baseURI *string) error {
 	aciClient.UserAgent = internal.UserAgentName + "/" + internal.Version
 	auth, err := NewAuthorizerFromLogin()
 	if err != nil {
 		return err
 	}
 	aciClient.Authorizer = auth
+	env, err := currentCloudEnvironment()
+	if err != nil {
+		return err
+	}
+	*baseURI = strings.TrimSuffix(env.ResourceManagerEndpoint, "/")
+	aciClient.Sender = autorest.DecorateSender(&http.Client{Transport: http.DefaultTransport, Timeout: requestTimeout}, withRetries())
 	return nil
 }
 
+// NewContainerRegistriesClient get client to manipulate container registries
+func NewContainerRegistriesClient(subscriptionID string) (containerregistry.RegistriesClient, error) {
+	registriesClient := containerregistry.NewRegistriesClient(subscriptionID)
+	err := setupClient(&registriesClient.Client, &registriesClient.BaseURI)
+	if err != nil {
+		return containerregistry.RegistriesClient{}, err
+	}
+	registriesClient.PollingDelay = 5 * time.Second
+	registriesClient.RetryAttempts = 30
+	registriesClient.RetryDuration = 1 * time.Second
+	return registriesClient, nil
+}
+
 // NewStorageAccountsClient get client to manipulate storage accounts
 func NewStorageAccountsClient(subscriptionID string) (storage.AccountsClient, error) {
 	containerGroupsClient := storage.NewAccountsClient(subscriptionID)
-	err := setupClient(&containerGroupsClient.Client)
+	err := setupClient(&containerGroupsClient.Client, &containerGroupsClient.BaseURI)
 	if err != nil {
 		return storage.AccountsClient{}, err
 	}
@@ -69,7 +91,7 @@ func NewStorageAccountsClient(subscriptionID string) (storage.AccountsClient, er
 // NewFileShareClient get client to manipulate file shares
 func NewFileShareClient(subscriptionID string) (storage.FileSharesClient, error) {
 	containerGroupsClient := storage.NewFileSharesClient(subscriptionID)
-	err := setupClient(&containerGroupsClient.Client)
+	err := setupClient(&containerGroupsClient.Client, &containerGroupsClient.BaseURI)
 	if err != nil {
 		return storage.FileSharesClient{}, err
 	}
@@ -82,7 +104,7 @@ func NewFileShareClient(subscriptionID string) (storage.FileSharesClient, error)
 // NewSubscriptionsClient get subscription client
 func NewSubscriptionsClient() (subscription.SubscriptionsClient, error) {
 	subc := subscription.NewSubscriptionsClient()
-	err := setupClient(&subc.Client)
+	err := setupClient(&subc.Client, &subc.BaseURI)
 	if err != nil {
 		return subscription.SubscriptionsClient{}, errors.Wrap(errdefs.ErrLoginRequired, err.Error())
 	}
@@ -92,7 +114,7 @@ func NewSubscriptionsClient() (subscription.SubscriptionsClient, error) {
 // NewGroupsClient get client to manipulate groups
 func NewGroupsClient(subscriptionID string) (resources.GroupsClient, error) {
 	groupsClient := resources.NewGroupsClient(subscriptionID)
-	err := setupClient(&groupsClient.Client)
+	err := setupClient(&groupsClient.Client, &groupsClient.BaseURI)
 	if err != nil {
 		return resources.GroupsClient{}, err
 	}
@@ -102,7 +124,7 @@ func NewGroupsClient(subscriptionID string) (resources.GroupsClient, error) {
 // NewContainerClient get client to manipulate containers
 func NewContainerClient(subscriptionID string) (containerinstance.ContainerClient, error) {
 	containerClient := containerinstance.NewContainerClient(subscriptionID)
-	err := setupClient(&containerClient.Client)
+	err := setupClient(&containerClient.Client, &containerClient.BaseURI)
 	if err != nil {
 		return containerinstance.ContainerClient{}, err
 	}
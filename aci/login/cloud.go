@@ -0,0 +1,82 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package login
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AzurePublicCloud name of the Azure global cloud
+	AzurePublicCloud = "AzureCloud"
+	// AzureChinaCloud name of the Azure China sovereign cloud
+	AzureChinaCloud = "AzureChinaCloud"
+	// AzureUSGovernmentCloud name of the Azure US Government sovereign cloud
+	AzureUSGovernmentCloud = "AzureUSGovernment"
+)
+
+// cloud names follow the ones used by `az cloud list`
+var clouds = map[string]azure.Environment{
+	AzurePublicCloud:       azure.PublicCloud,
+	AzureChinaCloud:        azure.ChinaCloud,
+	AzureUSGovernmentCloud: azure.USGovernmentCloud,
+}
+
+// acrRegistrySuffixes lists ACR registry suffixes for all supported clouds
+var acrRegistrySuffixes = []string{
+	AcrRegistrySuffix,
+	".azurecr.cn",
+	".azurecr.us",
+}
+
+// CloudEnvironment returns the Azure environment (authentication endpoints, ARM base URL, storage suffixes...) for a cloud name.
+// An empty name selects the Azure public cloud.
+func CloudEnvironment(cloudName string) (azure.Environment, error) {
+	if cloudName == "" {
+		return azure.PublicCloud, nil
+	}
+	for name, env := range clouds {
+		if strings.EqualFold(name, cloudName) {
+			return env, nil
+		}
+	}
+	return azure.Environment{}, errors.Errorf("unknown Azure cloud %q, supported clouds are: %s", cloudName, strings.Join(CloudNames(), ", "))
+}
+
+// CloudNames returns the names of supported Azure clouds
+func CloudNames() []string {
+	names := []string{}
+	for name := range clouds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsAcrRegistry returns true if the registry is hosted by ACR, in any of the supported clouds
+func IsAcrRegistry(registry string) bool {
+	for _, suffix := range acrRegistrySuffixes {
+		if strings.HasSuffix(registry, suffix) {
+			return true
+		}
+	}
+	return false
+}
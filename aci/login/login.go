@@ -22,14 +22,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"os"
-	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
-	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
@@ -39,20 +39,28 @@ import (
 //go login process, derived from code sample provided by MS at https://github.com/devigned/go-az-cli-stuff
 const (
 	// AcrRegistrySuffix suffix for ACR registry images
-	AcrRegistrySuffix         = ".azurecr.io"
-	activeDirectoryURL        = "https://login.microsoftonline.com"
-	azureManagementURL        = "https://management.core.windows.net/"
-	azureResouceManagementURL = "https://management.azure.com/"
-	authorizeFormat           = activeDirectoryURL + "/organizations/oauth2/v2.0/authorize?response_type=code&client_id=%s&redirect_uri=%s&state=%s&prompt=select_account&response_mode=query&scope=%s"
-	tokenEndpoint             = activeDirectoryURL + "/%s/oauth2/v2.0/token"
-	getTenantURL              = azureResouceManagementURL + "tenants?api-version=2019-11-01"
-
-	// scopes for a multi-tenant app works for openid, email, other common scopes, but fails when trying to add a token
-	// v1 scope like "https://management.azure.com/.default" for ARM access
-	scopes   = "offline_access " + azureResouceManagementURL + ".default"
+	AcrRegistrySuffix = ".azurecr.io"
+	authorizeFormat   = "%s/organizations/oauth2/v2.0/authorize?response_type=code&client_id=%s&redirect_uri=%s&state=%s&prompt=select_account&response_mode=query&scope=%s"
+	tokenFormat       = "%s/%s/oauth2/v2.0/token"
+	tenantsFormat     = "%stenants?api-version=2019-11-01"
+
 	clientID = "04b07795-8ddb-461a-bbee-02f9e1bf7b46" // Azure CLI client id
 )
 
+// scopes for a multi-tenant app works for openid, email, other common scopes, but fails when trying to add a token
+// v1 scope like "https://management.azure.com/.default" for ARM access
+func scopes(env azure.Environment) string {
+	return "offline_access " + env.ResourceManagerEndpoint + ".default"
+}
+
+func activeDirectoryURL(env azure.Environment) string {
+	return strings.TrimSuffix(env.ActiveDirectoryEndpoint, "/")
+}
+
+func tenantsURL(env azure.Environment) string {
+	return fmt.Sprintf(tenantsFormat, env.ResourceManagerEndpoint)
+}
+
 type (
 	azureToken struct {
 		Type         string `json:"token_type"`
@@ -80,8 +88,8 @@ type AzureLoginService struct {
 
 // AzureLoginServiceAPI interface for Azure login service
 type AzureLoginServiceAPI interface {
-	LoginServicePrincipal(clientID string, clientSecret string, tenantID string) error
-	Login(ctx context.Context, requestedTenantID string) error
+	LoginServicePrincipal(clientID string, clientSecret string, tenantID string, cloudName string) error
+	Login(ctx context.Context, requestedTenantID string, cloudName string) error
 	Logout(ctx context.Context) error
 }
 
@@ -105,9 +113,15 @@ func newAzureLoginServiceFromPath(tokenStorePath string, helper apiHelper) (*Azu
 
 // LoginServicePrincipal login with clientId / clientSecret from a service principal.
 // The resulting token does not include a refresh token
-func (login *AzureLoginService) LoginServicePrincipal(clientID string, clientSecret string, tenantID string) error {
+func (login *AzureLoginService) LoginServicePrincipal(clientID string, clientSecret string, tenantID string, cloudName string) error {
+	env, err := CloudEnvironment(cloudName)
+	if err != nil {
+		return err
+	}
 	// Tried with auth2.NewUsernamePasswordConfig() but could not make this work with username / password, setting this for CI with clientID / clientSecret
 	creds := auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID)
+	creds.AADEndpoint = env.ActiveDirectoryEndpoint
+	creds.Resource = env.ResourceManagerEndpoint
 
 	spToken, err := creds.ServicePrincipalToken()
 	if err != nil {
@@ -121,7 +135,7 @@ func (login *AzureLoginService) LoginServicePrincipal(clientID string, clientSec
 	if err != nil {
 		return errors.Wrapf(errdefs.ErrLoginFailed, "could not read service principal token expiry: %s", err)
 	}
-	loginInfo := TokenInfo{TenantID: tenantID, Token: token}
+	loginInfo := TokenInfo{TenantID: tenantID, Token: token, CloudName: cloudName}
 
 	if err := login.tokenStore.writeLoginInfo(loginInfo); err != nil {
 		return errors.Wrapf(errdefs.ErrLoginFailed, "could not store login info: %s", err)
@@ -132,14 +146,14 @@ func (login *AzureLoginService) LoginServicePrincipal(clientID string, clientSec
 // Logout remove azure token data
 func (login *AzureLoginService) Logout(ctx context.Context) error {
 	err := login.tokenStore.removeData()
-	if os.IsNotExist(err) {
+	if errdefs.IsNotFoundError(err) {
 		return errors.New("No Azure login data to be removed")
 	}
 	return err
 }
 
-func (login *AzureLoginService) getTenantAndValidateLogin(ctx context.Context, accessToken string, refreshToken string, requestedTenantID string) error {
-	bits, statusCode, err := login.apiHelper.queryAPIWithHeader(ctx, getTenantURL, fmt.Sprintf("Bearer %s", accessToken))
+func (login *AzureLoginService) getTenantAndValidateLogin(ctx context.Context, accessToken string, refreshToken string, requestedTenantID string, cloudName string, env azure.Environment) error {
+	bits, statusCode, err := login.apiHelper.queryAPIWithHeader(ctx, tenantsURL(env), fmt.Sprintf("Bearer %s", accessToken))
 	if err != nil {
 		return errors.Wrapf(errdefs.ErrLoginFailed, "check auth failed: %s", err)
 	}
@@ -155,11 +169,11 @@ func (login *AzureLoginService) getTenantAndValidateLogin(ctx context.Context, a
 	if err != nil {
 		return errors.Wrap(errdefs.ErrLoginFailed, err.Error())
 	}
-	tToken, err := login.refreshToken(refreshToken, tenantID)
+	tToken, err := login.refreshToken(refreshToken, tenantID, env)
 	if err != nil {
 		return errors.Wrapf(errdefs.ErrLoginFailed, "unable to refresh token: %s", err)
 	}
-	loginInfo := TokenInfo{TenantID: tenantID, Token: tToken}
+	loginInfo := TokenInfo{TenantID: tenantID, Token: tToken, CloudName: cloudName}
 
 	if err := login.tokenStore.writeLoginInfo(loginInfo); err != nil {
 		return errors.Wrapf(errdefs.ErrLoginFailed, "could not store login info: %s", err)
@@ -168,7 +182,11 @@ func (login *AzureLoginService) getTenantAndValidateLogin(ctx context.Context, a
 }
 
 // Login performs an Azure login through a web browser
-func (login *AzureLoginService) Login(ctx context.Context, requestedTenantID string) error {
+func (login *AzureLoginService) Login(ctx context.Context, requestedTenantID string, cloudName string) error {
+	env, err := CloudEnvironment(cloudName)
+	if err != nil {
+		return err
+	}
 	queryCh := make(chan localResponse, 1)
 	s, err := NewLocalServer(queryCh)
 	if err != nil {
@@ -183,8 +201,8 @@ func (login *AzureLoginService) Login(ctx context.Context, requestedTenantID str
 	}
 
 	deviceCodeFlowCh := make(chan deviceCodeFlowResponse, 1)
-	if err = login.apiHelper.openAzureLoginPage(redirectURL); err != nil {
-		login.startDeviceCodeFlow(deviceCodeFlowCh)
+	if err = login.apiHelper.openAzureLoginPage(redirectURL, env); err != nil {
+		login.startDeviceCodeFlow(deviceCodeFlowCh, env)
 	}
 
 	select {
@@ -195,7 +213,7 @@ func (login *AzureLoginService) Login(ctx context.Context, requestedTenantID str
 			return errors.Wrapf(errdefs.ErrLoginFailed, "could not get token using device code flow: %s", err)
 		}
 		token := dcft.token
-		return login.getTenantAndValidateLogin(ctx, token.AccessToken, token.RefreshToken, requestedTenantID)
+		return login.getTenantAndValidateLogin(ctx, token.AccessToken, token.RefreshToken, requestedTenantID, cloudName, env)
 	case q := <-queryCh:
 		if q.err != nil {
 			return errors.Wrapf(errdefs.ErrLoginFailed, "unhandled local login server error: %s", err)
@@ -208,14 +226,14 @@ func (login *AzureLoginService) Login(ctx context.Context, requestedTenantID str
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         code,
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(env)},
 			"redirect_uri": []string{redirectURL},
 		}
-		token, err := login.apiHelper.queryToken(data, "organizations")
+		token, err := login.apiHelper.queryToken(data, "organizations", env)
 		if err != nil {
 			return errors.Wrapf(errdefs.ErrLoginFailed, "access token request failed: %s", err)
 		}
-		return login.getTenantAndValidateLogin(ctx, token.AccessToken, token.RefreshToken, requestedTenantID)
+		return login.getTenantAndValidateLogin(ctx, token.AccessToken, token.RefreshToken, requestedTenantID, cloudName, env)
 	}
 }
 
@@ -224,10 +242,10 @@ type deviceCodeFlowResponse struct {
 	err   error
 }
 
-func (login *AzureLoginService) startDeviceCodeFlow(deviceCodeFlowCh chan deviceCodeFlowResponse) {
+func (login *AzureLoginService) startDeviceCodeFlow(deviceCodeFlowCh chan deviceCodeFlowResponse, env azure.Environment) {
 	fmt.Println("Could not automatically open a browser, falling back to Azure device code flow authentication")
 	go func() {
-		token, err := login.apiHelper.getDeviceCodeFlowToken()
+		token, err := login.apiHelper.getDeviceCodeFlowToken(env)
 		if err != nil {
 			deviceCodeFlowCh <- deviceCodeFlowResponse{err: err}
 		}
@@ -291,16 +309,39 @@ func newAuthorizerFromLoginStorePath(storeTokenPath string) (autorest.Authorizer
 		return nil, errors.Wrap(err, "not logged in to azure, you need to run \"docker login azure\" first")
 	}
 
-	token := adal.Token{
-		AccessToken:  oauthToken.AccessToken,
-		Type:         oauthToken.TokenType,
-		ExpiresIn:    json.Number(strconv.Itoa(int(time.Until(oauthToken.Expiry).Seconds()))),
-		ExpiresOn:    json.Number(strconv.Itoa(int(oauthToken.Expiry.Sub(date.UnixEpoch()).Seconds()))),
-		RefreshToken: "",
-		Resource:     "",
+	return autorest.NewBearerAuthorizer(&loginTokenProvider{
+		login: login,
+		token: oauthToken,
+	}), nil
+}
+
+// loginTokenProvider provides the access token of the current login to API requests. The token is
+// refreshed when it expires, so long running operations (deployments, polling) don't fail half way.
+type loginTokenProvider struct {
+	login *AzureLoginService
+	mutex sync.Mutex
+	token oauth2.Token
+}
+
+// OAuthToken implements adal.OAuthTokenProvider
+func (p *loginTokenProvider) OAuthToken() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.token.Valid() {
+		// requests are sent with the expired token on failure, and rejected with an explicit error
+		if token, err := p.login.GetValidToken(); err == nil {
+			p.token = token
+		}
 	}
+	return p.token.AccessToken
+}
 
-	return autorest.NewBearerAuthorizer(&token), nil
+func currentCloudEnvironment() (azure.Environment, error) {
+	login, err := NewAzureLoginService()
+	if err != nil {
+		return azure.Environment{}, err
+	}
+	return login.GetCloudEnvironment()
 }
 
 // GetTenantID returns tenantID for current login
@@ -312,6 +353,15 @@ func (login AzureLoginService) GetTenantID() (string, error) {
 	return loginInfo.TenantID, err
 }
 
+// GetCloudEnvironment returns the Azure cloud environment of the current login
+func (login AzureLoginService) GetCloudEnvironment() (azure.Environment, error) {
+	loginInfo, err := login.tokenStore.readToken()
+	if err != nil {
+		return azure.Environment{}, err
+	}
+	return CloudEnvironment(loginInfo.CloudName)
+}
+
 // GetValidToken returns an access token. Refresh token if needed
 func (login *AzureLoginService) GetValidToken() (oauth2.Token, error) {
 	loginInfo, err := login.tokenStore.readToken()
@@ -322,26 +372,30 @@ func (login *AzureLoginService) GetValidToken() (oauth2.Token, error) {
 	if token.Valid() {
 		return token, nil
 	}
+	env, err := CloudEnvironment(loginInfo.CloudName)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
 	tenantID := loginInfo.TenantID
-	token, err = login.refreshToken(token.RefreshToken, tenantID)
+	token, err = login.refreshToken(token.RefreshToken, tenantID, env)
 	if err != nil {
 		return oauth2.Token{}, errors.Wrap(err, "access token request failed. Maybe you need to login to azure again.")
 	}
-	err = login.tokenStore.writeLoginInfo(TokenInfo{TenantID: tenantID, Token: token})
+	err = login.tokenStore.writeLoginInfo(TokenInfo{TenantID: tenantID, Token: token, CloudName: loginInfo.CloudName})
 	if err != nil {
 		return oauth2.Token{}, err
 	}
 	return token, nil
 }
 
-func (login *AzureLoginService) refreshToken(currentRefreshToken string, tenantID string) (oauth2.Token, error) {
+func (login *AzureLoginService) refreshToken(currentRefreshToken string, tenantID string, env azure.Environment) (oauth2.Token, error) {
 	data := url.Values{
 		"grant_type":    []string{"refresh_token"},
 		"client_id":     []string{clientID},
-		"scope":         []string{scopes},
+		"scope":         []string{scopes(env)},
 		"refresh_token": []string{currentRefreshToken},
 	}
-	token, err := login.apiHelper.queryToken(data, tenantID)
+	token, err := login.apiHelper.queryToken(data, tenantID, env)
 	if err != nil {
 		return oauth2.Token{}, err
 	}
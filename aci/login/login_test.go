@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
 
 	"github.com/stretchr/testify/mock"
 	"gotest.tools/v3/assert"
@@ -50,7 +51,7 @@ func testLoginService(t *testing.T, m *MockAzureHelper) (*AzureLoginService, err
 func TestRefreshInValidToken(t *testing.T) {
 	data := refreshTokenData("refreshToken")
 	m := &MockAzureHelper{}
-	m.On("queryToken", data, "123456").Return(azureToken{
+	m.On("queryToken", data, "123456", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "newRefreshToken",
 		AccessToken:  "newAccessToken",
 		ExpiresIn:    3600,
@@ -81,6 +82,92 @@ func TestRefreshInValidToken(t *testing.T) {
 	assert.Assert(t, time.Now().Add(3500*time.Second).Before(storedToken.Token.Expiry))
 }
 
+func TestTokenProviderRefreshesExpiredToken(t *testing.T) {
+	data := refreshTokenData("refreshToken")
+	m := &MockAzureHelper{}
+	m.On("queryToken", data, "123456", azure.PublicCloud).Return(azureToken{
+		RefreshToken: "newRefreshToken",
+		AccessToken:  "newAccessToken",
+		ExpiresIn:    3600,
+		Foci:         "1",
+	}, nil).Once()
+
+	azureLogin, err := testLoginService(t, m)
+	assert.NilError(t, err)
+	expired := oauth2.Token{
+		AccessToken:  "accessToken",
+		RefreshToken: "refreshToken",
+		Expiry:       time.Now().Add(-1 * time.Minute),
+		TokenType:    "Bearer",
+	}
+	err = azureLogin.tokenStore.writeLoginInfo(TokenInfo{TenantID: "123456", Token: expired})
+	assert.NilError(t, err)
+
+	provider := &loginTokenProvider{login: azureLogin, token: expired}
+	assert.Equal(t, provider.OAuthToken(), "newAccessToken")
+	// the refreshed token is reused for subsequent requests
+	assert.Equal(t, provider.OAuthToken(), "newAccessToken")
+	m.AssertExpectations(t)
+}
+
+func TestRefreshTokenInSovereignCloud(t *testing.T) {
+	data := url.Values{
+		"grant_type":    []string{"refresh_token"},
+		"client_id":     []string{clientID},
+		"scope":         []string{"offline_access https://management.chinacloudapi.cn/.default"},
+		"refresh_token": []string{"refreshToken"},
+	}
+	m := &MockAzureHelper{}
+	m.On("queryToken", data, "123456", azure.ChinaCloud).Return(azureToken{
+		RefreshToken: "newRefreshToken",
+		AccessToken:  "newAccessToken",
+		ExpiresIn:    3600,
+		Foci:         "1",
+	}, nil)
+
+	azureLogin, err := testLoginService(t, m)
+	assert.NilError(t, err)
+	err = azureLogin.tokenStore.writeLoginInfo(TokenInfo{
+		TenantID:  "123456",
+		CloudName: AzureChinaCloud,
+		Token: oauth2.Token{
+			AccessToken:  "accessToken",
+			RefreshToken: "refreshToken",
+			Expiry:       time.Now().Add(-1 * time.Hour),
+			TokenType:    "Bearer",
+		},
+	})
+	assert.NilError(t, err)
+
+	token, err := azureLogin.GetValidToken()
+	assert.NilError(t, err)
+	assert.Equal(t, token.AccessToken, "newAccessToken")
+
+	storedToken, _ := azureLogin.tokenStore.readToken()
+	assert.Equal(t, storedToken.CloudName, AzureChinaCloud)
+	env, err := azureLogin.GetCloudEnvironment()
+	assert.NilError(t, err)
+	assert.Equal(t, env.Name, azure.ChinaCloud.Name)
+}
+
+func TestCloudEnvironment(t *testing.T) {
+	env, err := CloudEnvironment("")
+	assert.NilError(t, err)
+	assert.Equal(t, env.Name, azure.PublicCloud.Name)
+	assert.Equal(t, tenantsURL(env), "https://management.azure.com/tenants?api-version=2019-11-01")
+
+	env, err = CloudEnvironment("azureusgovernment")
+	assert.NilError(t, err)
+	assert.Equal(t, env.Name, azure.USGovernmentCloud.Name)
+	assert.Equal(t, activeDirectoryURL(env), "https://login.microsoftonline.us")
+
+	_, err = CloudEnvironment("AzureMoonCloud")
+	assert.ErrorContains(t, err, "unknown Azure cloud \"AzureMoonCloud\", supported clouds are: AzureChinaCloud, AzureCloud, AzureUSGovernment")
+
+	assert.Assert(t, IsAcrRegistry("myregistry.azurecr.cn"))
+	assert.Assert(t, !IsAcrRegistry("myregistry.example.com"))
+}
+
 func TestClearErrorMessageIfNotAlreadyLoggedIn(t *testing.T) {
 	dir, err := ioutil.TempDir("", "test_store")
 	assert.NilError(t, err)
@@ -112,7 +199,7 @@ func TestDoesNotRefreshValidToken(t *testing.T) {
 
 func TestInvalidLogin(t *testing.T) {
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL := args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "error", "access denied: login failed")
 		assert.NilError(t, err)
@@ -121,7 +208,7 @@ func TestInvalidLogin(t *testing.T) {
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(context.TODO(), "")
+	err = azureLogin.Login(context.TODO(), "", "")
 	assert.Error(t, err, "no login code: login failed")
 }
 
@@ -129,7 +216,7 @@ func TestValidLogin(t *testing.T) {
 	var redirectURL string
 	ctx := context.TODO()
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL = args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "code", "123456879")
 		assert.NilError(t, err)
@@ -141,10 +228,10 @@ func TestValidLogin(t *testing.T) {
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         []string{"123456879"},
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(azure.PublicCloud)},
 			"redirect_uri": []string{redirectURL},
 		})
-	}), "organizations").Return(azureToken{
+	}), "organizations", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "firstRefreshToken",
 		AccessToken:  "firstAccessToken",
 		ExpiresIn:    3600,
@@ -153,9 +240,9 @@ func TestValidLogin(t *testing.T) {
 
 	authBody := `{"value":[{"id":"/tenants/12345a7c-c56d-43e8-9549-dd230ce8a038","tenantId":"12345a7c-c56d-43e8-9549-dd230ce8a038"}]}`
 
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
 	data := refreshTokenData("firstRefreshToken")
-	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038").Return(azureToken{
+	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "newRefreshToken",
 		AccessToken:  "newAccessToken",
 		ExpiresIn:    3600,
@@ -164,7 +251,7 @@ func TestValidLogin(t *testing.T) {
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "")
+	err = azureLogin.Login(ctx, "", "")
 	assert.NilError(t, err)
 
 	loginToken, err := azureLogin.tokenStore.readToken()
@@ -179,7 +266,7 @@ func TestValidLogin(t *testing.T) {
 func TestValidLoginRequestedTenant(t *testing.T) {
 	var redirectURL string
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL = args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "code", "123456879")
 		assert.NilError(t, err)
@@ -191,10 +278,10 @@ func TestValidLoginRequestedTenant(t *testing.T) {
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         []string{"123456879"},
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(azure.PublicCloud)},
 			"redirect_uri": []string{redirectURL},
 		})
-	}), "organizations").Return(azureToken{
+	}), "organizations", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "firstRefreshToken",
 		AccessToken:  "firstAccessToken",
 		ExpiresIn:    3600,
@@ -205,9 +292,9 @@ func TestValidLoginRequestedTenant(t *testing.T) {
 						   {"id":"/tenants/12345a7c-c56d-43e8-9549-dd230ce8a038","tenantId":"12345a7c-c56d-43e8-9549-dd230ce8a038"}]}`
 
 	ctx := context.TODO()
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
 	data := refreshTokenData("firstRefreshToken")
-	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038").Return(azureToken{
+	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "newRefreshToken",
 		AccessToken:  "newAccessToken",
 		ExpiresIn:    3600,
@@ -216,7 +303,7 @@ func TestValidLoginRequestedTenant(t *testing.T) {
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "12345a7c-c56d-43e8-9549-dd230ce8a038")
+	err = azureLogin.Login(ctx, "12345a7c-c56d-43e8-9549-dd230ce8a038", "")
 	assert.NilError(t, err)
 
 	loginToken, err := azureLogin.tokenStore.readToken()
@@ -231,7 +318,7 @@ func TestValidLoginRequestedTenant(t *testing.T) {
 func TestLoginNoTenant(t *testing.T) {
 	var redirectURL string
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL = args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "code", "123456879")
 		assert.NilError(t, err)
@@ -243,10 +330,10 @@ func TestLoginNoTenant(t *testing.T) {
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         []string{"123456879"},
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(azure.PublicCloud)},
 			"redirect_uri": []string{redirectURL},
 		})
-	}), "organizations").Return(azureToken{
+	}), "organizations", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "firstRefreshToken",
 		AccessToken:  "firstAccessToken",
 		ExpiresIn:    3600,
@@ -255,19 +342,19 @@ func TestLoginNoTenant(t *testing.T) {
 
 	ctx := context.TODO()
 	authBody := `{"value":[{"id":"/tenants/12345a7c-c56d-43e8-9549-dd230ce8a038","tenantId":"12345a7c-c56d-43e8-9549-dd230ce8a038"}]}`
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
 
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "00000000-c56d-43e8-9549-dd230ce8a038")
+	err = azureLogin.Login(ctx, "00000000-c56d-43e8-9549-dd230ce8a038", "")
 	assert.Error(t, err, "could not find requested azure tenant 00000000-c56d-43e8-9549-dd230ce8a038: login failed")
 }
 
 func TestLoginRequestedTenantNotFound(t *testing.T) {
 	var redirectURL string
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL = args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "code", "123456879")
 		assert.NilError(t, err)
@@ -279,10 +366,10 @@ func TestLoginRequestedTenantNotFound(t *testing.T) {
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         []string{"123456879"},
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(azure.PublicCloud)},
 			"redirect_uri": []string{redirectURL},
 		})
-	}), "organizations").Return(azureToken{
+	}), "organizations", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "firstRefreshToken",
 		AccessToken:  "firstAccessToken",
 		ExpiresIn:    3600,
@@ -291,19 +378,19 @@ func TestLoginRequestedTenantNotFound(t *testing.T) {
 
 	ctx := context.TODO()
 	authBody := `{"value":[]}`
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
 
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "")
+	err = azureLogin.Login(ctx, "", "")
 	assert.Error(t, err, "could not find azure tenant: login failed")
 }
 
 func TestLoginAuthorizationFailed(t *testing.T) {
 	var redirectURL string
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Run(func(args mock.Arguments) {
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Run(func(args mock.Arguments) {
 		redirectURL = args.Get(0).(string)
 		err := queryKeyValue(redirectURL, "code", "123456879")
 		assert.NilError(t, err)
@@ -315,10 +402,10 @@ func TestLoginAuthorizationFailed(t *testing.T) {
 			"grant_type":   []string{"authorization_code"},
 			"client_id":    []string{clientID},
 			"code":         []string{"123456879"},
-			"scope":        []string{scopes},
+			"scope":        []string{scopes(azure.PublicCloud)},
 			"redirect_uri": []string{redirectURL},
 		})
-	}), "organizations").Return(azureToken{
+	}), "organizations", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "firstRefreshToken",
 		AccessToken:  "firstAccessToken",
 		ExpiresIn:    3600,
@@ -328,26 +415,26 @@ func TestLoginAuthorizationFailed(t *testing.T) {
 	authBody := `[access denied]`
 
 	ctx := context.TODO()
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 400, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 400, nil)
 
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "")
+	err = azureLogin.Login(ctx, "", "")
 	assert.Error(t, err, "unable to login status code 400: [access denied]: login failed")
 }
 
 func TestValidThroughDeviceCodeFlow(t *testing.T) {
 	m := &MockAzureHelper{}
-	m.On("openAzureLoginPage", mock.AnythingOfType("string")).Return(errors.New("Could not open browser"))
-	m.On("getDeviceCodeFlowToken").Return(adal.Token{AccessToken: "firstAccessToken", RefreshToken: "firstRefreshToken"}, nil)
+	m.On("openAzureLoginPage", mock.AnythingOfType("string"), azure.PublicCloud).Return(errors.New("Could not open browser"))
+	m.On("getDeviceCodeFlowToken", azure.PublicCloud).Return(adal.Token{AccessToken: "firstAccessToken", RefreshToken: "firstRefreshToken"}, nil)
 
 	authBody := `{"value":[{"id":"/tenants/12345a7c-c56d-43e8-9549-dd230ce8a038","tenantId":"12345a7c-c56d-43e8-9549-dd230ce8a038"}]}`
 
 	ctx := context.TODO()
-	m.On("queryAPIWithHeader", ctx, getTenantURL, "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
+	m.On("queryAPIWithHeader", ctx, tenantsURL(azure.PublicCloud), "Bearer firstAccessToken").Return([]byte(authBody), 200, nil)
 	data := refreshTokenData("firstRefreshToken")
-	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038").Return(azureToken{
+	m.On("queryToken", data, "12345a7c-c56d-43e8-9549-dd230ce8a038", azure.PublicCloud).Return(azureToken{
 		RefreshToken: "newRefreshToken",
 		AccessToken:  "newAccessToken",
 		ExpiresIn:    3600,
@@ -356,7 +443,7 @@ func TestValidThroughDeviceCodeFlow(t *testing.T) {
 	azureLogin, err := testLoginService(t, m)
 	assert.NilError(t, err)
 
-	err = azureLogin.Login(ctx, "")
+	err = azureLogin.Login(ctx, "", "")
 	assert.NilError(t, err)
 
 	loginToken, err := azureLogin.tokenStore.readToken()
@@ -372,7 +459,7 @@ func refreshTokenData(refreshToken string) url.Values {
 	return url.Values{
 		"grant_type":    []string{"refresh_token"},
 		"client_id":     []string{clientID},
-		"scope":         []string{scopes},
+		"scope":         []string{scopes(azure.PublicCloud)},
 		"refresh_token": []string{refreshToken},
 	}
 }
@@ -394,13 +481,13 @@ type MockAzureHelper struct {
 	mock.Mock
 }
 
-func (s *MockAzureHelper) getDeviceCodeFlowToken() (adal.Token, error) {
-	args := s.Called()
+func (s *MockAzureHelper) getDeviceCodeFlowToken(env azure.Environment) (adal.Token, error) {
+	args := s.Called(env)
 	return args.Get(0).(adal.Token), args.Error(1)
 }
 
-func (s *MockAzureHelper) queryToken(data url.Values, tenantID string) (token azureToken, err error) {
-	args := s.Called(data, tenantID)
+func (s *MockAzureHelper) queryToken(data url.Values, tenantID string, env azure.Environment) (token azureToken, err error) {
+	args := s.Called(data, tenantID, env)
 	return args.Get(0).(azureToken), args.Error(1)
 }
 
@@ -409,7 +496,7 @@ func (s *MockAzureHelper) queryAPIWithHeader(ctx context.Context, authorizationU
 	return args.Get(0).([]byte), args.Int(1), args.Error(2)
 }
 
-func (s *MockAzureHelper) openAzureLoginPage(redirectURL string) error {
-	args := s.Called(redirectURL)
+func (s *MockAzureHelper) openAzureLoginPage(redirectURL string, env azure.Environment) error {
+	args := s.Called(redirectURL, env)
 	return args.Error(0)
 }
@@ -0,0 +1,143 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package login
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/progress"
+)
+
+// maxBackoff caps the delay between two attempts
+const maxBackoff = 1 * time.Minute
+
+var (
+	// maxRetries is the number of times a throttled or failing ARM request is sent again
+	maxRetries = 8
+	// initialBackoff is the delay before the first retry, doubled on every attempt
+	initialBackoff = 1 * time.Second
+	// requestTimeout bounds each attempt of an ARM request, no limit if zero
+	requestTimeout time.Duration
+)
+
+// SetRetryPolicy applies the retry policy of the context to the ARM clients created afterwards, zero values
+// keep the defaults
+func SetRetryPolicy(policy store.RetryPolicy) {
+	if policy.MaxRetries > 0 {
+		maxRetries = policy.MaxRetries
+	}
+	if policy.RetryDelay > 0 {
+		initialBackoff = policy.RetryDelay
+	}
+	if policy.Timeout > 0 {
+		requestTimeout = policy.Timeout
+	}
+}
+
+// withRetries retries ARM requests failing with a transient status, or idempotent requests failing on a
+// network error, waiting for the delay requested by Retry-After headers or with an exponential backoff.
+// Throttling is reported as a progress event, so large deployments show why they are slowing down instead
+// of failing mid-way.
+func withRetries() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			rr := autorest.NewRetriableRequest(r)
+			backoff := initialBackoff
+			for attempt := 0; ; attempt++ {
+				if err := rr.Prepare(); err != nil {
+					return nil, err
+				}
+				resp, err := s.Do(rr.Request())
+				if attempt == maxRetries || r.Context().Err() != nil {
+					return resp, err
+				}
+				if err != nil && !isIdempotent(r.Method) {
+					return resp, err
+				}
+				if err == nil && !isTransient(resp.StatusCode) {
+					return resp, err
+				}
+				delay := backoff
+				if err == nil {
+					delay = retryAfter(resp, backoff)
+					if resp.StatusCode == http.StatusTooManyRequests {
+						progress.ContextWriter(r.Context()).Event(progress.Event{
+							ID:         "Azure",
+							Status:     progress.Working,
+							StatusText: fmt.Sprintf("Throttled, retrying in %s", delay),
+						})
+					}
+					_ = autorest.DrainResponseBody(resp)
+				}
+				select {
+				case <-r.Context().Done():
+					return nil, r.Context().Err()
+				case <-time.After(delay):
+				}
+				backoff *= 2
+				if backoff > maxBackoff {
+					backoff = maxBackoff
+				}
+			}
+		})
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isTransient(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the Retry-After header, in seconds or as a date, or the backoff
+func retryAfter(resp *http.Response, backoff time.Duration) time.Duration {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return backoff
+	}
+	var delay time.Duration
+	if seconds, err := strconv.Atoi(header); err == nil {
+		delay = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		return backoff
+	}
+	if delay < 0 {
+		return 0
+	}
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
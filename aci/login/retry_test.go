@@ -0,0 +1,122 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package login
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"gotest.tools/v3/assert"
+)
+
+type responses struct {
+	statuses []int
+	bodies   []string
+}
+
+func (r *responses) Do(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		body, _ := ioutil.ReadAll(req.Body)
+		r.bodies = append(r.bodies, string(body))
+	}
+	status := r.statuses[0]
+	r.statuses = r.statuses[1:]
+	if status == 0 {
+		return nil, errors.New("connection reset by peer")
+	}
+	resp := &http.Response{
+		StatusCode: status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}
+	if status == http.StatusTooManyRequests {
+		resp.Header.Set("Retry-After", "0")
+	}
+	return resp, nil
+}
+
+func TestRetryTransientStatuses(t *testing.T) {
+	previous := initialBackoff
+	initialBackoff = time.Millisecond
+	defer func() {
+		initialBackoff = previous
+	}()
+	sender := &responses{statuses: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}}
+	req, err := http.NewRequest(http.MethodPut, "https://management.azure.com", strings.NewReader("payload"))
+	assert.NilError(t, err)
+
+	resp, err := autorest.DecorateSender(sender, withRetries()).Do(req)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+	// the body is sent again on every attempt
+	assert.DeepEqual(t, sender.bodies, []string{"payload", "payload", "payload"})
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	sender := &responses{statuses: []int{http.StatusNotFound}}
+	req, err := http.NewRequest(http.MethodGet, "https://management.azure.com", nil)
+	assert.NilError(t, err)
+
+	resp, err := autorest.DecorateSender(sender, withRetries()).Do(req)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusNotFound)
+	assert.Equal(t, len(sender.statuses), 0)
+}
+
+func TestRetryNetworkErrors(t *testing.T) {
+	previous := initialBackoff
+	initialBackoff = time.Millisecond
+	defer func() {
+		initialBackoff = previous
+	}()
+	sender := &responses{statuses: []int{0, http.StatusOK}}
+	req, err := http.NewRequest(http.MethodGet, "https://management.azure.com", nil)
+	assert.NilError(t, err)
+
+	resp, err := autorest.DecorateSender(sender, withRetries()).Do(req)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	// requests which may not be idempotent aren't sent again
+	sender = &responses{statuses: []int{0, http.StatusOK}}
+	req, err = http.NewRequest(http.MethodPost, "https://management.azure.com", nil)
+	assert.NilError(t, err)
+
+	_, err = autorest.DecorateSender(sender, withRetries()).Do(req)
+	assert.ErrorContains(t, err, "connection reset by peer")
+	assert.Equal(t, len(sender.statuses), 1)
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, retryAfter(resp, time.Second), time.Second)
+
+	resp.Header.Set("Retry-After", "12")
+	assert.Equal(t, retryAfter(resp, time.Second), 12*time.Second)
+
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, retryAfter(resp, time.Second), maxBackoff)
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	assert.Equal(t, retryAfter(resp, time.Second), time.Duration(0))
+}
@@ -19,23 +19,25 @@ package login
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 
 	"github.com/Azure/go-autorest/autorest/azure/cli"
-
 	"golang.org/x/oauth2"
+
+	"github.com/docker/compose-cli/context/credentials"
 )
 
 type tokenStore struct {
 	filePath string
+	secrets  credentials.Store
 }
 
 // TokenInfo data stored in tokenStore
 type TokenInfo struct {
-	Token    oauth2.Token `json:"oauthToken"`
-	TenantID string       `json:"tenantId"`
+	Token     oauth2.Token `json:"oauthToken"`
+	TenantID  string       `json:"tenantId"`
+	CloudName string       `json:"cloudName,omitempty"`
 }
 
 func newTokenStore(path string) (tokenStore, error) {
@@ -56,6 +58,7 @@ func newTokenStore(path string) (tokenStore, error) {
 	}
 	return tokenStore{
 		filePath: path,
+		secrets:  credentials.NewStore(parentFolder),
 	}, nil
 }
 
@@ -70,11 +73,11 @@ func (store tokenStore) writeLoginInfo(info TokenInfo) error {
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(store.filePath, bytes, 0644)
+	return store.secrets.Set(store.key(), bytes)
 }
 
 func (store tokenStore) readToken() (TokenInfo, error) {
-	bytes, err := ioutil.ReadFile(store.filePath)
+	bytes, err := store.secrets.Get(store.key())
 	if err != nil {
 		return TokenInfo{}, err
 	}
@@ -86,5 +89,9 @@ func (store tokenStore) readToken() (TokenInfo, error) {
 }
 
 func (store tokenStore) removeData() error {
-	return os.Remove(store.filePath)
+	return store.secrets.Delete(store.key())
+}
+
+func (store tokenStore) key() string {
+	return filepath.Base(store.filePath)
 }
@@ -0,0 +1,79 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package aci
+
+import (
+	"strings"
+	"time"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// timestampsAPIVersion is the first ACI API version prefixing the log lines with their timestamp on request
+const timestampsAPIVersion = "2019-12-01"
+
+// logsQuery selects the logs of a container, ACI sends the tail and the lines are filtered on their timestamp
+type logsQuery struct {
+	tail  *int32
+	since time.Time
+	until time.Time
+}
+
+func newLogsQuery(tail, since, until string, now time.Time) (logsQuery, error) {
+	var (
+		query logsQuery
+		err   error
+	)
+	if query.tail, err = logsTail(tail); err != nil {
+		return query, err
+	}
+	if since != "" {
+		if query.since, err = compose.ParseLogTime(since, now); err != nil {
+			return query, err
+		}
+	}
+	if until != "" {
+		if query.until, err = compose.ParseLogTime(until, now); err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+func (q logsQuery) timeBounded() bool {
+	return !q.since.IsZero() || !q.until.IsZero()
+}
+
+// filterLogsByTime keeps the timestamped lines in the time range, removing their timestamp. The lines without
+// timestamp, continuing a multi-line message, are kept along with the line they continue.
+func filterLogsByTime(logs string, since, until time.Time) string {
+	var b strings.Builder
+	keep := false
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		fields := strings.SplitN(text, " ", 2)
+		if t, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+			keep = (since.IsZero() || !t.Before(since)) && (until.IsZero() || t.Before(until))
+			line = strings.TrimPrefix(line, fields[0])
+			line = strings.TrimPrefix(line, " ")
+		}
+		if keep {
+			b.WriteString(line)
+		}
+	}
+	return b.String()
+}
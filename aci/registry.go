@@ -0,0 +1,98 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package aci
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerregistry/mgmt/2019-05-01/containerregistry"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/aci/login"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/progress"
+)
+
+// EnsureImageRepository creates, or reuses, the container registry of the resource group. Images are pushed
+// to a repository named after the project and service.
+func (cs *aciComposeService) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	registriesClient, err := login.NewContainerRegistriesClient(cs.ctx.SubscriptionID)
+	if err != nil {
+		return compose.ImageRepository{}, err
+	}
+	name := registryName(cs.ctx)
+	w := progress.ContextWriter(ctx)
+	registry, err := registriesClient.Get(ctx, cs.ctx.ResourceGroup, name)
+	if err != nil {
+		if !registry.HasHTTPStatus(http.StatusNotFound) {
+			return compose.ImageRepository{}, err
+		}
+		w.Event(event(name, progress.Working, "Creating"))
+		future, err := registriesClient.Create(ctx, cs.ctx.ResourceGroup, name, containerregistry.Registry{
+			Location: to.StringPtr(cs.ctx.Location),
+			Sku: &containerregistry.Sku{
+				Name: containerregistry.Basic,
+			},
+			RegistryProperties: &containerregistry.RegistryProperties{
+				AdminUserEnabled: to.BoolPtr(true),
+			},
+		})
+		if err != nil {
+			w.Event(errorEvent(name))
+			return compose.ImageRepository{}, err
+		}
+		if err := future.WaitForCompletionRef(ctx, registriesClient.Client); err != nil {
+			w.Event(errorEvent(name))
+			return compose.ImageRepository{}, err
+		}
+		registry, err = future.Result(registriesClient)
+		if err != nil {
+			w.Event(errorEvent(name))
+			return compose.ImageRepository{}, err
+		}
+		w.Event(event(name, progress.Done, "Created"))
+	}
+	if registry.RegistryProperties == nil || registry.LoginServer == nil {
+		return compose.ImageRepository{}, errors.Errorf("container registry %q has no login server", name)
+	}
+
+	credentials, err := registriesClient.ListCredentials(ctx, cs.ctx.ResourceGroup, name)
+	if err != nil {
+		return compose.ImageRepository{}, err
+	}
+	if credentials.Passwords == nil || len(*credentials.Passwords) == 0 {
+		return compose.ImageRepository{}, errors.Errorf("container registry %q has no admin password", name)
+	}
+	return compose.ImageRepository{
+		Name:     strings.ToLower(fmt.Sprintf("%s/%s/%s", *registry.LoginServer, projectName, service)),
+		Username: to.String(credentials.Username),
+		Password: to.String((*credentials.Passwords)[0].Value),
+	}, nil
+}
+
+// registryName returns the name of the registry of a resource group, registry names are alphanumeric and
+// must be unique across Azure
+func registryName(aciContext store.AciContext) string {
+	hash := sha256.Sum256([]byte(aciContext.SubscriptionID + "/" + aciContext.ResourceGroup))
+	return fmt.Sprintf("compose%x", hash[:8])
+}
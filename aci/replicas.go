@@ -0,0 +1,159 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package aci
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/context/store"
+)
+
+// composeReplicaTag marks the additional container groups running a replica of a compose application,
+// its value is the name of the application
+const composeReplicaTag = "docker-compose-replica-of"
+
+// getReplicas returns the number of container groups running the application. All services of an
+// application run in the same container group, so services must agree on `deploy.replicas`.
+func getReplicas(project types.Project) (int, error) {
+	replicas := 1
+	declared := ""
+	for _, service := range project.Services {
+		if service.Deploy == nil || service.Deploy.Replicas == nil {
+			continue
+		}
+		r := int(*service.Deploy.Replicas)
+		if declared != "" && r != replicas {
+			return 0, fmt.Errorf("ACI integration requires services to declare the same number of replicas, found %d on service %q and %d on service %q",
+				replicas, declared, r, service.Name)
+		}
+		replicas = r
+		declared = service.Name
+	}
+	if replicas < 1 {
+		return 0, fmt.Errorf("ACI integration does not support %d replicas", replicas)
+	}
+	return replicas, nil
+}
+
+// replicaGroup returns the definition of a replica of the application container group, the first
+// replica being the container group itself. Replicas get a distinct name and DNS label.
+func replicaGroup(group containerinstance.ContainerGroup, project string, replica int) containerinstance.ContainerGroup {
+	if replica == 1 {
+		return group
+	}
+	group.Name = to.StringPtr(fmt.Sprintf("%s-%d", project, replica))
+	tags := map[string]*string{}
+	for k, v := range group.Tags {
+		tags[k] = v
+	}
+	tags[composeReplicaTag] = to.StringPtr(project)
+	group.Tags = tags
+
+	if group.ContainerGroupProperties != nil && group.IPAddress != nil {
+		properties := *group.ContainerGroupProperties
+		ip := *properties.IPAddress
+		if ip.DNSNameLabel != nil {
+			ip.DNSNameLabel = to.StringPtr(fmt.Sprintf("%s-%d", *ip.DNSNameLabel, replica))
+		}
+		properties.IPAddress = &ip
+		group.ContainerGroupProperties = &properties
+	}
+	return group
+}
+
+// replicaIndex returns the replica number of an additional container group of the application
+func replicaIndex(group containerinstance.ContainerGroup, project string) (int, bool) {
+	if group.Name == nil {
+		return 0, false
+	}
+	if of, ok := group.Tags[composeReplicaTag]; !ok || of == nil || *of != project {
+		return 0, false
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(*group.Name, project+"-"))
+	if err != nil {
+		return 0, false
+	}
+	return index, true
+}
+
+// getReplicaGroups lists the additional container groups running replicas of the application
+func getReplicaGroups(ctx context.Context, aciContext store.AciContext, project string) ([]containerinstance.ContainerGroup, error) {
+	groups, err := getACIContainerGroups(ctx, aciContext.SubscriptionID, aciContext.ResourceGroup)
+	if err != nil {
+		return nil, err
+	}
+	var replicas []containerinstance.ContainerGroup
+	for _, group := range groups {
+		if _, ok := replicaIndex(group, project); ok {
+			replicas = append(replicas, group)
+		}
+	}
+	return replicas, nil
+}
+
+// removeReplicas deletes the container groups running replicas above the given count
+func removeReplicas(ctx context.Context, aciContext store.AciContext, project string, count int) error {
+	replicas, err := getReplicaGroups(ctx, aciContext, project)
+	if err != nil {
+		return err
+	}
+	for _, group := range replicas {
+		if index, _ := replicaIndex(group, project); index <= count {
+			continue
+		}
+		if _, err := deleteACIContainerGroup(ctx, aciContext, *group.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregateServiceStatus merges the status of the service containers running in the replicas
+func aggregateServiceStatus(statuses []compose.ServiceStatus) []compose.ServiceStatus {
+	var (
+		names  []string
+		merged = map[string]*compose.ServiceStatus{}
+	)
+	for _, s := range statuses {
+		status, ok := merged[s.Name]
+		if !ok {
+			s := s
+			merged[s.Name] = &s
+			names = append(names, s.Name)
+			continue
+		}
+		status.Replicas += s.Replicas
+		status.Desired += s.Desired
+		status.Unhealthy += s.Unhealthy
+		status.Ports = append(status.Ports, s.Ports...)
+		status.Publishers = append(status.Publishers, s.Publishers...)
+		status.Containers = append(status.Containers, s.Containers...)
+	}
+	res := []compose.ServiceStatus{}
+	for _, name := range names {
+		res = append(res, *merged[name])
+	}
+	return res
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package aci
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/containerinstance/mgmt/2018-10-01/containerinstance"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func deployReplicas(n uint64) *types.DeployConfig {
+	return &types.DeployConfig{Replicas: &n}
+}
+
+func TestGetReplicas(t *testing.T) {
+	replicas, err := getReplicas(types.Project{Services: []types.ServiceConfig{{Name: "front"}}})
+	assert.NilError(t, err)
+	assert.Equal(t, replicas, 1)
+
+	replicas, err = getReplicas(types.Project{Services: []types.ServiceConfig{
+		{Name: "front", Deploy: deployReplicas(3)},
+		{Name: "back"},
+		{Name: "db", Deploy: deployReplicas(3)},
+	}})
+	assert.NilError(t, err)
+	assert.Equal(t, replicas, 3)
+
+	_, err = getReplicas(types.Project{Services: []types.ServiceConfig{
+		{Name: "front", Deploy: deployReplicas(3)},
+		{Name: "back", Deploy: deployReplicas(2)},
+	}})
+	assert.Error(t, err, `ACI integration requires services to declare the same number of replicas, found 3 on service "front" and 2 on service "back"`)
+}
+
+func TestReplicaGroup(t *testing.T) {
+	group := containerinstance.ContainerGroup{
+		Name: to.StringPtr("myapp"),
+		Tags: map[string]*string{composeContainerTag: to.StringPtr(composeContainerTag)},
+		ContainerGroupProperties: &containerinstance.ContainerGroupProperties{
+			IPAddress: &containerinstance.IPAddress{
+				DNSNameLabel: to.StringPtr("myapp"),
+			},
+		},
+	}
+
+	assert.DeepEqual(t, replicaGroup(group, "myapp", 1), group)
+
+	replica := replicaGroup(group, "myapp", 2)
+	assert.Equal(t, *replica.Name, "myapp-2")
+	assert.Equal(t, *replica.IPAddress.DNSNameLabel, "myapp-2")
+	assert.Equal(t, *replica.Tags[composeReplicaTag], "myapp")
+	index, ok := replicaIndex(replica, "myapp")
+	assert.Assert(t, ok)
+	assert.Equal(t, index, 2)
+
+	// the application container group is left untouched
+	assert.Equal(t, *group.IPAddress.DNSNameLabel, "myapp")
+	_, ok = group.Tags[composeReplicaTag]
+	assert.Assert(t, !ok)
+	_, ok = replicaIndex(group, "myapp")
+	assert.Assert(t, !ok)
+}
+
+func TestAggregateServiceStatus(t *testing.T) {
+	statuses := aggregateServiceStatus([]compose.ServiceStatus{
+		{Name: "front", Replicas: 1, Desired: 1, Ports: []string{"1.2.3.4:80->80/tcp"}},
+		{Name: "back", Replicas: 1, Desired: 1},
+		{Name: "front", Replicas: 0, Desired: 1, Ports: []string{"1.2.3.5:80->80/tcp"}},
+		{Name: "back", Replicas: 1, Desired: 1},
+	})
+	assert.DeepEqual(t, statuses, []compose.ServiceStatus{
+		{Name: "front", Replicas: 1, Desired: 2, Ports: []string{"1.2.3.4:80->80/tcp", "1.2.3.5:80->80/tcp"}},
+		{Name: "back", Replicas: 2, Desired: 2},
+	})
+}
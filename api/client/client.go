@@ -109,6 +109,11 @@ func (c *Client) VolumeService() volumes.Service {
 	return &volumeService{}
 }
 
+// Ping checks the backend for the current context is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.bs.Ping(ctx)
+}
+
 // ResourceService returns the backend service for the current context
 func (c *Client) ResourceService() resources.Service {
 	if vs := c.bs.ResourceService(); vs != nil {
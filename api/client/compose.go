@@ -30,7 +30,12 @@ type composeService struct {
 }
 
 // Up executes the equivalent to a `compose up`
-func (c *composeService) Up(context.Context, *types.Project, bool) error {
+func (c *composeService) Up(context.Context, *types.Project, compose.UpOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Pull executes the equivalent of a `compose pull`
+func (c *composeService) Pull(context.Context, *types.Project) error {
 	return errdefs.ErrNotImplemented
 }
 
@@ -39,8 +44,18 @@ func (c *composeService) Down(context.Context, string) error {
 	return errdefs.ErrNotImplemented
 }
 
+// UpPlan prepares the changes `compose up` applies to an existing deployment
+func (c *composeService) UpPlan(context.Context, *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errdefs.ErrNotImplemented
+}
+
+// DownPlan lists the resources `compose down` removes and those it preserves
+func (c *composeService) DownPlan(context.Context, string) ([]compose.DownResource, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
 // Logs executes the equivalent to a `compose logs`
-func (c *composeService) Logs(context.Context, string, io.Writer) error {
+func (c *composeService) Logs(context.Context, string, io.Writer, compose.LogOptions) error {
 	return errdefs.ErrNotImplemented
 }
 
@@ -58,3 +73,63 @@ func (c *composeService) List(context.Context, string) ([]compose.Stack, error)
 func (c *composeService) Convert(context.Context, *types.Project, string) ([]byte, error) {
 	return nil, errdefs.ErrNotImplemented
 }
+
+// RunOneOffContainer creates a service oneoff container and attaches to its io streams
+func (c *composeService) RunOneOffContainer(context.Context, *types.Project, compose.RunOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Exec executes a command in a running service container
+func (c *composeService) Exec(context.Context, string, compose.ExecOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Attach connects to the streams of the main process of a running service container
+func (c *composeService) Attach(context.Context, string, compose.AttachOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Inspect returns runtime details about the containers of a service
+func (c *composeService) Inspect(context.Context, string, string) ([]compose.ContainerInspect, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+// Wait blocks until the containers of the selected services exit
+func (c *composeService) Wait(context.Context, string, []string) ([]compose.ContainerExit, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+// Stats reports the resource usage of the services
+func (c *composeService) Stats(context.Context, string, compose.StatsOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Watch applies the develop.watch rules of the services
+func (c *composeService) Watch(context.Context, *types.Project, compose.WatchOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Stop stops the running containers of the project
+func (c *composeService) Stop(context.Context, *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Kill forcibly stops the running containers of the project
+func (c *composeService) Kill(context.Context, string) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Scale adjusts the number of containers of running services
+func (c *composeService) Scale(context.Context, *types.Project, compose.ScaleOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Capabilities reports the compose specification features the backend supports
+func (c *composeService) Capabilities(context.Context) (compose.Capabilities, error) {
+	return compose.Capabilities{}, errdefs.ErrNotImplemented
+}
+
+// EnsureImageRepository creates, or reuses, the registry repository of the image of a service
+func (c *composeService) EnsureImageRepository(context.Context, string, string) (compose.ImageRepository, error) {
+	return compose.ImageRepository{}, errdefs.ErrNotImplemented
+}
@@ -18,6 +18,7 @@ package client
 
 import (
 	"context"
+	"io"
 
 	"github.com/docker/compose-cli/api/containers"
 	"github.com/docker/compose-cli/errdefs"
@@ -69,3 +70,43 @@ func (c *containerService) Delete(context.Context, string, containers.DeleteRequ
 func (c *containerService) Inspect(context.Context, string) (containers.Container, error) {
 	return containers.Container{}, errdefs.ErrNotImplemented
 }
+
+// Checkpoint saves the state of a running container
+func (c *containerService) Checkpoint(context.Context, string, containers.CheckpointRequest) error {
+	return errdefs.ErrNotImplemented
+}
+
+// ListCheckpoints returns the checkpoints of a container
+func (c *containerService) ListCheckpoints(context.Context, string, string) ([]containers.Checkpoint, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+// Restore starts a container from a checkpoint
+func (c *containerService) Restore(context.Context, string, containers.RestoreRequest) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Commit creates an image from the changes of a container
+func (c *containerService) Commit(context.Context, string, containers.CommitRequest) (string, error) {
+	return "", errdefs.ErrNotImplemented
+}
+
+// Wait blocks until a container exits
+func (c *containerService) Wait(context.Context, string) (int64, error) {
+	return 0, errdefs.ErrNotImplemented
+}
+
+// Diff returns the changes made to the filesystem of a container
+func (c *containerService) Diff(context.Context, string) ([]containers.FilesystemChange, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+// Export writes the filesystem of a container as a tar archive
+func (c *containerService) Export(context.Context, string, io.Writer) error {
+	return errdefs.ErrNotImplemented
+}
+
+// Import creates an image from a filesystem tar archive
+func (c *containerService) Import(context.Context, containers.ImportRequest) (string, error) {
+	return "", errdefs.ErrNotImplemented
+}
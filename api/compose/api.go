@@ -19,24 +19,279 @@ package compose
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/compose-spec/compose-go/types"
 )
 
+// OptionalDependenciesExtension lists the `depends_on` entries of a service declared with `required: false`
+const OptionalDependenciesExtension = "x-optional-depends-on"
+
+// ConfigContentExtension holds the inline `content` of a config, materialized into a file by backends
+const ConfigContentExtension = "x-content"
+
+// EnableIPv6Extension enables IPv6 networking on a project network declared with `enable_ipv6`
+const EnableIPv6Extension = "x-enable-ipv6"
+
+// PortHostIPsExtension maps the `published:target/protocol` ports of a service to the host IP they are published on
+const PortHostIPsExtension = "x-port-host-ips"
+
+// PullPolicyExtension holds the `pull_policy` of a service
+const PullPolicyExtension = "x-pull-policy"
+
+// ContainerNameTemplateExtension is a project level Go template naming the service containers, from the
+// `.Project` name, the `.Service` name and the container `.Number`, e.g. "{{.Project}}-{{.Service}}-{{.Number}}"
+const ContainerNameTemplateExtension = "x-container-name-template"
+
+const (
+	// PullPolicyAlways pulls the service image before creating containers, even when available locally
+	PullPolicyAlways = "always"
+	// PullPolicyMissing pulls the service image when it is not available locally
+	PullPolicyMissing = "missing"
+	// PullPolicyNever requires the service image to be available locally
+	PullPolicyNever = "never"
+)
+
 // Service manages a compose project
 type Service interface {
 	// Up executes the equivalent to a `compose up`
-	Up(ctx context.Context, project *types.Project, detach bool) error
+	Up(ctx context.Context, project *types.Project, options UpOptions) error
+	// UpPlan prepares the changes `compose up` applies to an existing deployment, to be reviewed before they are applied
+	UpPlan(ctx context.Context, project *types.Project) (ChangePlan, error)
+	// Pull executes the equivalent of a `compose pull`
+	Pull(ctx context.Context, project *types.Project) error
 	// Down executes the equivalent to a `compose down`
 	Down(ctx context.Context, projectName string) error
+	// DownPlan lists the resources `compose down` removes and those it preserves
+	DownPlan(ctx context.Context, projectName string) ([]DownResource, error)
 	// Logs executes the equivalent to a `compose logs`
-	Logs(ctx context.Context, projectName string, w io.Writer) error
+	Logs(ctx context.Context, projectName string, w io.Writer, options LogOptions) error
 	// Ps executes the equivalent to a `compose ps`
 	Ps(ctx context.Context, projectName string) ([]ServiceStatus, error)
 	// List executes the equivalent to a `docker stack ls`
 	List(ctx context.Context, projectName string) ([]Stack, error)
 	// Convert translate compose model into backend's native format
 	Convert(ctx context.Context, project *types.Project, format string) ([]byte, error)
+	// RunOneOffContainer creates a service oneoff container and attaches to its io streams
+	RunOneOffContainer(ctx context.Context, project *types.Project, opts RunOptions) error
+	// Exec executes a command in a running service container
+	Exec(ctx context.Context, projectName string, opts ExecOptions) error
+	// Attach connects to the streams of the main process of a running service container
+	Attach(ctx context.Context, projectName string, opts AttachOptions) error
+	// Inspect returns runtime details about the containers of a service
+	Inspect(ctx context.Context, projectName string, service string) ([]ContainerInspect, error)
+	// Wait blocks until the containers of the selected services exit
+	Wait(ctx context.Context, projectName string, services []string) ([]ContainerExit, error)
+	// Stats reports the resource usage of the services, summed over their replicas
+	Stats(ctx context.Context, projectName string, options StatsOptions) error
+	// Watch applies the `develop.watch` rules of the services when the files they watch change, until the context is done
+	Watch(ctx context.Context, project *types.Project, options WatchOptions) error
+	// Stop stops the running containers of the project, the services depending on others being stopped first
+	Stop(ctx context.Context, project *types.Project) error
+	// Kill forcibly stops the running containers of the project
+	Kill(ctx context.Context, projectName string) error
+	// Scale adjusts the number of containers of running services, without converging the rest of the project
+	Scale(ctx context.Context, project *types.Project, options ScaleOptions) error
+	// Capabilities reports the compose specification features the backend supports
+	Capabilities(ctx context.Context) (Capabilities, error)
+	// EnsureImageRepository creates, or reuses, the registry repository the image of a service built locally is
+	// pushed to before the project is deployed
+	EnsureImageRepository(ctx context.Context, projectName string, service string) (ImageRepository, error)
+}
+
+// ImageRepository is a registry repository provisioned by a backend for the image of a service
+type ImageRepository struct {
+	// Name is the reference of the repository, without tag, e.g. 123456789012.dkr.ecr.eu-west-1.amazonaws.com/myproject/web
+	Name string
+	// Username and Password authenticate the push of the image to the registry
+	Username string
+	Password string
+}
+
+// WatchOptions group options of the Watch API
+type WatchOptions struct {
+	// Services restricts the watch rules applied to the selected services, all services when empty
+	Services []string
+	// Out receives a message for every applied action
+	Out io.Writer
+}
+
+// ScaleOptions group options of the Scale API
+type ScaleOptions struct {
+	// Services maps the services to scale to their number of replicas
+	Services map[string]int
+}
+
+// StatsOptions group options of the Stats API
+type StatsOptions struct {
+	// Stream keeps reporting resource usage until the context is done
+	Stream bool
+	// Consumer receives the resource usage of all services on every sample
+	Consumer func([]ServiceStats)
+}
+
+// ServiceStats holds the resource usage of a service, summed over its replicas
+type ServiceStats struct {
+	Service       string
+	Replicas      int
+	CPUPercentage float64
+	MemoryUsage   uint64
+	MemoryLimit   uint64
+}
+
+// ContainerInspect holds runtime details about a service container
+type ContainerInspect struct {
+	ID     string
+	Name   string
+	State  string
+	Health string `json:",omitempty"`
+	// Networks maps the networks the container is connected to with its IP address
+	Networks map[string]string `json:",omitempty"`
+	Mounts   []MountPoint      `json:",omitempty"`
+}
+
+const (
+	// ContainerResource is a container of a project
+	ContainerResource = "container"
+	// NetworkResource is a network a project is connected to
+	NetworkResource = "network"
+	// VolumeResource is a volume a project mounts
+	VolumeResource = "volume"
+)
+
+// DownResource describes what `compose down` does with a resource used by a project
+type DownResource struct {
+	Type   string
+	Name   string
+	Remove bool
+	// Reason explains why the resource is preserved
+	Reason string `json:",omitempty"`
+}
+
+// ContainerExit holds the exit code of a service container
+type ContainerExit struct {
+	Name     string
+	Service  string
+	ExitCode int64
+}
+
+// MountPoint describes a volume or bind mount of a container
+type MountPoint struct {
+	Type        string
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// ExecOptions options to execute compose exec
+type ExecOptions struct {
+	Service string
+	// Index selects the service replica, starting at 1
+	Index   int
+	Command []string
+	// Environment sets variables, as KEY=VALUE, in the environment of the command
+	Environment []string
+	// Detach runs the command in the background, without attaching to its streams
+	Detach bool
+	Tty    bool
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// AttachOptions options to execute compose attach
+type AttachOptions struct {
+	Service string
+	// Index selects the service replica, starting at 1
+	Index int
+	// DetachKeys overrides the key sequence detaching from the container, the backend default when empty
+	DetachKeys string
+	// Tty is set when the streams are attached to a terminal
+	Tty bool
+	// Stdin is forwarded to the container, not attached when nil
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// UpOptions group options of the Up API
+type UpOptions struct {
+	// Detach doesn't wait for the project to be stopped
+	Detach bool
+	// WaitTimeout overrides how long the dependencies healthchecks are waited for, no limit if zero
+	WaitTimeout time.Duration
+	// HealthInterval overrides how often the dependencies health is checked
+	HealthInterval time.Duration
+	// Plan is the ID of a plan returned by UpPlan to apply, changes are computed again when empty
+	Plan string
+	// Pull overrides the pull policy of the services, see PullPolicyAlways, PullPolicyMissing and PullPolicyNever
+	Pull string
+	// OnStatus is notified of the status of the services while the dependency graph is walked, by the backends walking it
+	OnStatus WalkStatusFunc
+}
+
+// ChangePlan lists the changes `compose up` applies to the resources of a deployment
+type ChangePlan struct {
+	// ID identifies the plan for UpOptions.Plan, empty when there is no existing deployment to change
+	ID      string
+	Changes []ResourceChange
+}
+
+const (
+	// ChangeAdd is the action of a resource created by `compose up`
+	ChangeAdd = "Add"
+	// ChangeModify is the action of a resource updated by `compose up`
+	ChangeModify = "Modify"
+	// ChangeRemove is the action of a resource deleted by `compose up`
+	ChangeRemove = "Remove"
+)
+
+// ResourceChange describes the change of a resource of a deployment
+type ResourceChange struct {
+	Action string
+	Type   string
+	Name   string
+	// Replacement is set when the resource is deleted and created again
+	Replacement bool `json:",omitempty"`
+}
+
+// RunOptions options to execute compose run
+type RunOptions struct {
+	Service string
+	Command []string
+	Detach  bool
+	Tty     bool
+	// ServicePorts publishes the ports declared by the service
+	ServicePorts bool
+	// UseAliases connects the container to networks with the service aliases
+	UseAliases bool
+	// AutoRemove removes the container when it exits
+	AutoRemove  bool
+	Entrypoint  []string
+	User        string
+	Environment []string
+	WorkingDir  string
+	// Volumes are additional volumes to mount, using the `SOURCE:TARGET[:MODE]` syntax
+	Volumes []string
+	// Pull overrides the pull policy of the service, see PullPolicyAlways, PullPolicyMissing and PullPolicyNever
+	Pull   string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// LogOptions defines optional parameters for the `Logs` API
+type LogOptions struct {
+	// Services restricts logs to the selected services, all services when empty
+	Services []string
+	// Filter is a regular expression log lines must match to be displayed, all lines when empty
+	Filter string
+	// Tail is the number of lines to show from the end of the logs of each container, all lines when empty or "all"
+	Tail string
+	// Since shows logs since a timestamp (e.g. 2013-01-02T13:23:37) or a relative duration (e.g. 42m), all logs when empty
+	Since string
+	// Until shows logs before a timestamp or a relative duration, and stops following the logs once reached
+	Until string
 }
 
 // PortPublisher hold status about published port
@@ -55,6 +310,19 @@ type ServiceStatus struct {
 	Desired    int
 	Ports      []string
 	Publishers []PortPublisher
+	// Unhealthy is the number of replicas reported unhealthy by their healthcheck
+	Unhealthy  int
+	Containers []ContainerSummary
+}
+
+// ContainerSummary hold status about a single service replica
+type ContainerSummary struct {
+	ID     string
+	Name   string
+	State  string
+	Health string
+	Ports  []string
+	Labels map[string]string
 }
 
 const (
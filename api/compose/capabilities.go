@@ -0,0 +1,240 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// Compose specification features a backend may not support
+const (
+	FeatureBuild         = "build"
+	FeatureConfigs       = "configs"
+	FeatureContainerName = "container_name"
+	FeatureDevelop       = "develop"
+	FeatureGPUs          = "gpus"
+	FeatureHealthcheck   = "healthcheck"
+	FeatureNetworks      = "networks"
+	FeaturePrivileged    = "privileged"
+	FeaturePullPolicy    = "pull_policy"
+	FeatureScale         = "scale"
+	FeatureSecrets       = "secrets"
+	FeatureVolumes       = "volumes"
+)
+
+// Features lists the compose specification features reported by the Capabilities API
+var Features = []string{
+	FeatureBuild,
+	FeatureConfigs,
+	FeatureContainerName,
+	FeatureDevelop,
+	FeatureGPUs,
+	FeatureHealthcheck,
+	FeatureNetworks,
+	FeaturePrivileged,
+	FeaturePullPolicy,
+	FeatureScale,
+	FeatureSecrets,
+	FeatureVolumes,
+}
+
+// Capabilities reports the compose specification features a backend supports
+type Capabilities struct {
+	Backend   string
+	Supported []string
+}
+
+// Supports checks whether the backend supports a feature
+func (c Capabilities) Supports(feature string) bool {
+	for _, f := range c.Supported {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Unsupported lists, sorted, the features the backend doesn't support
+func (c Capabilities) Unsupported() []string {
+	unsupported := []string{}
+	for _, f := range Features {
+		if !c.Supports(f) {
+			unsupported = append(unsupported, f)
+		}
+	}
+	return unsupported
+}
+
+// ProjectFeatures lists, sorted, the features a project uses
+func ProjectFeatures(project *types.Project) []string {
+	used := map[string]bool{
+		FeatureConfigs:  len(project.Configs) > 0,
+		FeatureNetworks: len(project.Networks) > 0,
+		FeatureSecrets:  len(project.Secrets) > 0,
+		FeatureVolumes:  len(project.Volumes) > 0,
+	}
+	for _, s := range project.Services {
+		used[FeatureBuild] = used[FeatureBuild] || s.Build != nil
+		used[FeatureConfigs] = used[FeatureConfigs] || len(s.Configs) > 0
+		used[FeatureContainerName] = used[FeatureContainerName] || s.ContainerName != ""
+		used[FeatureDevelop] = used[FeatureDevelop] || s.Extensions[DevelopExtension] != nil
+		used[FeatureHealthcheck] = used[FeatureHealthcheck] || (s.HealthCheck != nil && !s.HealthCheck.Disable)
+		used[FeaturePrivileged] = used[FeaturePrivileged] || s.Privileged
+		used[FeaturePullPolicy] = used[FeaturePullPolicy] || s.Extensions[PullPolicyExtension] != nil
+		used[FeatureScale] = used[FeatureScale] || s.Scale > 1 || (s.Deploy != nil && s.Deploy.Replicas != nil && *s.Deploy.Replicas > 1)
+		used[FeatureSecrets] = used[FeatureSecrets] || len(s.Secrets) > 0
+		used[FeatureVolumes] = used[FeatureVolumes] || len(s.Volumes) > 0
+		if s.Deploy != nil && s.Deploy.Resources.Reservations != nil {
+			for _, d := range s.Deploy.Resources.Reservations.Devices {
+				for _, c := range d.Capabilities {
+					used[FeatureGPUs] = used[FeatureGPUs] || c == "gpu"
+				}
+			}
+		}
+	}
+	features := []string{}
+	for f, ok := range used {
+		if ok {
+			features = append(features, f)
+		}
+	}
+	sort.Strings(features)
+	return features
+}
+
+// UnsupportedFeatures lists, sorted, the features a project uses which the backend doesn't support
+func UnsupportedFeatures(project *types.Project, capabilities Capabilities) []string {
+	unsupported := []string{}
+	for _, f := range ProjectFeatures(project) {
+		if !capabilities.Supports(f) {
+			unsupported = append(unsupported, f)
+		}
+	}
+	return unsupported
+}
+
+// RemoveUnsupportedFeatures removes from the project the attributes of the features the backend doesn't support
+func RemoveUnsupportedFeatures(project *types.Project, capabilities Capabilities) {
+	removed := map[string]bool{}
+	for _, f := range UnsupportedFeatures(project, capabilities) {
+		removed[f] = true
+	}
+	if removed[FeatureConfigs] {
+		project.Configs = nil
+	}
+	if removed[FeatureNetworks] {
+		project.Networks = nil
+	}
+	if removed[FeatureSecrets] {
+		project.Secrets = nil
+	}
+	if removed[FeatureVolumes] {
+		project.Volumes = nil
+	}
+	for i, s := range project.Services {
+		if removed[FeatureBuild] {
+			s.Build = nil
+		}
+		if removed[FeatureConfigs] {
+			s.Configs = nil
+		}
+		if removed[FeatureContainerName] {
+			s.ContainerName = ""
+		}
+		if removed[FeatureDevelop] {
+			delete(s.Extensions, DevelopExtension)
+		}
+		if removed[FeatureGPUs] && s.Deploy != nil && s.Deploy.Resources.Reservations != nil {
+			devices := []types.DeviceRequest{}
+			for _, d := range s.Deploy.Resources.Reservations.Devices {
+				if !isGPURequest(d) {
+					devices = append(devices, d)
+				}
+			}
+			s.Deploy.Resources.Reservations.Devices = devices
+		}
+		if removed[FeatureHealthcheck] {
+			s.HealthCheck = nil
+		}
+		if removed[FeatureNetworks] {
+			s.Networks = nil
+		}
+		if removed[FeaturePrivileged] {
+			s.Privileged = false
+		}
+		if removed[FeaturePullPolicy] {
+			delete(s.Extensions, PullPolicyExtension)
+		}
+		if removed[FeatureScale] {
+			s.Scale = 0
+			if s.Deploy != nil {
+				s.Deploy.Replicas = nil
+			}
+		}
+		if removed[FeatureSecrets] {
+			s.Secrets = nil
+		}
+		if removed[FeatureVolumes] {
+			s.Volumes = nil
+		}
+		project.Services[i] = s
+	}
+}
+
+func isGPURequest(d types.DeviceRequest) bool {
+	for _, c := range d.Capabilities {
+		if c == "gpu" {
+			return true
+		}
+	}
+	return false
+}
+
+// BackendConfig renders projects the way a backend deploys them, without a context of the backend
+type BackendConfig struct {
+	Capabilities Capabilities
+	// Normalize applies the transformations of the backend to the project, reporting the attributes it ignores with Warn
+	Normalize func(ctx context.Context, project *types.Project) error
+}
+
+var backendConfigs = map[string]BackendConfig{}
+
+// RegisterBackendConfig makes a backend available to render projects for, registered by the backends on init
+func RegisterBackendConfig(config BackendConfig) {
+	backendConfigs[config.Capabilities.Backend] = config
+}
+
+// GetBackendConfig returns the config of a registered backend
+func GetBackendConfig(backend string) (BackendConfig, error) {
+	config, ok := backendConfigs[backend]
+	if !ok {
+		names := []string{}
+		for name := range backendConfigs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return BackendConfig{}, errors.Wrapf(errdefs.ErrNotFound, "backend %q, available backends are %s", backend, strings.Join(names, ", "))
+	}
+	return config, nil
+}
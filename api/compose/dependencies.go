@@ -0,0 +1,68 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+)
+
+// ServiceDependencies returns the services a service depends on, declared with `depends_on` or
+// implied by legacy `links`, by mounting the volumes of another service with `volumes_from` or by
+// joining the network, pid or ipc namespace of another service
+func ServiceDependencies(s types.ServiceConfig) []string {
+	var dependencies []string
+	add := func(name string) {
+		for _, d := range dependencies {
+			if d == name {
+				return
+			}
+		}
+		dependencies = append(dependencies, name)
+	}
+	for _, d := range s.GetDependencies() {
+		add(d)
+	}
+	for _, link := range s.Links {
+		add(strings.SplitN(link, ":", 2)[0])
+	}
+	for _, spec := range s.VolumesFrom {
+		if source, isContainer, _ := ParseVolumesFrom(spec); !isContainer {
+			add(source)
+		}
+	}
+	for _, mode := range []string{s.NetworkMode, s.Pid, s.Ipc} {
+		if strings.HasPrefix(mode, "service:") {
+			add(strings.TrimPrefix(mode, "service:"))
+		}
+	}
+	return dependencies
+}
+
+// ParseVolumesFrom splits a `volumes_from` entry, `service[:ro|rw]` or `container:name[:ro|rw]`, into the
+// service or container name, whether it references a container and the access mode
+func ParseVolumesFrom(spec string) (source string, isContainer bool, mode string) {
+	source = spec
+	if i := strings.LastIndex(spec, ":"); i >= 0 && (spec[i+1:] == "ro" || spec[i+1:] == "rw") {
+		source, mode = spec[:i], spec[i+1:]
+	}
+	if strings.HasPrefix(source, "container:") {
+		return strings.TrimPrefix(source, "container:"), true, mode
+	}
+	return source, false, mode
+}
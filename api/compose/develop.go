@@ -0,0 +1,85 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"encoding/json"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+)
+
+// DevelopExtension holds the `develop` section of a service
+const DevelopExtension = "x-develop"
+
+const (
+	// WatchActionSync copies the changed files into the service containers
+	WatchActionSync = "sync"
+	// WatchActionRestart restarts the service containers
+	WatchActionRestart = "restart"
+	// WatchActionRebuild rebuilds the service image and recreates its containers
+	WatchActionRebuild = "rebuild"
+)
+
+// DevelopConfig is the `develop` section of a service, configuring the development workflow
+type DevelopConfig struct {
+	Watch []Trigger `json:"watch,omitempty"`
+}
+
+// Trigger is a `develop.watch` rule, applying an action when files under a path change
+type Trigger struct {
+	// Path is the file or directory watched for changes, relative to the project working directory
+	Path string `json:"path"`
+	// Action is one of WatchActionSync, WatchActionRestart or WatchActionRebuild
+	Action string `json:"action"`
+	// Target is the path in the service containers files are synced to
+	Target string `json:"target,omitempty"`
+	// Ignore lists patterns, relative to Path, of the files which changes are ignored
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// ServiceDevelopConfig returns the `develop` section of a service, nil when the service doesn't declare one
+func ServiceDevelopConfig(s types.ServiceConfig) (*DevelopConfig, error) {
+	section, ok := s.Extensions[DevelopExtension]
+	if !ok {
+		return nil, nil
+	}
+	data, err := json.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	var config DevelopConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrapf(err, "service %q: invalid develop section", s.Name)
+	}
+	for _, trigger := range config.Watch {
+		if trigger.Path == "" {
+			return nil, errors.Errorf("service %q: develop.watch rules require a path", s.Name)
+		}
+		switch trigger.Action {
+		case WatchActionSync:
+			if trigger.Target == "" {
+				return nil, errors.Errorf("service %q: develop.watch rule for %s requires a target to sync files to", s.Name, trigger.Path)
+			}
+		case WatchActionRestart, WatchActionRebuild:
+		default:
+			return nil, errors.Errorf("service %q: invalid develop.watch action %q, expected %q, %q or %q",
+				s.Name, trigger.Action, WatchActionSync, WatchActionRestart, WatchActionRebuild)
+		}
+	}
+	return &config, nil
+}
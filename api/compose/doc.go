@@ -0,0 +1,38 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package compose defines the API to orchestrate compose projects, implemented by every backend.
+//
+// It has no dependency on the CLI nor on a terminal, which import-restrictions.yaml enforces, so
+// Go programs can depend on it to drive compose projects through a Service:
+//
+//	func redeploy(ctx context.Context, service compose.Service, project *types.Project) error {
+//		if err := service.Down(ctx, project.Name); err != nil {
+//			return err
+//		}
+//		return service.Up(ctx, project, compose.UpOptions{Detach: true})
+//	}
+//
+// The local backend runs projects on a Docker engine, it is built with the `local` build tag, so
+// programs embedding it are built with `go build -tags local`:
+//
+//	apiClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+//	service := local.NewComposeService(apiClient)
+//	err = redeploy(ctx, service, project)
+//
+// Backends report the progress of long running operations to the progress.Writer set in the
+// context, if any. Errors can be tested against the errdefs package errors.
+package compose
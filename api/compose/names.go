@@ -0,0 +1,55 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// ProjectNameExtension holds the top-level `name` of the compose file, which compose-go doesn't support
+const ProjectNameExtension = "x-project-name"
+
+var (
+	invalidProjectNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+	projectNamePrefix       = regexp.MustCompile(`^[^a-z0-9]+`)
+)
+
+// NormalizeProjectName applies the project naming rules shared by all backends: the name is
+// lowercased, characters other than letters, digits, `_` and `-` are dropped and it must start
+// with a letter or a digit
+func NormalizeProjectName(name string) string {
+	name = invalidProjectNameChars.ReplaceAllString(strings.ToLower(name), "")
+	return projectNamePrefix.ReplaceAllString(name, "")
+}
+
+// CheckProjectName returns an error if nothing is left of the project name once normalized. Backends
+// whose resource names can't contain `_` (ACI container groups, CloudFormation stacks) reject it rather
+// than renaming the project, so a project keeps the same name on all backends.
+func CheckProjectName(name string, allowUnderscore bool) error {
+	if NormalizeProjectName(name) == "" {
+		return errors.Wrapf(errdefs.ErrParsingFailed, "invalid project name %q: it must contain at least one letter or digit", name)
+	}
+	if !allowUnderscore && strings.Contains(name, "_") {
+		return errors.Wrapf(errdefs.ErrParsingFailed, "invalid project name %q: '_' is not supported by this backend, use '-' instead", name)
+	}
+	return nil
+}
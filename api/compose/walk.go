@@ -0,0 +1,412 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// WalkStatus is the state of a service while the dependency graph is walked
+type WalkStatus int
+
+const (
+	// WalkQueued the service waits for its dependencies
+	WalkQueued WalkStatus = iota
+	// WalkRunning the service is being processed
+	WalkRunning
+	// WalkDone the service has been processed
+	WalkDone
+	// WalkFailed processing the service failed
+	WalkFailed
+	// WalkSkipped the service has not been processed as one of its dependencies failed
+	WalkSkipped
+	// WalkRetrying processing the service failed and is retried, reported by the walked function
+	WalkRetrying
+)
+
+func (s WalkStatus) String() string {
+	switch s {
+	case WalkQueued:
+		return "queued"
+	case WalkRunning:
+		return "running"
+	case WalkDone:
+		return "done"
+	case WalkFailed:
+		return "failed"
+	case WalkSkipped:
+		return "skipped"
+	case WalkRetrying:
+		return "retrying"
+	default:
+		return "unknown"
+	}
+}
+
+// WalkStatusFunc is notified of the status transitions of the services, err is set for WalkFailed and WalkRetrying,
+// and for WalkSkipped with the dependency which failed
+type WalkStatusFunc func(service string, status WalkStatus, err error)
+
+// ResourceKind is the kind of the project resources services depend on
+type ResourceKind string
+
+const (
+	// ResourceNetwork a network declared by the project
+	ResourceNetwork ResourceKind = "network"
+	// ResourceVolume a named volume declared by the project
+	ResourceVolume ResourceKind = "volume"
+)
+
+// Resource is a network or volume of the project, ensured before the services using it are processed
+type Resource struct {
+	Kind ResourceKind
+	// Name is the key of the resource in the project
+	Name string
+}
+
+// key of the resource vertex, service names can't contain `:` so it doesn't collide with a service
+func (r Resource) key() string {
+	return fmt.Sprintf("%s:%s", r.Kind, r.Name)
+}
+
+func (r Resource) String() string {
+	return fmt.Sprintf("%s %q", r.Kind, r.Name)
+}
+
+// WalkOptions customizes the walk of the dependency graph
+type WalkOptions struct {
+	// EnsureResource is run for the networks and volumes of the project, before the services using them.
+	// Services using a resource which failed are skipped. Resources are not walked when it is nil.
+	EnsureResource func(context.Context, Resource) error
+	// OnStatus is notified of the status transitions of every service
+	OnStatus WalkStatusFunc
+}
+
+// Walk runs fn for the services of the project in dependency order, concurrently for the services which
+// don't depend on each other. A service is skipped when one of its required dependencies failed, the
+// failure of an optional one is only a warning.
+func Walk(ctx context.Context, project *types.Project, fn func(context.Context, types.ServiceConfig) error, options WalkOptions) error {
+	g := newWalkGraph(project, options.EnsureResource != nil)
+	if err := g.checkCycles(); err != nil {
+		return err
+	}
+
+	notify := func(service string, status WalkStatus, err error) {
+		if options.OnStatus != nil {
+			options.OnStatus(service, status, err)
+		}
+	}
+	for _, s := range project.Services {
+		notify(s.Name, WalkQueued, nil)
+	}
+
+	var (
+		lock      sync.Mutex
+		scheduled = map[string]bool{}
+	)
+	done := func(n *walkNode, state walkState) {
+		lock.Lock()
+		defer lock.Unlock()
+		n.state = state
+	}
+	eg, _ := errgroup.WithContext(ctx)
+	var schedule func(nodes []*walkNode)
+	schedule = func(nodes []*walkNode) {
+		for _, node := range nodes {
+			n := node
+			// dependents completing concurrently may both try to schedule the same node
+			lock.Lock()
+			if scheduled[n.key] || !n.ready() {
+				lock.Unlock()
+				continue
+			}
+			scheduled[n.key] = true
+			lock.Unlock()
+
+			if n.resource != nil {
+				eg.Go(func() error {
+					err := options.EnsureResource(ctx, *n.resource)
+					if err != nil {
+						done(n, walkFailed)
+						schedule(n.dependents)
+						return err
+					}
+					done(n, walkSucceeded)
+					schedule(n.dependents)
+					return nil
+				})
+				continue
+			}
+
+			eg.Go(func() error {
+				notify(n.key, WalkRunning, nil)
+				err := fn(ctx, n.service)
+				if err != nil {
+					notify(n.key, WalkFailed, err)
+					done(n, walkFailed)
+					for _, d := range n.dependents {
+						if IsOptionalDependency(d.service, n.key) {
+							logrus.Warnf("optional dependency %q of service %q failed: %v", n.key, d.key, err)
+						}
+					}
+					schedule(n.dependents)
+					if n.isOnlyOptionalDependency() {
+						return nil
+					}
+					return err
+				}
+				done(n, walkSucceeded)
+				notify(n.key, WalkDone, nil)
+				schedule(n.dependents)
+				return nil
+			})
+		}
+	}
+	schedule(g.leaves())
+
+	err := eg.Wait()
+	for _, s := range project.Services {
+		if !scheduled[s.Name] {
+			var reason error
+			if failed := g.nodes[s.Name].failedDependency(scheduled); failed != "" {
+				reason = fmt.Errorf("%s failed", failed)
+			}
+			notify(s.Name, WalkSkipped, reason)
+		}
+	}
+	return err
+}
+
+// IsOptionalDependency checks whether service declares dependency with `required: false`
+func IsOptionalDependency(service types.ServiceConfig, dependency string) bool {
+	optional, ok := service.Extensions[OptionalDependenciesExtension].([]interface{})
+	if !ok {
+		return false
+	}
+	for _, o := range optional {
+		if o == dependency {
+			return true
+		}
+	}
+	return false
+}
+
+type walkState int
+
+const (
+	walkPending walkState = iota
+	walkSucceeded
+	walkFailed
+)
+
+// walkNode is a service or a resource of the walked graph, its dependencies are processed before it
+type walkNode struct {
+	key          string
+	service      types.ServiceConfig
+	resource     *Resource
+	state        walkState
+	dependencies []*walkNode
+	dependents   []*walkNode
+}
+
+type walkGraph struct {
+	nodes map[string]*walkNode
+	// keys of the nodes by order of insertion, so the walk doesn't depend on the map order
+	keys []string
+}
+
+func newWalkGraph(project *types.Project, withResources bool) *walkGraph {
+	g := &walkGraph{nodes: map[string]*walkNode{}}
+	add := func(n *walkNode) {
+		g.nodes[n.key] = n
+		g.keys = append(g.keys, n.key)
+	}
+	for _, s := range project.Services {
+		add(&walkNode{key: s.Name, service: s})
+	}
+	if withResources {
+		var resources []Resource
+		for name := range project.Networks {
+			resources = append(resources, Resource{Kind: ResourceNetwork, Name: name})
+		}
+		for name := range project.Volumes {
+			resources = append(resources, Resource{Kind: ResourceVolume, Name: name})
+		}
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].key() < resources[j].key()
+		})
+		for i := range resources {
+			add(&walkNode{key: resources[i].key(), resource: &resources[i]})
+		}
+	}
+
+	for _, s := range project.Services {
+		for _, dep := range ServiceDependencies(s) {
+			g.addEdge(s.Name, dep)
+		}
+		if withResources {
+			for _, r := range serviceResources(project, s) {
+				g.addEdge(s.Name, r.key())
+			}
+		}
+	}
+	return g
+}
+
+// addEdge makes source depend on destination, dependencies which are not part of the graph are ignored
+func (g *walkGraph) addEdge(source string, destination string) {
+	from, to := g.nodes[source], g.nodes[destination]
+	if from == nil || to == nil {
+		return
+	}
+	for _, d := range from.dependencies {
+		if d == to {
+			return
+		}
+	}
+	from.dependencies = append(from.dependencies, to)
+	to.dependents = append(to.dependents, from)
+}
+
+// leaves returns the nodes which don't depend on any other
+func (g *walkGraph) leaves() []*walkNode {
+	var leaves []*walkNode
+	for _, key := range g.keys {
+		if n := g.nodes[key]; len(n.dependencies) == 0 {
+			leaves = append(leaves, n)
+		}
+	}
+	return leaves
+}
+
+func (g *walkGraph) checkCycles() error {
+	visited := map[string]bool{}
+	var visit func(n *walkNode, path []string) error
+	visit = func(n *walkNode, path []string) error {
+		for _, d := range n.dependencies {
+			p := append(path, d.key)
+			for _, k := range path {
+				if k == d.key {
+					return fmt.Errorf("cycle found: %s", strings.Join(p, " -> "))
+				}
+			}
+			if visited[d.key] {
+				continue
+			}
+			if err := visit(d, p); err != nil {
+				return err
+			}
+		}
+		visited[n.key] = true
+		return nil
+	}
+	for _, key := range g.keys {
+		if !visited[key] {
+			if err := visit(g.nodes[key], []string{key}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ready returns true once all the dependencies of the node have been processed, and none of the required ones failed
+func (n *walkNode) ready() bool {
+	for _, d := range n.dependencies {
+		switch d.state {
+		case walkPending:
+			return false
+		case walkFailed:
+			if d.resource != nil || !IsOptionalDependency(n.service, d.key) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isOnlyOptionalDependency returns true when all the services depending on the node declare it
+// optional, its failure is then only reported as a warning
+func (n *walkNode) isOnlyOptionalDependency() bool {
+	for _, d := range n.dependents {
+		if !IsOptionalDependency(d.service, n.key) {
+			return false
+		}
+	}
+	return len(n.dependents) > 0
+}
+
+// failedDependency describes the dependency, resource or service, which failed and prevented the node
+// from being processed, looking through the dependencies which were skipped themselves
+func (n *walkNode) failedDependency(scheduled map[string]bool) string {
+	for _, d := range n.dependencies {
+		if d.state == walkFailed && (d.resource != nil || !IsOptionalDependency(n.service, d.key)) {
+			return d.describe()
+		}
+	}
+	for _, d := range n.dependencies {
+		if !scheduled[d.key] {
+			if failed := d.failedDependency(scheduled); failed != "" {
+				return failed
+			}
+		}
+	}
+	return ""
+}
+
+func (n *walkNode) describe() string {
+	if n.resource != nil {
+		return n.resource.String()
+	}
+	return fmt.Sprintf("service %q", n.key)
+}
+
+// serviceResources returns the networks and named volumes of the project a service uses
+func serviceResources(project *types.Project, s types.ServiceConfig) []Resource {
+	var resources []Resource
+	if s.NetworkMode == "" {
+		networks := []string{"default"}
+		if len(s.Networks) > 0 {
+			networks = nil
+			for name := range s.Networks {
+				networks = append(networks, name)
+			}
+		}
+		for _, name := range networks {
+			if _, ok := project.Networks[name]; ok {
+				resources = append(resources, Resource{Kind: ResourceNetwork, Name: name})
+			}
+		}
+	}
+	for _, v := range s.Volumes {
+		if v.Type != types.VolumeTypeVolume || v.Source == "" {
+			continue
+		}
+		if _, ok := project.Volumes[v.Source]; ok {
+			resources = append(resources, Resource{Kind: ResourceVolume, Name: v.Source})
+		}
+	}
+	return resources
+}
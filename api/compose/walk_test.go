@@ -0,0 +1,195 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+// walkRecorder records the services processed by a walk and the statuses they were reported
+type walkRecorder struct {
+	lock      sync.Mutex
+	processed []string
+	statuses  map[string][]WalkStatus
+	errors    map[string]error
+}
+
+func newWalkRecorder() *walkRecorder {
+	return &walkRecorder{
+		statuses: map[string][]WalkStatus{},
+		errors:   map[string]error{},
+	}
+}
+
+func (r *walkRecorder) process(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.processed = append(r.processed, name)
+}
+
+func (r *walkRecorder) onStatus(service string, status WalkStatus, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.statuses[service] = append(r.statuses[service], status)
+	if err != nil {
+		r.errors[service] = err
+	}
+}
+
+func TestWalkInDependencyOrder(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{Name: "web", DependsOn: types.DependsOnConfig{"api": {}}},
+			{Name: "api", DependsOn: types.DependsOnConfig{"db": {}}},
+			{Name: "db"},
+		},
+	}
+	recorder := newWalkRecorder()
+	err := Walk(context.Background(), project, func(ctx context.Context, s types.ServiceConfig) error {
+		recorder.process(s.Name)
+		return nil
+	}, WalkOptions{OnStatus: recorder.onStatus})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, recorder.processed, []string{"db", "api", "web"})
+	for _, s := range []string{"web", "api", "db"} {
+		assert.DeepEqual(t, recorder.statuses[s], []WalkStatus{WalkQueued, WalkRunning, WalkDone})
+	}
+}
+
+func TestWalkStatus(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{Name: "web", DependsOn: types.DependsOnConfig{"db": {}}},
+			{Name: "db"},
+			{Name: "cache"},
+		},
+	}
+	recorder := newWalkRecorder()
+	err := Walk(context.Background(), project, func(ctx context.Context, s types.ServiceConfig) error {
+		if s.Name == "db" {
+			return errors.New("failed")
+		}
+		return nil
+	}, WalkOptions{OnStatus: recorder.onStatus})
+	assert.Error(t, err, "failed")
+	assert.DeepEqual(t, recorder.statuses["db"], []WalkStatus{WalkQueued, WalkRunning, WalkFailed})
+	assert.DeepEqual(t, recorder.statuses["cache"], []WalkStatus{WalkQueued, WalkRunning, WalkDone})
+	assert.DeepEqual(t, recorder.statuses["web"], []WalkStatus{WalkQueued, WalkSkipped})
+	assert.Error(t, recorder.errors["web"], `service "db" failed`)
+}
+
+func TestWalkWithResources(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{
+				Name:     "web",
+				Networks: map[string]*types.ServiceNetworkConfig{"front": nil},
+			},
+			{
+				Name: "db",
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/var/lib/db"},
+				},
+			},
+			{
+				Name:        "sidecar",
+				NetworkMode: "service:db",
+			},
+		},
+		Networks: types.Networks{"front": types.NetworkConfig{}},
+		Volumes:  types.Volumes{"data": types.VolumeConfig{}},
+	}
+	recorder := newWalkRecorder()
+	err := Walk(context.Background(), project, func(ctx context.Context, s types.ServiceConfig) error {
+		recorder.process(s.Name)
+		return nil
+	}, WalkOptions{
+		EnsureResource: func(ctx context.Context, r Resource) error {
+			if r.Kind == ResourceVolume {
+				return errors.New("no space left on device")
+			}
+			recorder.process(r.key())
+			return nil
+		},
+		OnStatus: recorder.onStatus,
+	})
+	assert.Error(t, err, "no space left on device")
+	assert.DeepEqual(t, recorder.processed, []string{"network:front", "web"})
+	assert.DeepEqual(t, recorder.statuses["db"], []WalkStatus{WalkQueued, WalkSkipped})
+	// the resource which failed is reported through the skipped dependencies
+	assert.Error(t, recorder.errors["sidecar"], `volume "data" failed`)
+}
+
+func TestWalkOptionalDependency(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{
+				Name:      "web",
+				DependsOn: types.DependsOnConfig{"metrics": {}},
+				Extensions: map[string]interface{}{
+					OptionalDependenciesExtension: []interface{}{"metrics"},
+				},
+			},
+			{Name: "metrics"},
+		},
+	}
+	walk := func() ([]string, error) {
+		recorder := newWalkRecorder()
+		err := Walk(context.Background(), project, func(ctx context.Context, s types.ServiceConfig) error {
+			if s.Name == "metrics" {
+				return errors.New("failed")
+			}
+			recorder.process(s.Name)
+			return nil
+		}, WalkOptions{})
+		return recorder.processed, err
+	}
+
+	// the failure of an optional dependency is only a warning
+	processed, err := walk()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, processed, []string{"web"})
+
+	// but not when another service requires it
+	project.Services = append(project.Services, types.ServiceConfig{
+		Name:      "monitor",
+		DependsOn: types.DependsOnConfig{"metrics": {}},
+	})
+	processed, err = walk()
+	assert.Error(t, err, "failed")
+	assert.DeepEqual(t, processed, []string{"web"})
+}
+
+func TestWalkCycle(t *testing.T) {
+	project := &types.Project{
+		Services: types.Services{
+			{Name: "web", DependsOn: types.DependsOnConfig{"api": {}}},
+			{Name: "api", DependsOn: types.DependsOnConfig{"web": {}}},
+		},
+	}
+	err := Walk(context.Background(), project, func(ctx context.Context, s types.ServiceConfig) error {
+		t.Errorf("service %q processed", s.Name)
+		return nil
+	}, WalkOptions{})
+	assert.Error(t, err, "cycle found: web -> api -> web")
+}
@@ -0,0 +1,87 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// Warning reports a compose field a backend doesn't support and ignores
+type Warning struct {
+	// Service is the service declaring the field, empty for top-level fields or when unknown
+	Service string
+	// Field is the compose field or feature, when known
+	Field   string
+	Message string
+}
+
+func (w Warning) String() string {
+	message := w.Message
+	if w.Field != "" {
+		message = fmt.Sprintf("%s: %s", w.Field, message)
+	}
+	if w.Service != "" {
+		message = fmt.Sprintf("service %q: %s", w.Service, message)
+	}
+	return message
+}
+
+// Warnings collects the warnings reported while a project is deployed, so they are shown once the
+// deployment completes. In strict mode the first warning fails the deployment instead.
+type Warnings struct {
+	Strict   bool
+	lock     sync.Mutex
+	warnings []Warning
+}
+
+// List returns the warnings collected so far
+func (c *Warnings) List() []Warning {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]Warning{}, c.warnings...)
+}
+
+type warningsKey struct{}
+
+// WithWarnings sets the collector of the warnings reported by backends
+func WithWarnings(ctx context.Context, warnings *Warnings) context.Context {
+	return context.WithValue(ctx, warningsKey{}, warnings)
+}
+
+// Warn reports a compose field the backend ignores. It returns an error when the warnings are collected in
+// strict mode, backends must then stop before creating any resource. Warnings are logged when not collected.
+func Warn(ctx context.Context, w Warning) error {
+	warnings, ok := ctx.Value(warningsKey{}).(*Warnings)
+	if !ok {
+		logrus.Warn(w.String())
+		return nil
+	}
+	if warnings.Strict {
+		return errors.Wrap(errdefs.ErrNotImplemented, w.String())
+	}
+	warnings.lock.Lock()
+	defer warnings.lock.Unlock()
+	warnings.warnings = append(warnings.warnings, w)
+	return nil
+}
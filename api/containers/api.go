@@ -146,6 +146,8 @@ type ExecRequest struct {
 type LogsRequest struct {
 	Follow bool
 	Tail   string
+	Since  string
+	Until  string
 	Width  int
 	Writer io.Writer
 }
@@ -155,6 +157,69 @@ type DeleteRequest struct {
 	Force bool
 }
 
+// CheckpointRequest contains configuration about a checkpoint request
+type CheckpointRequest struct {
+	// Name is the name of the checkpoint
+	Name string
+	// Dir overrides the directory the checkpoint is stored in
+	Dir string
+	// LeaveRunning keeps the container running after the checkpoint is created
+	LeaveRunning bool
+}
+
+// RestoreRequest contains configuration about a restore request
+type RestoreRequest struct {
+	// Checkpoint is the name of the checkpoint to restore
+	Checkpoint string
+	// Dir overrides the directory the checkpoint is stored in
+	Dir string
+}
+
+// CommitRequest contains configuration about a commit request
+type CommitRequest struct {
+	// Reference is the repository and tag of the created image
+	Reference string
+	Author    string
+	Message   string
+	// Changes are Dockerfile instructions applied to the created image
+	Changes []string
+	// Pause pauses the container during the commit
+	Pause bool
+}
+
+const (
+	// ChangeAdded a file or directory was added
+	ChangeAdded = "A"
+	// ChangeModified a file or directory was modified
+	ChangeModified = "C"
+	// ChangeDeleted a file or directory was deleted
+	ChangeDeleted = "D"
+)
+
+// FilesystemChange describes a change made to the filesystem of a container
+type FilesystemChange struct {
+	Kind string
+	Path string
+}
+
+// ImportRequest contains configuration about an import request
+type ImportRequest struct {
+	// Source is the tar archive to import, ignored when SourceURL is set
+	Source io.Reader
+	// SourceURL is the URL of a remote tar archive to import
+	SourceURL string
+	// Reference is the repository and tag of the created image
+	Reference string
+	Message   string
+	// Changes are Dockerfile instructions applied to the created image
+	Changes []string
+}
+
+// Checkpoint represents the saved state of a container
+type Checkpoint struct {
+	Name string
+}
+
 // Service interacts with the underlying container backend
 type Service interface {
 	// List returns all the containers
@@ -175,4 +240,20 @@ type Service interface {
 	Delete(ctx context.Context, containerID string, request DeleteRequest) error
 	// Inspect get a specific container
 	Inspect(ctx context.Context, id string) (Container, error)
+	// Checkpoint saves the state of a running container
+	Checkpoint(ctx context.Context, containerID string, request CheckpointRequest) error
+	// ListCheckpoints returns the checkpoints of a container
+	ListCheckpoints(ctx context.Context, containerID string, dir string) ([]Checkpoint, error)
+	// Restore starts a container from a checkpoint
+	Restore(ctx context.Context, containerID string, request RestoreRequest) error
+	// Commit creates an image from the changes of a container and returns its ID
+	Commit(ctx context.Context, containerID string, request CommitRequest) (string, error)
+	// Wait blocks until a container exits and returns its exit code
+	Wait(ctx context.Context, containerID string) (int64, error)
+	// Diff returns the changes made to the filesystem of a container
+	Diff(ctx context.Context, containerID string) ([]FilesystemChange, error)
+	// Export writes the filesystem of a container as a tar archive
+	Export(ctx context.Context, containerID string, w io.Writer) error
+	// Import creates an image from a filesystem tar archive and returns its ID
+	Import(ctx context.Context, request ImportRequest) (string, error)
 }
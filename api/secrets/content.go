@@ -0,0 +1,45 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+)
+
+// EnvironmentExtension names the environment variable the content of a compose secret is read from
+const EnvironmentExtension = "x-environment"
+
+// ReadContent returns the content of a compose secret, read from its file, from the
+// environment variable it is sourced from or from the external provider it is stored in
+func ReadContent(ctx context.Context, config types.SecretConfig) ([]byte, error) {
+	if _, ok := config.Extensions[ProviderExtension]; ok {
+		return resolveFromProvider(ctx, config)
+	}
+	if name, ok := config.Extensions[EnvironmentExtension].(string); ok {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, errors.Errorf("environment variable %q of secret %q is not set", name, config.Name)
+		}
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(config.File)
+}
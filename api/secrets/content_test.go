@@ -0,0 +1,84 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestReadContentFromEnvironment(t *testing.T) {
+	os.Setenv("TEST_SECRET_CONTENT", "s3cr3t") // nolint:errcheck
+	defer os.Unsetenv("TEST_SECRET_CONTENT")   // nolint:errcheck
+
+	content, err := ReadContent(context.Background(), types.SecretConfig{
+		Name:       "password",
+		Extensions: map[string]interface{}{EnvironmentExtension: "TEST_SECRET_CONTENT"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "s3cr3t")
+
+	_, err = ReadContent(context.Background(), types.SecretConfig{
+		Name:       "token",
+		Extensions: map[string]interface{}{EnvironmentExtension: "TEST_SECRET_UNSET"},
+	})
+	assert.Error(t, err, `environment variable "TEST_SECRET_UNSET" of secret "token" is not set`)
+}
+
+type staticProvider map[string]string
+
+func (p staticProvider) Resolve(ctx context.Context, options map[string]string) ([]byte, error) {
+	value, ok := p[options["key"]]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(value), nil
+}
+
+func TestReadContentFromProvider(t *testing.T) {
+	RegisterProvider("test", staticProvider{"db": "p4ssw0rd"})
+
+	content, err := ReadContent(context.Background(), types.SecretConfig{
+		Name: "db",
+		Extensions: map[string]interface{}{ProviderExtension: map[string]interface{}{
+			"name":    "test",
+			"options": map[string]interface{}{"key": "db"},
+		}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "p4ssw0rd")
+
+	_, err = ReadContent(context.Background(), types.SecretConfig{
+		Name: "api",
+		Extensions: map[string]interface{}{ProviderExtension: map[string]interface{}{
+			"name":    "test",
+			"options": map[string]interface{}{"key": "api"},
+		}},
+	})
+	assert.Error(t, err, `resolving secret "api" from provider "test": not found`)
+
+	_, err = ReadContent(context.Background(), types.SecretConfig{
+		Name:       "token",
+		Extensions: map[string]interface{}{ProviderExtension: map[string]interface{}{"name": "unknown"}},
+	})
+	assert.Error(t, err, `unknown secret provider "unknown" for secret "token"`)
+}
@@ -0,0 +1,84 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+)
+
+// ProviderExtension declares the external provider a compose secret is resolved from, as a
+// mapping with the provider `name` and the provider specific `options`
+const ProviderExtension = "x-provider"
+
+// Provider resolves the content of secrets stored in an external secret store
+type Provider interface {
+	// Resolve returns the content of a secret given the options set in the compose file
+	Resolve(ctx context.Context, options map[string]string) ([]byte, error)
+}
+
+var providers = struct {
+	sync.RWMutex
+	r map[string]Provider
+}{r: map[string]Provider{}}
+
+// RegisterProvider makes a secret provider available under the given name
+func RegisterProvider(name string, provider Provider) {
+	providers.Lock()
+	defer providers.Unlock()
+	if _, ok := providers.r[name]; ok {
+		panic(fmt.Sprintf("secret provider %q already registered", name))
+	}
+	providers.r[name] = provider
+}
+
+func getProvider(name string) (Provider, bool) {
+	providers.RLock()
+	defer providers.RUnlock()
+	provider, ok := providers.r[name]
+	return provider, ok
+}
+
+func resolveFromProvider(ctx context.Context, config types.SecretConfig) ([]byte, error) {
+	ext, ok := config.Extensions[ProviderExtension].(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid %s for secret %q: expected a mapping", ProviderExtension, config.Name)
+	}
+	name, ok := ext["name"].(string)
+	if !ok || name == "" {
+		return nil, errors.Errorf("secret %q does not declare a provider name", config.Name)
+	}
+	provider, ok := getProvider(name)
+	if !ok {
+		return nil, errors.Errorf("unknown secret provider %q for secret %q", name, config.Name)
+	}
+	options := map[string]string{}
+	if opts, ok := ext["options"].(map[string]interface{}); ok {
+		for k, v := range opts {
+			options[k] = fmt.Sprint(v)
+		}
+	}
+	content, err := provider.Resolve(ctx, options)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving secret %q from provider %q", config.Name, name)
+	}
+	return content, nil
+}
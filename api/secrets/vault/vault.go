@@ -0,0 +1,115 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/secrets"
+)
+
+const (
+	// ProviderName is the name compose files use to resolve secrets from Vault
+	ProviderName = "vault"
+
+	defaultKey = "value"
+)
+
+func init() {
+	secrets.RegisterProvider(ProviderName, provider{
+		client: http.DefaultClient,
+		getenv: os.Getenv,
+	})
+}
+
+type provider struct {
+	client *http.Client
+	getenv func(string) string
+}
+
+type response struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+// Resolve reads the `key` field (`value` by default) of the secret stored at `path`, using
+// the Vault server and token set in the VAULT_ADDR and VAULT_TOKEN environment variables.
+// Secrets stored in a KV version 2 engine are unwrapped from their metadata.
+func (p provider) Resolve(ctx context.Context, options map[string]string) ([]byte, error) {
+	secretPath := strings.Trim(options["path"], "/")
+	if secretPath == "" {
+		return nil, errors.New("vault secrets require a path option")
+	}
+	key := options["key"]
+	if key == "" {
+		key = defaultKey
+	}
+	address := options["address"]
+	if address == "" {
+		address = p.getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, errors.New("vault address is not set, use the address option or VAULT_ADDR")
+	}
+	token := p.getenv("VAULT_TOKEN")
+	if token == "" {
+		return nil, errors.New("VAULT_TOKEN is not set")
+	}
+
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(address, "/"), secretPath)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := p.getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("reading %s from vault: %s", secretPath, resp.Status)
+	}
+
+	var body response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	value, ok := data[key]
+	if !ok {
+		return nil, errors.Errorf("key %q not found in vault secret %s", key, secretPath)
+	}
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
@@ -0,0 +1,54 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveKVv2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1/secret/data/db")
+		assert.Equal(t, r.Header.Get("X-Vault-Token"), "root")
+		w.Write([]byte(`{"data":{"data":{"password":"s3cr3t"},"metadata":{"version":1}}}`)) // nolint:errcheck
+	}))
+	defer server.Close()
+
+	p := provider{
+		client: server.Client(),
+		getenv: func(name string) string {
+			return map[string]string{"VAULT_ADDR": server.URL, "VAULT_TOKEN": "root"}[name]
+		},
+	}
+	content, err := p.Resolve(context.Background(), map[string]string{"path": "secret/data/db", "key": "password"})
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "s3cr3t")
+
+	_, err = p.Resolve(context.Background(), map[string]string{"path": "secret/data/db"})
+	assert.Error(t, err, `key "value" not found in vault secret secret/data/db`)
+}
+
+func TestResolveRequiresPath(t *testing.T) {
+	p := provider{getenv: func(string) string { return "" }}
+	_, err := p.Resolve(context.Background(), map[string]string{})
+	assert.Error(t, err, "vault secrets require a path option")
+}
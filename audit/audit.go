@@ -0,0 +1,108 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+)
+
+// EnvVar enables the audit log when set to the directory the audit logs are written to, one JSON lines
+// file per project
+const EnvVar = "COMPOSE_AUDIT_LOG"
+
+const (
+	// StatusSuccess is the status of an operation which completed
+	StatusSuccess = "success"
+	// StatusFailure is the status of an operation which failed, the error is recorded
+	StatusFailure = "failure"
+)
+
+// Record is the entry of a mutating operation in the audit log
+type Record struct {
+	Time      time.Time `json:"time"`
+	Project   string    `json:"project"`
+	Operation string    `json:"operation"`
+	Services  []string  `json:"services,omitempty"`
+	// Command is the command run by exec
+	Command []string `json:"command,omitempty"`
+	// Scale is the number of replicas requested by scale
+	Scale   map[string]int `json:"scale,omitempty"`
+	User    string         `json:"user"`
+	Context string         `json:"context"`
+	// ConfigHash identifies the resolved compose configuration, omitted by operations which don't load it
+	ConfigHash string `json:"configHash,omitempty"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Log is the audit log of a project
+type Log struct {
+	file *os.File
+}
+
+// Open opens the audit log of a project in dir, records are appended to the existing ones
+func Open(dir string, project string) (*Log, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.Wrap(err, "could not create audit log directory")
+	}
+	f, err := os.OpenFile(filepath.Join(dir, project+".jsonl"), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open audit log")
+	}
+	return &Log{file: f}, nil
+}
+
+// Write appends a record to the log, as a single line so concurrent writers don't interleave
+func (l *Log) Write(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = l.file.Write(append(line, '\n'))
+	return errors.Wrap(err, "could not write audit log")
+}
+
+// Close closes the log file
+func (l *Log) Close() error {
+	return l.file.Close()
+}
+
+// ConfigHash returns the digest of the resolved configuration of a project
+func ConfigHash(project *types.Project) (string, error) {
+	config, err := json.Marshal(project)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(config)), nil
+}
+
+// CurrentUser returns the name of the user running the operation
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestLogAppendsRecords(t *testing.T) {
+	dir := fs.NewDir(t, "audit")
+	defer dir.Remove()
+	logs := filepath.Join(dir.Path(), "logs")
+
+	now := time.Date(2020, 11, 20, 10, 0, 0, 0, time.UTC)
+	for _, r := range []Record{
+		{Time: now, Project: "shop", Operation: "up", User: "alice", Context: "default", Status: StatusSuccess},
+		{Time: now, Project: "shop", Operation: "exec", Services: []string{"web"}, Command: []string{"sh"}, User: "alice", Context: "default", Status: StatusFailure, Error: "exit status 1"},
+	} {
+		log, err := Open(logs, "shop")
+		assert.NilError(t, err)
+		assert.NilError(t, log.Write(r))
+		assert.NilError(t, log.Close())
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(logs, "shop.jsonl"))
+	assert.NilError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Equal(t, len(lines), 2)
+	assert.Equal(t, lines[0], `{"time":"2020-11-20T10:00:00Z","project":"shop","operation":"up","user":"alice","context":"default","status":"success"}`)
+	var r Record
+	assert.NilError(t, json.Unmarshal([]byte(lines[1]), &r))
+	assert.DeepEqual(t, r.Command, []string{"sh"})
+	assert.Equal(t, r.Error, "exit status 1")
+}
+
+func TestConfigHash(t *testing.T) {
+	project := &types.Project{Name: "shop", Services: types.Services{{Name: "web", Image: "nginx"}}}
+	hash, err := ConfigHash(project)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(hash, "sha256:"))
+
+	again, err := ConfigHash(project)
+	assert.NilError(t, err)
+	assert.Equal(t, hash, again)
+
+	project.Services[0].Image = "nginx:alpine"
+	changed, err := ConfigHash(project)
+	assert.NilError(t, err)
+	assert.Assert(t, hash != changed)
+}
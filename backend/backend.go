@@ -59,6 +59,8 @@ type Service interface {
 	ResourceService() resources.Service
 	SecretsService() secrets.Service
 	VolumeService() volumes.Service
+	// Ping checks the backend is reachable with the context credentials
+	Ping(ctx context.Context) error
 }
 
 // Register adds a typed backend to the registry
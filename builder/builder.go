@@ -0,0 +1,311 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/builder/dockerignore"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/fileutils"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/progress"
+)
+
+// RepositoryFunc provisions the registry repository the image of a service is pushed to
+type RepositoryFunc func(ctx context.Context, service string) (compose.ImageRepository, error)
+
+// BuildAndPush builds with the local engine the images of the services declaring a build section, pushes
+// them to the repositories provisioned by the backend and substitutes the image reference of the services,
+// so the project can be deployed on a cloud backend which can't build images
+func BuildAndPush(ctx context.Context, apiClient client.APIClient, project *types.Project, repository RepositoryFunc) error {
+	w := progress.ContextWriter(ctx)
+	for i, service := range project.Services {
+		if service.Build == nil {
+			continue
+		}
+		repo, err := repository(ctx, service.Name)
+		if err != nil {
+			return err
+		}
+		w.Event(progress.Event{ID: service.Name, Text: "Building", Status: progress.Working})
+		id, err := build(ctx, apiClient, project.Name, service)
+		if err != nil {
+			w.Event(errorEvent(service.Name, err))
+			return errors.Wrapf(err, "building image of service %q", service.Name)
+		}
+		ref := imageReference(repo.Name, id)
+		if err := apiClient.ImageTag(ctx, id, ref); err != nil {
+			w.Event(errorEvent(service.Name, err))
+			return err
+		}
+		w.Event(progress.Event{ID: service.Name, Text: "Pushing", Status: progress.Working})
+		if err := push(ctx, apiClient, ref, repo, progress.SubWriter(w, service.Name)); err != nil {
+			w.Event(errorEvent(service.Name, err))
+			return errors.Wrapf(err, "pushing image of service %q", service.Name)
+		}
+		w.Event(progress.Event{ID: service.Name, Text: "Pushed " + ref, Status: progress.Done})
+		project.Services[i].Image = ref
+		project.Services[i].Build = nil
+	}
+	return nil
+}
+
+func build(ctx context.Context, apiClient client.APIClient, projectName string, service types.ServiceConfig) (string, error) {
+	dockerfile := service.Build.Dockerfile
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	buildContext := archiveContext(service.Build.Context, dockerfile)
+	defer buildContext.Close() // nolint:errcheck
+
+	tag := fmt.Sprintf("%s_%s", projectName, service.Name)
+	response, err := apiClient.ImageBuild(ctx, buildContext, moby.ImageBuildOptions{
+		Tags:        []string{tag},
+		Dockerfile:  dockerfile,
+		BuildArgs:   service.Build.Args,
+		Labels:      service.Build.Labels,
+		Target:      service.Build.Target,
+		NetworkMode: service.Build.Network,
+		ExtraHosts:  service.Build.ExtraHosts,
+		CacheFrom:   service.Build.CacheFrom,
+		Remove:      true,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close() // nolint:errcheck
+
+	var id string
+	dec := json.NewDecoder(response.Body)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+		if jm.Error != nil {
+			return "", errors.New(jm.Error.Message)
+		}
+		if jm.Aux != nil {
+			var result moby.BuildResult
+			if err := json.Unmarshal(*jm.Aux, &result); err == nil && result.ID != "" {
+				id = result.ID
+			}
+		}
+	}
+	if id != "" {
+		return id, nil
+	}
+	// legacy builders don't report the image ID, it is resolved from the tag
+	inspect, _, err := apiClient.ImageInspectWithRaw(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	return inspect.ID, nil
+}
+
+func push(ctx context.Context, apiClient client.APIClient, ref string, repo compose.ImageRepository, w progress.Writer) error {
+	auth, err := encodeAuth(repo)
+	if err != nil {
+		return err
+	}
+	stream, err := apiClient.ImagePush(ctx, ref, moby.ImagePushOptions{
+		RegistryAuth: auth,
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.Close() // nolint:errcheck
+
+	dec := json.NewDecoder(stream)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if jm.Error != nil {
+			return errors.New(jm.Error.Message)
+		}
+		if jm.ID == "" || jm.Progress == nil {
+			continue
+		}
+		status := progress.Working
+		if jm.Status == "Pushed" || jm.Status == "Layer already exists" {
+			status = progress.Done
+		}
+		w.Event(progress.Event{
+			ID:         jm.ID,
+			Text:       jm.Status,
+			Status:     status,
+			StatusText: jm.Progress.String(),
+			Current:    jm.Progress.Current,
+			Total:      jm.Progress.Total,
+		})
+	}
+}
+
+func errorEvent(id string, err error) progress.Event {
+	return progress.Event{
+		ID:         id,
+		Text:       "Error",
+		Status:     progress.Error,
+		StatusText: err.Error(),
+	}
+}
+
+// imageReference tags the pushed image with its short ID, so a new build always results in a new reference
+// and the backend rolls out the services which changed
+func imageReference(repository string, id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return fmt.Sprintf("%s:%s", repository, id)
+}
+
+func encodeAuth(repo compose.ImageRepository) (string, error) {
+	registry := repo.Name
+	if i := strings.Index(registry, "/"); i > 0 {
+		registry = registry[:i]
+	}
+	bytes, err := json.Marshal(moby.AuthConfig{
+		Username:      repo.Username,
+		Password:      repo.Password,
+		ServerAddress: registry,
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(bytes), nil
+}
+
+// archiveContext streams the build context as a tar archive, skipping the files excluded by the
+// .dockerignore file. The Dockerfile and the .dockerignore file are always sent, the engine reading them.
+func archiveContext(contextDir string, dockerfile string) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeContext(writer, contextDir, dockerfile)) // nolint:errcheck
+	}()
+	return reader
+}
+
+func writeContext(w io.Writer, contextDir string, dockerfile string) error {
+	excludes, err := readDockerignore(contextDir)
+	if err != nil {
+		return err
+	}
+	matcher, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return err
+	}
+	keep := map[string]bool{
+		filepath.ToSlash(filepath.Clean(dockerfile)): true,
+		".dockerignore": true,
+	}
+
+	tw := tar.NewWriter(w)
+	err = filepath.Walk(contextDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(contextDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !keep[name] {
+			excluded, err := matcher.Matches(rel)
+			if err != nil {
+				return err
+			}
+			if excluded {
+				if info.IsDir() && !matcher.Exclusions() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+		}
+		return addFile(tw, path, name, info)
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func addFile(tw *tar.Writer, path string, name string, info os.FileInfo) error {
+	link := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		link = target
+	}
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint:errcheck
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+func readDockerignore(contextDir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(contextDir, ".dockerignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+	return dockerignore.ReadAll(f)
+}
@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package builder
+
+import (
+	"archive/tar"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"sort"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestArchiveContextHonoursDockerignore(t *testing.T) {
+	dir := fs.NewDir(t, "context",
+		fs.WithFile("Dockerfile", "FROM alpine"),
+		fs.WithFile(".dockerignore", "Dockerfile\n*.log\nnode_modules\n!keep.log\n"),
+		fs.WithFile("main.go", "package main"),
+		fs.WithFile("debug.log", ""),
+		fs.WithFile("keep.log", ""),
+		fs.WithDir("node_modules", fs.WithFile("index.js", "")),
+	)
+	defer dir.Remove()
+
+	reader := archiveContext(dir.Path(), "Dockerfile")
+	defer reader.Close() // nolint:errcheck
+	names := []string{}
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		names = append(names, header.Name)
+	}
+	sort.Strings(names)
+	assert.DeepEqual(t, names, []string{".dockerignore", "Dockerfile", "keep.log", "main.go"})
+}
+
+func TestImageReference(t *testing.T) {
+	id := "sha256:4e07f3bd88fb4a468d5551c21eb05f625b0efe9259c7d5d6b4c0e9fe19f1e2ad"
+	assert.Equal(t, imageReference("123456789012.dkr.ecr.eu-west-1.amazonaws.com/shop/web", id),
+		"123456789012.dkr.ecr.eu-west-1.amazonaws.com/shop/web:4e07f3bd88fb")
+}
+
+func TestEncodeAuth(t *testing.T) {
+	auth, err := encodeAuth(compose.ImageRepository{
+		Name:     "composeabc.azurecr.io/shop/web",
+		Username: "composeabc",
+		Password: "secret",
+	})
+	assert.NilError(t, err)
+	decoded, err := base64.URLEncoding.DecodeString(auth)
+	assert.NilError(t, err)
+	var config moby.AuthConfig
+	assert.NilError(t, json.Unmarshal(decoded, &config))
+	assert.DeepEqual(t, config, moby.AuthConfig{
+		Username:      "composeabc",
+		Password:      "secret",
+		ServerAddress: "composeabc.azurecr.io",
+	})
+}
@@ -0,0 +1,162 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/formatter"
+)
+
+// experimentalEnvVar enables the experimental commands, as for the docker CLI
+const experimentalEnvVar = "DOCKER_CLI_EXPERIMENTAL"
+
+func experimentalEnabled() bool {
+	return os.Getenv(experimentalEnvVar) == "enabled"
+}
+
+// checkExperimental rejects the experimental commands unless they are enabled
+func checkExperimental(cmd *cobra.Command) error {
+	if experimentalEnabled() {
+		return nil
+	}
+	return errors.Errorf("%q is an experimental command, set %s=enabled to use it", cmd.CommandPath(), experimentalEnvVar)
+}
+
+// CheckpointCommand manage container checkpoints
+func CheckpointCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "checkpoint",
+		Short:  "Manages container checkpoints (experimental)",
+		Hidden: !experimentalEnabled(),
+	}
+
+	cmd.AddCommand(
+		createCheckpoint(),
+		listCheckpoints(),
+		restoreCheckpoint(),
+	)
+	return cmd
+}
+
+type createCheckpointOpts struct {
+	dir          string
+	leaveRunning bool
+}
+
+func createCheckpoint() *cobra.Command {
+	var opts createCheckpointOpts
+	cmd := &cobra.Command{
+		Use:   "create [OPTIONS] CONTAINER CHECKPOINT",
+		Short: "Creates a checkpoint from a running container",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkExperimental(cmd); err != nil {
+				return err
+			}
+			c, err := client.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+			err = c.ContainerService().Checkpoint(cmd.Context(), args[0], containers.CheckpointRequest{
+				Name:         args[1],
+				Dir:          opts.dir,
+				LeaveRunning: opts.leaveRunning,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(args[1])
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.dir, "checkpoint-dir", "", "Use a custom checkpoint storage directory")
+	cmd.Flags().BoolVar(&opts.leaveRunning, "leave-running", false, "Leave the container running after checkpoint")
+	return cmd
+}
+
+type listCheckpointsOpts struct {
+	dir    string
+	format string
+}
+
+func listCheckpoints() *cobra.Command {
+	var opts listCheckpointsOpts
+	cmd := &cobra.Command{
+		Use:     "list [OPTIONS] CONTAINER",
+		Aliases: []string{"ls"},
+		Short:   "Lists checkpoints for a container",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkExperimental(cmd); err != nil {
+				return err
+			}
+			c, err := client.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+			checkpoints, err := c.ContainerService().ListCheckpoints(cmd.Context(), args[0], opts.dir)
+			if err != nil {
+				return err
+			}
+			return formatter.Print(checkpoints, opts.format, os.Stdout, func(w io.Writer) {
+				for _, checkpoint := range checkpoints {
+					_, _ = fmt.Fprintf(w, "%s\n", checkpoint.Name)
+				}
+			}, "CHECKPOINT NAME")
+		},
+	}
+	cmd.Flags().StringVar(&opts.dir, "checkpoint-dir", "", "Use a custom checkpoint storage directory")
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, formatter.FormatUsage)
+	return cmd
+}
+
+func restoreCheckpoint() *cobra.Command {
+	var dir string
+	cmd := &cobra.Command{
+		Use:   "restore [OPTIONS] CONTAINER CHECKPOINT",
+		Short: "Starts a stopped container from a checkpoint",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkExperimental(cmd); err != nil {
+				return err
+			}
+			c, err := client.New(cmd.Context())
+			if err != nil {
+				return err
+			}
+			err = c.ContainerService().Restore(cmd.Context(), args[0], containers.RestoreRequest{
+				Checkpoint: args[1],
+				Dir:        dir,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "checkpoint-dir", "", "Use a custom checkpoint storage directory")
+	return cmd
+}
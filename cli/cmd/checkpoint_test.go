@@ -0,0 +1,89 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	_ "github.com/docker/compose-cli/example"
+	"github.com/docker/compose-cli/tests/framework"
+)
+
+func runCheckpointCommand(t *testing.T, args ...string) (string, error) {
+	c := framework.NewTestCLI(t)
+	cmd := CheckpointCommand()
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(c.Context())
+	return c.GetStdOut(), err
+}
+
+func enableExperimental(t *testing.T) {
+	original, ok := os.LookupEnv(experimentalEnvVar)
+	assert.NilError(t, os.Setenv(experimentalEnvVar, "enabled"))
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(experimentalEnvVar, original)
+		} else {
+			_ = os.Unsetenv(experimentalEnvVar)
+		}
+	})
+}
+
+func TestCheckpointRequiresExperimental(t *testing.T) {
+	original, ok := os.LookupEnv(experimentalEnvVar)
+	assert.NilError(t, os.Unsetenv(experimentalEnvVar))
+	defer func() {
+		if ok {
+			_ = os.Setenv(experimentalEnvVar, original)
+		}
+	}()
+
+	assert.Assert(t, CheckpointCommand().Hidden)
+	_, err := runCheckpointCommand(t, "create", "id", "cp1")
+	assert.Error(t, err, `"checkpoint create" is an experimental command, set DOCKER_CLI_EXPERIMENTAL=enabled to use it`)
+}
+
+func TestCheckpointCreate(t *testing.T) {
+	enableExperimental(t)
+	assert.Assert(t, !CheckpointCommand().Hidden)
+
+	out, err := runCheckpointCommand(t, "create", "id", "cp1")
+	assert.NilError(t, err)
+	assert.Equal(t, out, "Checkpointing container \"id\" as \"cp1\"\ncp1\n")
+
+	_, err = runCheckpointCommand(t, "create", "stopped", "cp1")
+	assert.ErrorContains(t, err, "stopped")
+}
+
+func TestCheckpointList(t *testing.T) {
+	enableExperimental(t)
+
+	out, err := runCheckpointCommand(t, "ls", "id")
+	assert.NilError(t, err)
+	assert.Equal(t, out, "CHECKPOINT NAME\ncheckpoint1\n")
+}
+
+func TestCheckpointRestore(t *testing.T) {
+	enableExperimental(t)
+
+	out, err := runCheckpointCommand(t, "restore", "stopped", "cp1")
+	assert.NilError(t, err)
+	assert.Equal(t, out, "Restoring container \"stopped\" from \"cp1\"\nstopped\n")
+}
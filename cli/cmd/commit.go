@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type commitOpts struct {
+	author  string
+	message string
+	changes []string
+	pause   bool
+}
+
+// CommitCommand creates an image from a container
+func CommitCommand() *cobra.Command {
+	var opts commitOpts
+	cmd := &cobra.Command{
+		Use:   "commit [OPTIONS] CONTAINER [REPOSITORY[:TAG]]",
+		Short: "Create a new image from a container's changes",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCommit(cmd.Context(), opts, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.author, "author", "a", "", "Author (e.g., \"John Hannibal Smith <hannibal@a-team.com>\")")
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Commit message")
+	cmd.Flags().StringArrayVarP(&opts.changes, "change", "c", []string{}, "Apply Dockerfile instruction to the created image")
+	cmd.Flags().BoolVarP(&opts.pause, "pause", "p", true, "Pause container during commit")
+
+	return cmd
+}
+
+func runCommit(ctx context.Context, opts commitOpts, args []string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to backend")
+	}
+
+	reference := ""
+	if len(args) == 2 {
+		reference = args[1]
+	}
+	id, err := c.ContainerService().Commit(ctx, args[0], containers.CommitRequest{
+		Reference: reference,
+		Author:    opts.author,
+		Message:   opts.message,
+		Changes:   opts.changes,
+		Pause:     opts.pause,
+	})
+	if err != nil {
+		if errdefs.IsNotFoundError(err) {
+			return fmt.Errorf("container %s not found", args[0])
+		}
+		return err
+	}
+	fmt.Println(id)
+	return nil
+}
@@ -0,0 +1,39 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	_ "github.com/docker/compose-cli/example"
+	"github.com/docker/compose-cli/tests/framework"
+)
+
+func TestCommit(t *testing.T) {
+	c := framework.NewTestCLI(t)
+	err := runCommit(c.Context(), commitOpts{author: "John Doe", message: "add config", changes: []string{"ENV DEBUG=1"}}, []string{"id", "myimage:v1"})
+	assert.NilError(t, err)
+	assert.Equal(t, c.GetStdOut(), "Committing container \"id\" as \"myimage:v1\"\nsha256:0123456789abcdef\n")
+}
+
+func TestCommitNotFound(t *testing.T) {
+	c := framework.NewTestCLI(t)
+	err := runCommit(c.Context(), commitOpts{}, []string{"unknown"})
+	assert.Error(t, err, "container unknown not found")
+}
@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type scaffoldOptions struct {
+	Force bool
+	Port  int
+}
+
+func alphaCommand() *cobra.Command {
+	alphaCmd := &cobra.Command{
+		Use:   "alpha",
+		Short: "Experimental commands, which may change or be removed in future releases",
+	}
+	alphaCmd.AddCommand(alphaGenerateCommand())
+	return alphaCmd
+}
+
+func alphaGenerateCommand() *cobra.Command {
+	opts := scaffoldOptions{}
+	generateCmd := &cobra.Command{
+		Use:   "generate [DIRECTORY]",
+		Short: "Generate a starter Dockerfile and compose.yaml for the Go, Node or Python application in DIRECTORY",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runAlphaGenerate(opts, dir)
+		},
+	}
+	generateCmd.Flags().BoolVar(&opts.Force, "force", false, "Overwrite the existing Dockerfile and compose.yaml")
+	generateCmd.Flags().IntVar(&opts.Port, "port", 0, "Port the application listens on, the default of the detected framework when zero")
+	return generateCmd
+}
+
+func runAlphaGenerate(opts scaffoldOptions, dir string) error {
+	s, err := detectScaffold(dir, opts.Port)
+	if err != nil {
+		return err
+	}
+	files := map[string]string{
+		"Dockerfile":   s.Dockerfile,
+		"compose.yaml": s.composeFile(),
+	}
+	if !opts.Force {
+		for _, name := range []string{"Dockerfile", "compose.yaml"} {
+			if fileExists(dir, name) {
+				return errors.Wrapf(errdefs.ErrAlreadyExists, "%s already exists, use --force to overwrite it", filepath.Join(dir, name))
+			}
+		}
+	}
+	for _, name := range []string{"Dockerfile", "compose.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(files[name]), 0644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	fmt.Printf("Generated for a %s application listening on port %d, review the files then run `docker compose up --build`\n", s.Language, s.Port)
+	return nil
+}
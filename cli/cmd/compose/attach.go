@@ -0,0 +1,106 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containerd/console"
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type attachOptions struct {
+	composeOptions
+	Service    string
+	Index      int
+	DetachKeys string
+	NoStdin    bool
+}
+
+func attachCommand() *cobra.Command {
+	opts := attachOptions{}
+	attachCmd := &cobra.Command{
+		Use:   "attach [options] SERVICE",
+		Short: "Attach to the main process of a running service container.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Service = args[0]
+			return runAttach(cmd.Context(), opts)
+		},
+	}
+	attachCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	attachCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	attachCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	attachCmd.Flags().IntVar(&opts.Index, "index", 1, "Index of the container if there are multiple instances of a service")
+	attachCmd.Flags().StringVar(&opts.DetachKeys, "detach-keys", "", "Override the key sequence for detaching from the container")
+	attachCmd.Flags().BoolVar(&opts.NoStdin, "no-stdin", false, "Do not attach STDIN")
+
+	return attachCmd
+}
+
+func runAttach(ctx context.Context, opts attachOptions) error {
+	if opts.Index < 1 {
+		return fmt.Errorf("invalid index %d, container indexes start at 1", opts.Index)
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+
+	attachOpts := compose.AttachOptions{
+		Service:    opts.Service,
+		Index:      opts.Index,
+		DetachKeys: opts.DetachKeys,
+		Stdout:     os.Stdout,
+		Stderr:     os.Stderr,
+	}
+	if !opts.NoStdin {
+		_, isTerminal := term.GetFdInfo(os.Stdin)
+		attachOpts.Stdin = os.Stdin
+		attachOpts.Tty = isTerminal
+	}
+
+	if attachOpts.Tty {
+		con := console.Current()
+		if err := con.SetRaw(); err != nil {
+			return err
+		}
+		defer func() {
+			if err := con.Reset(); err != nil {
+				fmt.Println("Unable to close the console")
+			}
+		}()
+
+		attachOpts.Stdin = con
+		attachOpts.Stdout = con
+		attachOpts.Stderr = con
+	}
+
+	return c.ComposeService().Attach(ctx, projectName, attachOpts)
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/audit"
+	apicontext "github.com/docker/compose-cli/context"
+)
+
+// audited runs a mutating operation, recording it in the audit log of the project when enabled. The log
+// is opened first so an operation which can't be audited isn't run. project is nil for operations which
+// don't load the compose files.
+func audited(ctx context.Context, record audit.Record, project *types.Project, fn func() error) error {
+	dir := os.Getenv(audit.EnvVar)
+	if dir == "" {
+		return fn()
+	}
+	log, err := audit.Open(dir, record.Project)
+	if err != nil {
+		return err
+	}
+	defer log.Close() // nolint:errcheck
+
+	record.Time = time.Now().UTC()
+	record.User = audit.CurrentUser()
+	record.Context = apicontext.CurrentContext(ctx)
+	if project != nil {
+		record.ConfigHash, err = audit.ConfigHash(project)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = fn()
+	record.Status = audit.StatusSuccess
+	if err != nil {
+		record.Status = audit.StatusFailure
+		record.Error = err.Error()
+	}
+	if werr := log.Write(record); werr != nil {
+		if err == nil {
+			return werr
+		}
+		logrus.Warn(werr)
+	}
+	return err
+}
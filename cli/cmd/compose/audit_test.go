@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+
+	"github.com/docker/compose-cli/audit"
+	apicontext "github.com/docker/compose-cli/context"
+)
+
+func TestAudited(t *testing.T) {
+	dir := fs.NewDir(t, "audit")
+	defer dir.Remove()
+	assert.NilError(t, os.Setenv(audit.EnvVar, dir.Path()))
+	defer os.Unsetenv(audit.EnvVar) // nolint:errcheck
+
+	ctx := apicontext.WithCurrentContext(context.TODO(), "production")
+	project := &types.Project{Name: "shop", Services: types.Services{{Name: "web", Image: "nginx"}}}
+	err := audited(ctx, audit.Record{Project: "shop", Operation: "scale", Scale: map[string]int{"web": 3}}, project, func() error {
+		return errors.New("no such service")
+	})
+	assert.Error(t, err, "no such service")
+
+	content, err := ioutil.ReadFile(dir.Join("shop.jsonl"))
+	assert.NilError(t, err)
+	var record audit.Record
+	assert.NilError(t, json.Unmarshal([]byte(strings.TrimSpace(string(content))), &record))
+	hash, err := audit.ConfigHash(project)
+	assert.NilError(t, err)
+	assert.Equal(t, record.Operation, "scale")
+	assert.Equal(t, record.Context, "production")
+	assert.Equal(t, record.ConfigHash, hash)
+	assert.Equal(t, record.Status, audit.StatusFailure)
+	assert.Equal(t, record.Error, "no such service")
+	assert.DeepEqual(t, record.Scale, map[string]int{"web": 3})
+}
+
+func TestAuditedDisabled(t *testing.T) {
+	assert.NilError(t, os.Unsetenv(audit.EnvVar))
+	ran := false
+	err := audited(context.TODO(), audit.Record{Project: "shop", Operation: "down"}, nil, func() error {
+		ran = true
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Assert(t, ran)
+}
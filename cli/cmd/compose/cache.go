@@ -0,0 +1,113 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// projectCacheDir returns the directory where project names are cached
+var projectCacheDir = func() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docker-compose", "projects"), nil
+}
+
+// projectCacheKey hashes everything the project name may be computed from: the compose files
+// content and location, the working directory, the `.env` file and the COMPOSE_ variables.
+// Caching is skipped when the compose files can't be read.
+func (o *composeOptions) projectCacheKey() (string, bool) {
+	workingDir := o.WorkingDir
+	if workingDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", false
+		}
+		workingDir = wd
+	}
+	paths := o.ConfigPaths
+	if len(paths) == 0 {
+		file, ok := findDefaultComposeFile(workingDir)
+		if !ok {
+			return "", false
+		}
+		paths = []string{file}
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "workdir=%s\n", workingDir)
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return "", false
+		}
+		data, err := ioutil.ReadFile(abs)
+		if err != nil {
+			return "", false
+		}
+		fmt.Fprintf(h, "file=%s\n", abs)
+		h.Write(data)
+	}
+	if data, err := ioutil.ReadFile(filepath.Join(workingDir, ".env")); err == nil {
+		fmt.Fprint(h, "dotenv\n")
+		h.Write(data)
+	}
+	var env []string
+	for _, e := range append(os.Environ(), o.Environment...) {
+		if strings.HasPrefix(e, "COMPOSE_") {
+			env = append(env, e)
+		}
+	}
+	sort.Strings(env)
+	for _, e := range env {
+		fmt.Fprintf(h, "env=%s\n", e)
+	}
+	return hex.EncodeToString(h.Sum(nil)), true
+}
+
+func readCachedProjectName(key string) (string, bool) {
+	dir, err := projectCacheDir()
+	if err != nil {
+		return "", false
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, key))
+	if err != nil || len(data) == 0 {
+		return "", false
+	}
+	return string(data), true
+}
+
+// writeCachedProjectName stores the project name, failures only mean the next command loads the project again
+func writeCachedProjectName(key string, name string) {
+	dir, err := projectCacheDir()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	_ = ioutil.WriteFile(filepath.Join(dir, key), []byte(name), 0600)
+}
@@ -0,0 +1,71 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestProjectCacheKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+	file := filepath.Join(dir, "compose.yaml")
+	assert.NilError(t, ioutil.WriteFile(file, []byte("services:\n  web:\n    image: nginx\n"), 0644))
+
+	opts := composeOptions{WorkingDir: dir}
+	key, ok := opts.projectCacheKey()
+	assert.Assert(t, ok)
+	same, _ := opts.projectCacheKey()
+	assert.Equal(t, key, same)
+
+	withEnv := composeOptions{WorkingDir: dir, Environment: []string{"COMPOSE_PROJECT_NAME=other"}}
+	envKey, _ := withEnv.projectCacheKey()
+	assert.Assert(t, key != envKey)
+
+	assert.NilError(t, ioutil.WriteFile(file, []byte("services:\n  web:\n    image: httpd\n"), 0644))
+	changed, _ := opts.projectCacheKey()
+	assert.Assert(t, key != changed)
+
+	_, ok = (&composeOptions{WorkingDir: filepath.Join(dir, "missing")}).projectCacheKey()
+	assert.Assert(t, !ok)
+}
+
+func TestCachedProjectName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+	previous := projectCacheDir
+	projectCacheDir = func() (string, error) {
+		return filepath.Join(dir, "projects"), nil
+	}
+	defer func() {
+		projectCacheDir = previous
+	}()
+
+	_, ok := readCachedProjectName("key")
+	assert.Assert(t, !ok)
+	writeCachedProjectName("key", "demo")
+	name, ok := readCachedProjectName("key")
+	assert.Assert(t, ok)
+	assert.Equal(t, name, "demo")
+}
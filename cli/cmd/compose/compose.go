@@ -18,14 +18,21 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/types"
 	"github.com/spf13/pflag"
 
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/utils"
 )
 
 type composeOptions struct {
@@ -41,25 +48,71 @@ type composeOptions struct {
 
 func addComposeCommonFlags(f *pflag.FlagSet, opts *composeOptions) {
 	f.StringVarP(&opts.Name, "project-name", "p", "", "Project name")
-	f.StringVar(&opts.Format, "format", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+	addOutputFlags(f, opts)
+}
+
+func addOutputFlags(f *pflag.FlagSet, opts *composeOptions) {
+	f.StringVar(&opts.Format, "format", "", formatter.FormatUsage)
 	f.BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
 }
 
 func (o *composeOptions) toProjectName() (string, error) {
 	if o.Name != "" {
-		return o.Name, nil
+		return normalizeProjectName(o.Name)
 	}
 
-	options, err := o.toProjectOptions()
+	// commands only needing the project name don't load the compose files again while they are unchanged
+	key, cacheable := o.projectCacheKey()
+	if cacheable {
+		if name, ok := readCachedProjectName(key); ok {
+			return name, nil
+		}
+	}
+	project, err := o.toProject()
 	if err != nil {
 		return "", err
 	}
+	if cacheable {
+		writeCachedProjectName(key, project.Name)
+	}
+	return project.Name, nil
+}
+
+// toProject loads the compose project, preprocessing the compose files for the features compose-go doesn't support
+func (o *composeOptions) toProject() (*types.Project, error) {
+	configPaths, workingDir, cleanup, err := preprocessComposeFiles(o.ConfigPaths, o.WorkingDir, o.environment())
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+	opts := *o
+	opts.ConfigPaths = configPaths
+	opts.WorkingDir = workingDir
 
+	options, err := opts.toProjectOptions()
+	if err != nil {
+		return nil, err
+	}
 	project, err := cli.ProjectFromOptions(options)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return project.Name, nil
+	if err := opts.applyProjectName(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// environment is the environment the compose files are interpolated with
+func (o *composeOptions) environment() map[string]string {
+	env := map[string]string{}
+	for _, e := range append(os.Environ(), o.Environment...) {
+		parts := strings.SplitN(e, "=", 2)
+		if len(parts) == 2 {
+			env[parts[0]] = parts[1]
+		}
+	}
+	return env
 }
 
 func (o *composeOptions) toProjectOptions() (*cli.ProjectOptions, error) {
@@ -70,28 +123,140 @@ func (o *composeOptions) toProjectOptions() (*cli.ProjectOptions, error) {
 		cli.WithName(o.Name))
 }
 
+// filterServices restricts the project to the selected services and the services they depend on
+func filterServices(project *types.Project, services []string) error {
+	if len(services) == 0 {
+		return nil
+	}
+	selected, err := withDependencies(project, services)
+	if err != nil {
+		return err
+	}
+	enabled := types.Services{}
+	for _, s := range project.Services {
+		if utils.StringContains(selected, s.Name) {
+			enabled = append(enabled, s)
+		}
+	}
+	project.Services = enabled
+	return nil
+}
+
+// selectServices restricts the project to the selected services, without their dependencies
+func selectServices(project *types.Project, services []string) error {
+	if len(services) == 0 {
+		return nil
+	}
+	enabled := types.Services{}
+	var found []string
+	for _, s := range project.Services {
+		if utils.StringContains(services, s.Name) {
+			enabled = append(enabled, s)
+			found = append(found, s.Name)
+		}
+	}
+	for _, name := range services {
+		if !utils.StringContains(found, name) {
+			return fmt.Errorf("no such service: %q", name)
+		}
+	}
+	project.Services = enabled
+	return nil
+}
+
+// withDependencies returns the selected services along with all their transitive dependencies
+func withDependencies(project *types.Project, services []string) ([]string, error) {
+	byName := map[string]types.ServiceConfig{}
+	for _, s := range project.Services {
+		byName[s.Name] = s
+	}
+	var result []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		if utils.StringContains(result, name) {
+			return nil
+		}
+		service, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("no such service: %q", name)
+		}
+		result = append(result, name)
+		for _, dep := range compose.ServiceDependencies(service) {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range services {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 // Command returns the compose command with its child commands
 func Command(contextType string) *cobra.Command {
 	command := &cobra.Command{
 		Short: "Docker Compose",
 		Use:   "compose",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			warnLegacyFlags(cmd)
 			return checkComposeSupport(cmd.Context())
 		},
 	}
 
+	addLegacyFlags(command.PersistentFlags())
+
 	command.AddCommand(
 		upCommand(contextType),
 		downCommand(),
+		pullCommand(),
 		psCommand(),
 		listCommand(),
 		logsCommand(),
 		convertCommand(),
+		configCommand(),
+		runCommand(),
+		execCommand(),
+		attachCommand(),
+		envCommand(),
+		inspectCommand(),
+		generateCommand(),
+		publishCommand(),
+		importCommand(),
+		waitCommand(),
+		statsCommand(),
+		notifyCommand(),
+		watchCommand(),
+		dashboardCommand(),
+		scaleCommand(),
+		versionCommand(),
+		alphaCommand(),
 	)
 
 	return command
 }
 
+// addLegacyFlags accepts docker-compose v1 global flags, which have no effect or are handled by sub commands
+func addLegacyFlags(f *pflag.FlagSet) {
+	f.Bool("compatibility", false, "Run compose in backward compatibility mode")
+	_ = f.MarkDeprecated("compatibility", "deploy keys are always translated, this flag has no effect")
+	// allows `compose -d up`, the flag is then parsed by the sub command which defines it
+	f.BoolP("detach", "d", false, "Detached mode: Run containers in the background")
+	_ = f.MarkHidden("detach")
+}
+
+// warnLegacyFlags prints a deprecation notice for the global -d of docker-compose v1 on the commands which don't
+// run containers in the background, the flag is then ignored
+func warnLegacyFlags(cmd *cobra.Command) {
+	// the commands supporting it define their own detach flag, overriding the hidden global one
+	if f := cmd.Flags().Lookup("detach"); f != nil && f.Hidden && f.Changed {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Flag -d/--detach has been deprecated, it has no effect on %q\n", cmd.CommandPath())
+	}
+}
+
 func checkComposeSupport(ctx context.Context) error {
 	_, err := client.New(ctx)
 	if errdefs.IsNotFoundError(err) {
@@ -0,0 +1,107 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestFilterServices(t *testing.T) {
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+				DependsOn: map[string]types.ServiceDependency{
+					"db": {},
+				},
+			},
+			{
+				Name: "db",
+			},
+			{
+				Name: "worker",
+			},
+		},
+	}
+
+	err := filterServices(&project, []string{"web"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(project.Services), 2)
+	assert.Equal(t, project.Services[0].Name, "web")
+	assert.Equal(t, project.Services[1].Name, "db")
+}
+
+func TestFilterUnknownService(t *testing.T) {
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+			},
+		},
+	}
+
+	err := filterServices(&project, []string{"db"})
+	assert.Error(t, err, `no such service: "db"`)
+}
+
+func TestWarnLegacyFlags(t *testing.T) {
+	command := Command("")
+	for _, args := range [][]string{{"-d", "up"}, {"up", "-d"}, {"ps"}} {
+		cmd, flags, err := command.Find(args)
+		assert.NilError(t, err)
+		assert.NilError(t, cmd.ParseFlags(flags))
+		var b bytes.Buffer
+		cmd.SetErr(&b)
+		warnLegacyFlags(cmd)
+		assert.Equal(t, b.String(), "")
+	}
+
+	cmd, flags, err := command.Find([]string{"-d", "ps"})
+	assert.NilError(t, err)
+	assert.NilError(t, cmd.ParseFlags(flags))
+	var b bytes.Buffer
+	cmd.SetErr(&b)
+	warnLegacyFlags(cmd)
+	assert.Equal(t, b.String(), "Flag -d/--detach has been deprecated, it has no effect on \"compose ps\"\n")
+}
+
+func TestSelectServices(t *testing.T) {
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+				DependsOn: map[string]types.ServiceDependency{
+					"db": {},
+				},
+			},
+			{
+				Name: "db",
+			},
+		},
+	}
+
+	err := selectServices(&project, []string{"web"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, project.ServiceNames(), []string{"web"})
+
+	err = selectServices(&project, []string{"db"})
+	assert.Error(t, err, `no such service: "db"`)
+}
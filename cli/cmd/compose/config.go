@@ -0,0 +1,144 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type configOptions struct {
+	composeOptions
+	Backend string
+}
+
+func configCommand() *cobra.Command {
+	opts := configOptions{}
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Render the compose file, as deployed by a backend with --for-backend",
+		// rendering the project doesn't need a backend for the current context
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			warnLegacyFlags(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfig(cmd.Context(), os.Stdout, os.Stderr, opts)
+		},
+	}
+	configCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	configCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	configCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	configCmd.Flags().StringArrayVarP(&opts.Environment, "environment", "e", []string{}, "Environment variables")
+	configCmd.Flags().StringVar(&opts.Format, "format", "yaml", "Format the output. Values: [yaml | json]")
+	configCmd.Flags().StringVar(&opts.Backend, "for-backend", "", "Render the project as deployed by a backend. Values: [aci | ecs | local]")
+
+	return configCmd
+}
+
+func runConfig(ctx context.Context, out io.Writer, errOut io.Writer, opts configOptions) error {
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	if opts.Backend != "" {
+		warnings := &compose.Warnings{}
+		err := forBackend(compose.WithWarnings(ctx, warnings), project, opts.Backend)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings.List() {
+			fmt.Fprintf(errOut, "WARNING: %s\n", warning)
+		}
+	}
+	content, err := marshalProject(project, opts.Format)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+	return err
+}
+
+// forBackend applies the transformations of the backend to the project, the fields it ignores are removed
+func forBackend(ctx context.Context, project *types.Project, backend string) error {
+	config, err := compose.GetBackendConfig(backend)
+	if err != nil {
+		return err
+	}
+	if err := warnFeatures(ctx, project, config.Capabilities); err != nil {
+		return err
+	}
+	compose.RemoveUnsupportedFeatures(project, config.Capabilities)
+	if config.Normalize == nil {
+		return nil
+	}
+	return config.Normalize(ctx, project)
+}
+
+// marshalProject renders the project as a compose file, with the services indexed by name
+func marshalProject(project *types.Project, format string) ([]byte, error) {
+	services := map[string]types.ServiceConfig{}
+	for _, s := range project.Services {
+		services[s.Name] = s
+	}
+	config := map[string]interface{}{
+		"services": services,
+	}
+	if len(project.Networks) > 0 {
+		config["networks"] = project.Networks
+	}
+	if len(project.Volumes) > 0 {
+		config["volumes"] = project.Volumes
+	}
+	if len(project.Secrets) > 0 {
+		config["secrets"] = project.Secrets
+	}
+	if len(project.Configs) > 0 {
+		config["configs"] = project.Configs
+	}
+	for k, v := range project.Extensions {
+		config[k] = v
+	}
+
+	switch format {
+	case "json":
+		content, err := json.MarshalIndent(config, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case "yaml", "":
+		var buf bytes.Buffer
+		encoder := yamlv3.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(config); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), encoder.Close()
+	default:
+		return nil, fmt.Errorf("unsupported format %q, values are yaml and json", format)
+	}
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestConfigForBackend(t *testing.T) {
+	compose.RegisterBackendConfig(compose.BackendConfig{
+		Capabilities: compose.Capabilities{
+			Backend:   "test",
+			Supported: []string{compose.FeatureVolumes},
+		},
+		Normalize: func(ctx context.Context, project *types.Project) error {
+			project.Services[0].Image = "nginx:latest"
+			return nil
+		},
+	})
+	project := &types.Project{
+		Services: types.Services{{
+			Name:          "web",
+			Image:         "nginx",
+			ContainerName: "front",
+			HealthCheck:   &types.HealthCheckConfig{Test: []string{"CMD", "true"}},
+			Volumes:       []types.ServiceVolumeConfig{{Type: "volume", Source: "data", Target: "/data"}},
+		}},
+		Volumes: types.Volumes{"data": {}},
+	}
+
+	warnings := &compose.Warnings{}
+	err := forBackend(compose.WithWarnings(context.TODO(), warnings), project, "test")
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings.List()), 2)
+
+	content, err := marshalProject(project, "yaml")
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), `services:
+  web:
+    image: nginx:latest
+    volumes:
+    - type: volume
+      source: data
+      target: /data
+volumes:
+  data: {}
+`)
+
+	err = forBackend(context.TODO(), project, "unknown")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
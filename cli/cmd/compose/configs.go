@@ -0,0 +1,42 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// resolveConfigsContent moves the inline `content` attribute of the configs, which compose-go
+// doesn't support, to the x-content config extension
+func resolveConfigsContent(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		declared, _ := config["configs"].(map[interface{}]interface{})
+		for _, c := range declared {
+			cfg, ok := c.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if content, ok := cfg["content"]; ok {
+				cfg[compose.ConfigContentExtension] = content
+				delete(cfg, "content")
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,37 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveConfigsContent(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"configs": map[interface{}]interface{}{
+			"nginx": map[interface{}]interface{}{"content": "server { listen 80; }\n"},
+			"app":   map[interface{}]interface{}{"file": "./app.conf"},
+		},
+	}}
+
+	assert.Assert(t, resolveConfigsContent(configs))
+	declared := configs[0]["configs"].(map[interface{}]interface{})
+	assert.DeepEqual(t, declared["nginx"], map[interface{}]interface{}{"x-content": "server { listen 80; }\n"})
+	assert.DeepEqual(t, declared["app"], map[interface{}]interface{}{"file": "./app.conf"})
+}
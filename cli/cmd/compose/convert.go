@@ -20,7 +20,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/compose-spec/compose-go/cli"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/client"
@@ -51,12 +50,7 @@ func runConvert(ctx context.Context, opts composeOptions) error {
 		return err
 	}
 
-	options, err := opts.toProjectOptions()
-	if err != nil {
-		return err
-	}
-
-	project, err := cli.ProjectFromOptions(options)
+	project, err := opts.toProject()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,65 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/dashboard"
+)
+
+type dashboardOptions struct {
+	composeOptions
+	port int
+}
+
+func dashboardCommand() *cobra.Command {
+	opts := dashboardOptions{}
+	dashboardCmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Serve a local web dashboard showing the project services, their health, logs and dependencies",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDashboard(cmd.Context(), opts)
+		},
+	}
+	dashboardCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	dashboardCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	dashboardCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	dashboardCmd.Flags().IntVar(&opts.port, "port", 8080, "Port the dashboard listens on, on localhost")
+
+	return dashboardCmd
+}
+
+func runDashboard(ctx context.Context, opts dashboardOptions) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	// the dashboard exposes the project logs, it is only served on the loopback interface
+	addr := fmt.Sprintf("127.0.0.1:%d", opts.port)
+	fmt.Printf("Dashboard of project %s available at http://%s/\n", project.Name, addr)
+	return dashboard.New(c.ComposeService(), project).Serve(ctx, addr)
+}
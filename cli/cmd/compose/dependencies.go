@@ -0,0 +1,79 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// resolveOptionalDependencies moves the `depends_on` entries declared with `required: false`,
+// which compose-go doesn't support, to the x-optional-depends-on service extension. Optional
+// dependencies on services the project doesn't declare are dropped with a warning.
+func resolveOptionalDependencies(configs []map[string]interface{}) bool {
+	declared := map[string]bool{}
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for name := range services {
+			declared[fmt.Sprint(name)] = true
+		}
+	}
+
+	changed := false
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for name, s := range services {
+			service, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			dependsOn, ok := service["depends_on"].(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			var optional []interface{}
+			for dep, d := range dependsOn {
+				dependency, ok := d.(map[interface{}]interface{})
+				if !ok {
+					continue
+				}
+				required, ok := dependency["required"]
+				if !ok {
+					continue
+				}
+				changed = true
+				delete(dependency, "required")
+				if required != false {
+					continue
+				}
+				if !declared[fmt.Sprint(dep)] {
+					logrus.Warnf("service %q: optional dependency %q is not declared, ignoring", name, dep)
+					delete(dependsOn, dep)
+					continue
+				}
+				optional = append(optional, fmt.Sprint(dep))
+			}
+			if len(optional) > 0 {
+				service[compose.OptionalDependenciesExtension] = optional
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,47 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveOptionalDependencies(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"services": map[interface{}]interface{}{
+			"web": map[interface{}]interface{}{
+				"depends_on": map[interface{}]interface{}{
+					"db":      map[interface{}]interface{}{"condition": "service_healthy", "required": true},
+					"cache":   map[interface{}]interface{}{"required": false},
+					"metrics": map[interface{}]interface{}{"required": false},
+				},
+			},
+			"db":    map[interface{}]interface{}{},
+			"cache": map[interface{}]interface{}{},
+		},
+	}}
+
+	assert.Assert(t, resolveOptionalDependencies(configs))
+	web := configs[0]["services"].(map[interface{}]interface{})["web"].(map[interface{}]interface{})
+	assert.DeepEqual(t, web["depends_on"], map[interface{}]interface{}{
+		"db":    map[interface{}]interface{}{"condition": "service_healthy"},
+		"cache": map[interface{}]interface{}{},
+	})
+	assert.DeepEqual(t, web["x-optional-depends-on"], []interface{}{"cache"})
+}
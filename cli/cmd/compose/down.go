@@ -18,40 +18,108 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/audit"
+	"github.com/docker/compose-cli/errdefs"
 	"github.com/docker/compose-cli/progress"
 )
 
+type downOptions struct {
+	composeOptions
+	projects projectsOptions
+	DryRun   bool
+}
+
 func downCommand() *cobra.Command {
-	opts := composeOptions{}
+	opts := downOptions{}
 	downCmd := &cobra.Command{
-		Use: "down",
+		Use:   "down",
+		Short: "Stop and remove the containers and the networks of the project, volumes are preserved",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDown(cmd.Context(), opts)
 		},
 	}
-	downCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	addProjectsFlags(downCmd.Flags(), &opts.projects)
 	downCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
 	downCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	downCmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Only list the resources which would be removed or preserved, without removing them")
 
 	return downCmd
 }
 
-func runDown(ctx context.Context, opts composeOptions) error {
+func runDown(ctx context.Context, opts downOptions) error {
 	c, err := client.New(ctx)
 	if err != nil {
 		return err
 	}
+	projectNames, err := opts.projects.projectNames(ctx, c.ComposeService(), opts.composeOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := printDownPlans(ctx, os.Stdout, c.ComposeService(), projectNames, opts.DryRun); err != nil {
+		return err
+	}
+	if opts.DryRun {
+		return nil
+	}
 
 	_, err = progress.Run(ctx, func(ctx context.Context) (string, error) {
-		projectName, err := opts.toProjectName()
-		if err != nil {
-			return "", err
-		}
-		return projectName, c.ComposeService().Down(ctx, projectName)
+		return strings.Join(projectNames, ", "), forEachProject(ctx, projectNames, func(ctx context.Context, projectName string) error {
+			return audited(ctx, audit.Record{Project: projectName, Operation: "down"}, nil, func() error {
+				return c.ComposeService().Down(ctx, projectName)
+			})
+		})
 	})
 	return err
 }
+
+// printDownPlans prints the resources down removes or preserves for each project, backends
+// which can't tell are skipped unless it's a dry run
+func printDownPlans(ctx context.Context, w io.Writer, service compose.Service, projectNames []string, dryRun bool) error {
+	for _, projectName := range projectNames {
+		plan, err := service.DownPlan(ctx, projectName)
+		switch {
+		case err == nil:
+			if len(projectNames) > 1 {
+				fmt.Fprintf(w, "Project %s:\n", projectName)
+			}
+			printDownPlan(w, plan)
+		case !errdefs.IsErrNotImplemented(err) || dryRun:
+			return err
+		}
+	}
+	return nil
+}
+
+// printDownPlan lists the resources removed by `down` then those it preserves
+func printDownPlan(w io.Writer, plan []compose.DownResource) {
+	var removed, kept []compose.DownResource
+	for _, r := range plan {
+		if r.Remove {
+			removed = append(removed, r)
+		} else {
+			kept = append(kept, r)
+		}
+	}
+	if len(removed) > 0 {
+		fmt.Fprintln(w, "Will remove:")
+		for _, r := range removed {
+			fmt.Fprintf(w, "  %s %s\n", r.Type, r.Name)
+		}
+	}
+	if len(kept) > 0 {
+		fmt.Fprintln(w, "Will keep:")
+		for _, r := range kept {
+			fmt.Fprintf(w, "  %s %s (%s)\n", r.Type, r.Name, r.Reason)
+		}
+	}
+}
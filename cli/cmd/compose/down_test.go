@@ -0,0 +1,81 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type downPlanService struct {
+	compose.Service
+	plans map[string][]compose.DownResource
+}
+
+func (s downPlanService) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	plan, ok := s.plans[projectName]
+	if !ok {
+		return nil, errdefs.ErrNotImplemented
+	}
+	return plan, nil
+}
+
+func TestPrintDownPlan(t *testing.T) {
+	var b bytes.Buffer
+	printDownPlan(&b, []compose.DownResource{
+		{Type: compose.ContainerResource, Name: "app_web_1", Remove: true},
+		{Type: compose.NetworkResource, Name: "app_default", Remove: true},
+		{Type: compose.NetworkResource, Name: "proxy", Reason: "external"},
+		{Type: compose.VolumeResource, Name: "app_data", Reason: "volumes are preserved"},
+	})
+	assert.Equal(t, b.String(), `Will remove:
+  container app_web_1
+  network app_default
+Will keep:
+  network proxy (external)
+  volume app_data (volumes are preserved)
+`)
+}
+
+func TestPrintDownPlans(t *testing.T) {
+	service := downPlanService{plans: map[string][]compose.DownResource{
+		"api": {{Type: compose.ContainerResource, Name: "api_web_1", Remove: true}},
+		"web": {{Type: compose.ContainerResource, Name: "web_web_1", Remove: true}},
+	}}
+	var b bytes.Buffer
+	assert.NilError(t, printDownPlans(context.TODO(), &b, service, []string{"api", "web"}, true))
+	assert.Equal(t, b.String(), `Project api:
+Will remove:
+  container api_web_1
+Project web:
+Will remove:
+  container web_web_1
+`)
+
+	// backends without a plan only fail dry runs
+	b.Reset()
+	assert.NilError(t, printDownPlans(context.TODO(), &b, service, []string{"other"}, false))
+	assert.Equal(t, b.String(), "")
+	err := printDownPlans(context.TODO(), &b, service, []string{"other"}, true)
+	assert.Assert(t, errdefs.IsErrNotImplemented(err))
+}
@@ -0,0 +1,216 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/formatter"
+)
+
+const shellFormat = "shell"
+
+func envCommand() *cobra.Command {
+	opts := composeOptions{}
+	envCmd := &cobra.Command{
+		Use:   "env [SERVICE...]",
+		Short: "Print the published endpoints of the running services as shell exports",
+		Long: `Print the published endpoints of the running services, a variable being set for each published port:
+
+$ eval "$(docker compose env)"
+$ curl http://$WEB_80/`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnv(cmd.Context(), opts, args)
+		},
+	}
+	envCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	envCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	envCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	envCmd.Flags().StringVar(&opts.Format, "format", shellFormat, "Format the output. Values: [shell | json]")
+
+	return envCmd
+}
+
+func runEnv(ctx context.Context, opts composeOptions, services []string) error {
+	format := strings.ToLower(opts.Format)
+	if format != shellFormat && format != formatter.JSON {
+		return errors.Wrapf(errdefs.ErrParsingFailed, "format value %q could not be parsed", opts.Format)
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	serviceList, err := c.ComposeService().Ps(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	endpoints, err := serviceEndpoints(serviceList, services)
+	if err != nil {
+		return err
+	}
+	if format == formatter.JSON {
+		return formatter.Print(endpoints, format, os.Stdout, nil)
+	}
+	printEndpointExports(os.Stdout, endpoints)
+	return nil
+}
+
+// endpoint is a port published by a service, where clients outside of the project reach it
+type endpoint struct {
+	Service       string
+	Variable      string
+	Host          string
+	PublishedPort int
+	TargetPort    int
+	Protocol      string
+	Address       string
+}
+
+// serviceEndpoints lists the published ports of the services, all services when none is selected
+func serviceEndpoints(serviceList []compose.ServiceStatus, services []string) ([]endpoint, error) {
+	selected := map[string]bool{}
+	for _, s := range services {
+		selected[s] = true
+	}
+	endpoints := []endpoint{}
+	found := map[string]bool{}
+	seen := map[string]bool{}
+	for _, s := range serviceList {
+		if len(selected) > 0 && !selected[s.Name] {
+			continue
+		}
+		found[s.Name] = true
+		for _, port := range s.Ports {
+			for _, e := range parsePublishedPorts(s.Name, port) {
+				// replicas publish the same target port on distinct host ports, the first one is kept
+				if seen[e.Variable] {
+					continue
+				}
+				seen[e.Variable] = true
+				endpoints = append(endpoints, e)
+			}
+		}
+	}
+	for _, s := range services {
+		if !found[s] {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "service %q is not running", s)
+		}
+	}
+	return endpoints, nil
+}
+
+// parsePublishedPorts parses the ports reported by the backends, as HOST:PUBLISHED->TARGET/PROTOCOL where ports
+// can be ranges, ports which aren't published are ignored
+func parsePublishedPorts(service string, port string) []endpoint {
+	parts := strings.SplitN(port, "->", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+	i := strings.LastIndex(parts[0], ":")
+	if i < 0 {
+		return nil
+	}
+	host, published := parts[0][:i], parts[0][i+1:]
+	target, protocol := parts[1], "tcp"
+	if j := strings.Index(target, "/"); j >= 0 {
+		target, protocol = target[:j], target[j+1:]
+	}
+	publishedFirst, publishedLast, err := parsePortRange(published)
+	if err != nil {
+		return nil
+	}
+	targetFirst, targetLast, err := parsePortRange(target)
+	if err != nil || publishedLast-publishedFirst != targetLast-targetFirst {
+		return nil
+	}
+	switch host {
+	case "", "0.0.0.0", "::", "[::]":
+		host = "localhost"
+	}
+
+	endpoints := []endpoint{}
+	for offset := 0; offset <= targetLast-targetFirst; offset++ {
+		e := endpoint{
+			Service:       service,
+			Host:          host,
+			PublishedPort: publishedFirst + offset,
+			TargetPort:    targetFirst + offset,
+			Protocol:      protocol,
+		}
+		e.Address = fmt.Sprintf("%s:%d", e.Host, e.PublishedPort)
+		if strings.Contains(e.Host, ":") && !strings.HasPrefix(e.Host, "[") {
+			e.Address = fmt.Sprintf("[%s]:%d", e.Host, e.PublishedPort)
+		}
+		e.Variable = endpointVariable(e)
+		endpoints = append(endpoints, e)
+	}
+	return endpoints
+}
+
+func parsePortRange(value string) (int, int, error) {
+	bounds := strings.SplitN(value, "-", 2)
+	first, err := strconv.Atoi(bounds[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bounds) == 1 {
+		return first, first, nil
+	}
+	last, err := strconv.Atoi(bounds[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return first, last, nil
+}
+
+// endpointVariable names the variable of an endpoint SERVICE_TARGETPORT, suffixed with the protocol when not tcp
+func endpointVariable(e endpoint) string {
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, e.Service)
+	name = fmt.Sprintf("%s_%d", strings.ToUpper(name), e.TargetPort)
+	if e.Protocol != "tcp" {
+		name += "_" + strings.ToUpper(e.Protocol)
+	}
+	return name
+}
+
+func printEndpointExports(w io.Writer, endpoints []endpoint) {
+	for _, e := range endpoints {
+		fmt.Fprintf(w, "export %s=%s\n", e.Variable, e.Address)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestServiceEndpoints(t *testing.T) {
+	serviceList := []compose.ServiceStatus{
+		{Name: "web", Ports: []string{"0.0.0.0:8080->80/tcp", "0.0.0.0:8081->80/tcp", "443/tcp"}},
+		{Name: "dns-server", Ports: []string{"10.0.0.4:53->53/udp"}},
+		{Name: "api", Ports: []string{"api.eastus.azurecontainer.io:9000-9001->9000-9001/tcp"}},
+		{Name: "worker"},
+	}
+
+	endpoints, err := serviceEndpoints(serviceList, nil)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	printEndpointExports(&b, endpoints)
+	assert.Equal(t, b.String(), `export WEB_80=localhost:8080
+export DNS_SERVER_53_UDP=10.0.0.4:53
+export API_9000=api.eastus.azurecontainer.io:9000
+export API_9001=api.eastus.azurecontainer.io:9001
+`)
+
+	endpoints, err = serviceEndpoints(serviceList, []string{"dns-server"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, endpoints, []endpoint{{
+		Service:       "dns-server",
+		Variable:      "DNS_SERVER_53_UDP",
+		Host:          "10.0.0.4",
+		PublishedPort: 53,
+		TargetPort:    53,
+		Protocol:      "udp",
+		Address:       "10.0.0.4:53",
+	}})
+
+	_, err = serviceEndpoints(serviceList, []string{"cache"})
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestParsePublishedPortsIPv6(t *testing.T) {
+	endpoints := parsePublishedPorts("web", "fd00::1:8080->80/tcp")
+	assert.Equal(t, len(endpoints), 1)
+	assert.Equal(t, endpoints[0].Address, "[fd00::1]:8080")
+}
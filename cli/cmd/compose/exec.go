@@ -0,0 +1,132 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/containerd/console"
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/audit"
+)
+
+type execOptions struct {
+	composeOptions
+	Service     string
+	Command     []string
+	Index       int
+	NoTty       bool
+	Detach      bool
+	Environment []string
+}
+
+func execCommand() *cobra.Command {
+	opts := execOptions{}
+	execCmd := &cobra.Command{
+		Use:   "exec [options] SERVICE COMMAND [ARGS...]",
+		Short: "Execute a command in a running container.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Service = args[0]
+			opts.Command = args[1:]
+			return runExec(cmd.Context(), opts)
+		},
+	}
+	execCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	execCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	execCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	execCmd.Flags().IntVar(&opts.Index, "index", 1, "Index of the container if there are multiple instances of a service")
+	execCmd.Flags().BoolVarP(&opts.NoTty, "no-TTY", "T", false, "Disable pseudo-tty allocation. By default a TTY is allocated when stdin is a terminal")
+	execCmd.Flags().BoolVarP(&opts.Detach, "detach", "d", false, "Detached mode: Run command in the background")
+	execCmd.Flags().StringArrayVarP(&opts.Environment, "env", "e", []string{}, "Set environment variables, KEY alone takes the value of the variable in the current environment")
+	execCmd.Flags().SetInterspersed(false)
+
+	return execCmd
+}
+
+func runExec(ctx context.Context, opts execOptions) error {
+	if opts.Index < 1 {
+		return fmt.Errorf("invalid index %d, container indexes start at 1", opts.Index)
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+
+	_, isTerminal := term.GetFdInfo(os.Stdin)
+	execOpts := compose.ExecOptions{
+		Service:     opts.Service,
+		Index:       opts.Index,
+		Command:     opts.Command,
+		Environment: resolveExecEnvironment(opts.Environment, os.LookupEnv),
+		Detach:      opts.Detach,
+		Tty:         isTerminal && !opts.NoTty && !opts.Detach,
+		Stdin:       os.Stdin,
+		Stdout:      os.Stdout,
+		Stderr:      os.Stderr,
+	}
+
+	if execOpts.Tty {
+		con := console.Current()
+		if err := con.SetRaw(); err != nil {
+			return err
+		}
+		defer func() {
+			if err := con.Reset(); err != nil {
+				fmt.Println("Unable to close the console")
+			}
+		}()
+
+		execOpts.Stdin = con
+		execOpts.Stdout = con
+		execOpts.Stderr = con
+	}
+
+	record := audit.Record{Project: projectName, Operation: "exec", Services: []string{opts.Service}, Command: opts.Command}
+	return audited(ctx, record, nil, func() error {
+		return c.ComposeService().Exec(ctx, projectName, execOpts)
+	})
+}
+
+// resolveExecEnvironment completes the variables set without a value with their value in the current
+// environment, variables which are not set in the current environment are dropped
+func resolveExecEnvironment(environment []string, lookupEnv func(string) (string, bool)) []string {
+	resolved := []string{}
+	for _, e := range environment {
+		if strings.Contains(e, "=") {
+			resolved = append(resolved, e)
+			continue
+		}
+		if value, ok := lookupEnv(e); ok {
+			resolved = append(resolved, e+"="+value)
+		}
+	}
+	return resolved
+}
@@ -0,0 +1,169 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// templatesExtension declares reusable service fragments
+	templatesExtension = "x-templates"
+	// templateExtension lists the templates a service is based on
+	templateExtension = "x-template"
+)
+
+// expandTemplates applies the `x-templates` a service refers to with `x-template`, the
+// attributes declared by the service taking precedence. Templates are resolved across all
+// the compose files, later files overriding templates with the same name.
+func expandTemplates(configs []map[string]interface{}) (bool, error) {
+	templates := map[string]map[interface{}]interface{}{}
+	for _, config := range configs {
+		declared, _ := config[templatesExtension].(map[interface{}]interface{})
+		for name, t := range declared {
+			template, ok := t.(map[interface{}]interface{})
+			if !ok {
+				return false, errors.Errorf("template %q must be a mapping", name)
+			}
+			templates[fmt.Sprint(name)] = template
+		}
+	}
+
+	expanded := false
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for name, s := range services {
+			service, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := service[templateExtension]; !ok {
+				continue
+			}
+			expanded = true
+			if err := applyTemplates(service, templates, nil); err != nil {
+				return false, errors.Wrapf(err, "service %q", name)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+func applyTemplates(target map[interface{}]interface{}, templates map[string]map[interface{}]interface{}, parents []string) error {
+	var names []string
+	switch t := target[templateExtension].(type) {
+	case string:
+		names = []string{t}
+	case []interface{}:
+		for _, name := range t {
+			names = append(names, fmt.Sprint(name))
+		}
+	default:
+		return errors.Errorf("invalid %s %v", templateExtension, t)
+	}
+	delete(target, templateExtension)
+
+	for _, name := range names {
+		for _, parent := range parents {
+			if parent == name {
+				return errors.Errorf("template cycle detected: %s", strings.Join(append(parents, name), " -> "))
+			}
+		}
+		template, ok := templates[name]
+		if !ok {
+			return errors.Errorf("undefined template %q", name)
+		}
+		resolved := deepCopy(template).(map[interface{}]interface{})
+		if _, ok := resolved[templateExtension]; ok {
+			if err := applyTemplates(resolved, templates, append(parents, name)); err != nil {
+				return err
+			}
+		}
+		applyDefaults(target, resolved)
+	}
+	return nil
+}
+
+// applyDefaults sets the attributes of defaults target doesn't declare, merging nested mappings
+func applyDefaults(target map[interface{}]interface{}, defaults map[interface{}]interface{}) {
+	for k, v := range defaults {
+		current, ok := target[k]
+		if !ok {
+			target[k] = deepCopy(v)
+			continue
+		}
+		currentMap, ok := current.(map[interface{}]interface{})
+		defaultMap, isMap := v.(map[interface{}]interface{})
+		if ok && isMap {
+			applyDefaults(currentMap, defaultMap)
+		}
+	}
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			m[k] = deepCopy(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = deepCopy(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
+
+// hoistExtensions moves the top-level `x-` extensions of all the compose files to the
+// first one, as compose-go only keeps those of the first file when merging. Extensions
+// declared by included files don't override those of the project.
+func hoistExtensions(main []map[string]interface{}, included []map[string]interface{}) bool {
+	if len(main) == 0 {
+		return false
+	}
+	hoisted := false
+	first := main[0]
+	for _, config := range main[1:] {
+		for k, v := range config {
+			if strings.HasPrefix(k, "x-") {
+				first[k] = v
+				delete(config, k)
+				hoisted = true
+			}
+		}
+	}
+	for _, config := range included {
+		for k, v := range config {
+			if strings.HasPrefix(k, "x-") {
+				if _, ok := first[k]; !ok {
+					first[k] = v
+				}
+				delete(config, k)
+				hoisted = true
+			}
+		}
+	}
+	return hoisted
+}
@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestExpandTemplates(t *testing.T) {
+	dir := fs.NewDir(t, "templates",
+		fs.WithFile("compose.yaml", `x-templates:
+  base:
+    restart: always
+    environment:
+      LOG_LEVEL: info
+  java:
+    x-template: base
+    image: openjdk
+services:
+  api:
+    x-template: java
+    environment:
+      LOG_LEVEL: debug
+`))
+	defer dir.Remove()
+
+	paths, _, cleanup, err := preprocessComposeFiles([]string{dir.Join("compose.yaml")}, "", nil)
+	defer cleanup()
+	assert.NilError(t, err)
+
+	config, err := readComposeFile(paths[0])
+	assert.NilError(t, err)
+	api := config["services"].(map[interface{}]interface{})["api"].(map[interface{}]interface{})
+	assert.DeepEqual(t, api, map[interface{}]interface{}{
+		"image":   "openjdk",
+		"restart": "always",
+		"environment": map[interface{}]interface{}{
+			"LOG_LEVEL": "debug",
+		},
+	})
+}
+
+func TestExpandUndefinedTemplate(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"services": map[interface{}]interface{}{
+			"api": map[interface{}]interface{}{"x-template": "missing"},
+		},
+	}}
+	_, err := expandTemplates(configs)
+	assert.Error(t, err, `service "api": undefined template "missing"`)
+}
+
+func TestHoistExtensions(t *testing.T) {
+	main := []map[string]interface{}{
+		{"x-owner": "team-a", "services": map[interface{}]interface{}{}},
+		{"x-owner": "team-b", "x-region": "eu"},
+	}
+	included := []map[string]interface{}{{"x-region": "us", "x-tier": "backend"}}
+
+	assert.Assert(t, hoistExtensions(main, included))
+	assert.Equal(t, main[0]["x-owner"], "team-b")
+	assert.Equal(t, main[0]["x-region"], "eu")
+	assert.Equal(t, main[0]["x-tier"], "backend")
+	_, ok := main[1]["x-owner"]
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,199 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type systemdOptions struct {
+	composeOptions
+	OutputDir string
+	Docker    string
+}
+
+func generateCommand() *cobra.Command {
+	generateCmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate configuration to run the compose project with other tools",
+	}
+	generateCmd.AddCommand(generateSystemdCommand())
+	return generateCmd
+}
+
+func generateSystemdCommand() *cobra.Command {
+	opts := systemdOptions{}
+	systemdCmd := &cobra.Command{
+		Use:   "systemd [SERVICE...]",
+		Short: "Generate a systemd unit file per service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGenerateSystemd(opts, args)
+		},
+	}
+	systemdCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	systemdCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	systemdCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	systemdCmd.Flags().StringArrayVarP(&opts.Environment, "environment", "e", []string{}, "Environment variables")
+	systemdCmd.Flags().StringVarP(&opts.OutputDir, "output-dir", "o", "", "Write unit files to DIRECTORY instead of the standard output")
+	systemdCmd.Flags().StringVar(&opts.Docker, "docker", "/usr/bin/docker", "Path of the docker binary used by the units")
+
+	return systemdCmd
+}
+
+func runGenerateSystemd(opts systemdOptions, services []string) error {
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	err = filterServices(project, services)
+	if err != nil {
+		return err
+	}
+
+	var configPaths []string
+	for _, f := range opts.ConfigPaths {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return err
+		}
+		configPaths = append(configPaths, abs)
+	}
+
+	units := systemdUnits(project, configPaths, opts.Docker)
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if opts.OutputDir == "" {
+		for _, name := range names {
+			fmt.Printf("# %s\n%s\n", name, units[name])
+		}
+		return nil
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(opts.OutputDir, name)
+		if err := ioutil.WriteFile(path, []byte(units[name]), 0644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+func systemdUnitName(project string, service string) string {
+	return fmt.Sprintf("%s-%s.service", project, service)
+}
+
+// systemdUnits returns the unit file content for each service of the project, indexed by unit name.
+// Each unit runs the service with `docker compose up`, and is ordered after the units of the
+// services it depends on.
+func systemdUnits(project *types.Project, configPaths []string, docker string) map[string]string {
+	command := []string{docker, "compose"}
+	var up []string
+	// systemd starts the dependencies with their own units
+	up = append(up, "up", "--no-deps", "--project-name", project.Name)
+	if project.WorkingDir != "" {
+		up = append(up, "--workdir", project.WorkingDir)
+	}
+	for _, f := range configPaths {
+		up = append(up, "--file", f)
+	}
+
+	units := map[string]string{}
+	for _, service := range project.Services {
+		requires := []string{"docker.service"}
+		deps := compose.ServiceDependencies(service)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			requires = append(requires, systemdUnitName(project.Name, dep))
+		}
+
+		var b strings.Builder
+		b.WriteString("[Unit]\n")
+		fmt.Fprintf(&b, "Description=Service %s of compose project %s\n", service.Name, project.Name)
+		fmt.Fprintf(&b, "After=%s\n", strings.Join(requires, " "))
+		fmt.Fprintf(&b, "Requires=%s\n", strings.Join(requires, " "))
+		b.WriteString("\n[Service]\n")
+		if project.WorkingDir != "" {
+			// paths aren't quoted in this setting, only specifiers are expanded
+			fmt.Fprintf(&b, "WorkingDirectory=%s\n", strings.ReplaceAll(project.WorkingDir, "%", "%%"))
+		}
+		fmt.Fprintf(&b, "ExecStart=%s\n", systemdCommand(append(append(command, up...), service.Name)))
+		stop := fmt.Sprintf("%s ps -q --filter label=com.docker.compose.project=%s --filter label=com.docker.compose.service=%s | xargs -r %s stop",
+			shellQuote(docker), shellQuote(project.Name), shellQuote(service.Name), shellQuote(docker))
+		fmt.Fprintf(&b, "ExecStop=%s\n", systemdCommand([]string{"/bin/sh", "-c", stop}))
+		fmt.Fprintf(&b, "Restart=%s\n", systemdRestart(service.Restart))
+		b.WriteString("\n[Install]\n")
+		b.WriteString("WantedBy=multi-user.target\n")
+
+		units[systemdUnitName(project.Name, service.Name)] = b.String()
+	}
+	return units
+}
+
+// systemdCommand quotes the arguments of a command line of a unit file
+func systemdCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = systemdQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// systemdQuote quotes a value of a unit file when it contains spaces, quotes or specifiers
+func systemdQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\%$") {
+		return s
+	}
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "%", "%%", "$", "$$").Replace(s)
+	return `"` + s + `"`
+}
+
+// shellQuote quotes an argument of a shell command line
+func shellQuote(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\"'\\$`;&|<>()*?[]#~") {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// systemdRestart maps a compose restart policy to the systemd Restart= setting
+func systemdRestart(restart string) string {
+	switch {
+	case restart == "always", restart == "unless-stopped":
+		return "always"
+	case strings.HasPrefix(restart, "on-failure"):
+		return "on-failure"
+	default:
+		return "no"
+	}
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestSystemdUnits(t *testing.T) {
+	project := types.Project{
+		Name:       "myproject",
+		WorkingDir: "/srv/myproject",
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+				DependsOn: map[string]types.ServiceDependency{
+					"db": {},
+				},
+				Restart: "unless-stopped",
+			},
+			{
+				Name: "db",
+			},
+		},
+	}
+
+	units := systemdUnits(&project, []string{"/srv/myproject/compose.yaml"}, "/usr/bin/docker")
+	assert.Equal(t, len(units), 2)
+	assert.Equal(t, units["myproject-web.service"], `[Unit]
+Description=Service web of compose project myproject
+After=docker.service myproject-db.service
+Requires=docker.service myproject-db.service
+
+[Service]
+WorkingDirectory=/srv/myproject
+ExecStart=/usr/bin/docker compose up --no-deps --project-name myproject --workdir /srv/myproject --file /srv/myproject/compose.yaml web
+ExecStop=/bin/sh -c "/usr/bin/docker ps -q --filter label=com.docker.compose.project=myproject --filter label=com.docker.compose.service=web | xargs -r /usr/bin/docker stop"
+Restart=always
+
+[Install]
+WantedBy=multi-user.target
+`)
+	assert.Assert(t, units["myproject-db.service"] != "")
+}
+
+func TestSystemdUnitsQuotePaths(t *testing.T) {
+	project := types.Project{
+		Name:       "myproject",
+		WorkingDir: "/srv/my project",
+		Services:   []types.ServiceConfig{{Name: "web"}},
+	}
+
+	units := systemdUnits(&project, []string{"/srv/my project/compose.yaml"}, "/opt/docker bin/docker")
+	assert.Equal(t, units["myproject-web.service"], `[Unit]
+Description=Service web of compose project myproject
+After=docker.service
+Requires=docker.service
+
+[Service]
+WorkingDirectory=/srv/my project
+ExecStart="/opt/docker bin/docker" compose up --no-deps --project-name myproject --workdir "/srv/my project" --file "/srv/my project/compose.yaml" web
+ExecStop=/bin/sh -c "'/opt/docker bin/docker' ps -q --filter label=com.docker.compose.project=myproject --filter label=com.docker.compose.service=web | xargs -r '/opt/docker bin/docker' stop"
+Restart=no
+
+[Install]
+WantedBy=multi-user.target
+`)
+}
+
+func TestSystemdRestart(t *testing.T) {
+	assert.Equal(t, systemdRestart("always"), "always")
+	assert.Equal(t, systemdRestart("unless-stopped"), "always")
+	assert.Equal(t, systemdRestart("on-failure:3"), "on-failure")
+	assert.Equal(t, systemdRestart(""), "no")
+	assert.Equal(t, systemdRestart("no"), "no")
+}
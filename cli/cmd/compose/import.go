@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+	"github.com/sanathkr/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/kube"
+)
+
+type importOptions struct {
+	From   string
+	Output string
+}
+
+func importCommand() *cobra.Command {
+	opts := importOptions{}
+	importCmd := &cobra.Command{
+		Use:   "import --from k8s PATH...",
+		Short: "Translate Kubernetes Deployments and Services into a compose file",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(opts, args)
+		},
+	}
+	importCmd.Flags().StringVar(&opts.From, "from", "k8s", "Format of the files to import. Values: [k8s]")
+	importCmd.Flags().StringVarP(&opts.Output, "output", "o", "", "Write the compose file to FILE instead of the standard output")
+
+	return importCmd
+}
+
+func runImport(opts importOptions, paths []string) error {
+	if opts.From != "k8s" {
+		return errors.Errorf("unsupported import format %q", opts.From)
+	}
+	data, err := kube.LoadManifests(paths...)
+	if err != nil {
+		return err
+	}
+	services, err := kube.ToCompose(data)
+	if err != nil {
+		return err
+	}
+	if len(services) == 0 {
+		return errors.New("no Deployment found")
+	}
+
+	byName := map[string]types.ServiceConfig{}
+	for _, s := range services {
+		byName[s.Name] = s
+	}
+	out, err := yaml.Marshal(map[string]interface{}{
+		"services": byName,
+	})
+	if err != nil {
+		return err
+	}
+	if opts.Output == "" {
+		fmt.Print(string(out))
+		return nil
+	}
+	return ioutil.WriteFile(opts.Output, out, 0644)
+}
@@ -0,0 +1,311 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/compose-spec/compose-go/template"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+)
+
+// includedResources are the top-level sections an included file must not redefine
+var includedResources = []string{"services", "networks", "volumes", "secrets", "configs"}
+
+// loadIncludes loads the files listed in the `include` section of config, which is removed,
+// along with the files they include themselves. Each included file is interpolated with its own
+// env files (`.env` in its project directory by default), environment overriding them. Included
+// files aren't interpolated when environment is nil. parents is used to detect include cycles.
+func loadIncludes(config map[string]interface{}, dir string, environment map[string]string, parents []string) ([]map[string]interface{}, error) {
+	entries, ok := config["include"].([]interface{})
+	delete(config, "include")
+	if !ok {
+		return nil, errors.New("include must be a list")
+	}
+	var result []map[string]interface{}
+	for _, entry := range entries {
+		paths, projectDir, envFiles, err := parseIncludeEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			path = absPath(dir, path)
+			for _, parent := range parents {
+				if parent == path {
+					return nil, errors.Errorf("include cycle detected: %s", strings.Join(append(parents, path), " -> "))
+				}
+			}
+			included, err := readComposeFile(path)
+			if err != nil {
+				return nil, err
+			}
+			includedDir := filepath.Dir(path)
+			if projectDir != "" {
+				includedDir = absPath(dir, projectDir)
+			}
+			if environment != nil {
+				env, err := includeEnvironment(dir, includedDir, envFiles, environment)
+				if err != nil {
+					return nil, err
+				}
+				if err := interpolateConfig(included, env); err != nil {
+					return nil, errors.Wrapf(err, "failed to interpolate %s", path)
+				}
+			}
+			if _, ok := included["include"]; ok {
+				nested, err := loadIncludes(included, includedDir, environment, append(parents, path))
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, nested...)
+			}
+			resolveRelativePaths(included, includedDir)
+			result = append(result, included)
+		}
+	}
+	return result, nil
+}
+
+// parseIncludeEntry supports both the short `- path` and long `- path: …` syntaxes
+func parseIncludeEntry(entry interface{}) ([]string, string, []string, error) {
+	switch e := entry.(type) {
+	case string:
+		return []string{e}, "", nil, nil
+	case map[interface{}]interface{}:
+		var paths, envFiles []string
+		var projectDir string
+		for k, v := range e {
+			switch k {
+			case "path":
+				switch p := v.(type) {
+				case string:
+					paths = append(paths, p)
+				case []interface{}:
+					for _, s := range p {
+						paths = append(paths, fmt.Sprint(s))
+					}
+				default:
+					return nil, "", nil, errors.Errorf("invalid include path %v", v)
+				}
+			case "project_directory":
+				projectDir = fmt.Sprint(v)
+			case "env_file":
+				switch f := v.(type) {
+				case string:
+					envFiles = append(envFiles, f)
+				case []interface{}:
+					for _, s := range f {
+						envFiles = append(envFiles, fmt.Sprint(s))
+					}
+				default:
+					return nil, "", nil, errors.Errorf("invalid include env_file %v", v)
+				}
+			default:
+				return nil, "", nil, errors.Errorf("unsupported include attribute %q", k)
+			}
+		}
+		if len(paths) == 0 {
+			return nil, "", nil, errors.New("include entry requires a path")
+		}
+		return paths, projectDir, envFiles, nil
+	default:
+		return nil, "", nil, errors.Errorf("invalid include entry %v", entry)
+	}
+}
+
+// includeEnvironment reads the env files of an included file, envFiles are relative to
+// the including file and default to the `.env` file of the included project directory
+func includeEnvironment(dir string, includedDir string, envFiles []string, environment map[string]string) (map[string]string, error) {
+	files := []string{filepath.Join(includedDir, ".env")}
+	if len(envFiles) > 0 {
+		files = nil
+		for _, f := range envFiles {
+			files = append(files, absPath(dir, f))
+		}
+	}
+	env := map[string]string{}
+	for _, f := range files {
+		values, err := godotenv.Read(f)
+		if os.IsNotExist(err) && len(envFiles) == 0 {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read env file %s", f)
+		}
+		for k, v := range values {
+			env[k] = v
+		}
+	}
+	for k, v := range environment {
+		env[k] = v
+	}
+	return env, nil
+}
+
+// interpolateConfig substitutes the variables of an included file in place. The result is
+// escaped, so that it isn't interpolated again with the environment of the including project.
+func interpolateConfig(config map[string]interface{}, env map[string]string) error {
+	lookup := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+	var interpolate func(value interface{}) (interface{}, error)
+	interpolate = func(value interface{}) (interface{}, error) {
+		switch v := value.(type) {
+		case string:
+			s, err := template.Substitute(v, lookup)
+			if err != nil {
+				return nil, err
+			}
+			return strings.ReplaceAll(s, "$", "$$"), nil
+		case map[interface{}]interface{}:
+			for k, e := range v {
+				i, err := interpolate(e)
+				if err != nil {
+					return nil, err
+				}
+				v[k] = i
+			}
+		case []interface{}:
+			for k, e := range v {
+				i, err := interpolate(e)
+				if err != nil {
+					return nil, err
+				}
+				v[k] = i
+			}
+		}
+		return value, nil
+	}
+	for k, v := range config {
+		i, err := interpolate(v)
+		if err != nil {
+			return err
+		}
+		config[k] = i
+	}
+	return nil
+}
+
+// resolveRelativePaths makes the host paths of an included file absolute so they don't get
+// resolved against the working directory of the including project
+func resolveRelativePaths(config map[string]interface{}, dir string) {
+	services, _ := config["services"].(map[interface{}]interface{})
+	for _, s := range services {
+		service, ok := s.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		switch build := service["build"].(type) {
+		case string:
+			service["build"] = absContext(dir, build)
+		case map[interface{}]interface{}:
+			if context, ok := build["context"].(string); ok {
+				build["context"] = absContext(dir, context)
+			}
+		}
+		switch envFile := service["env_file"].(type) {
+		case string:
+			service["env_file"] = absPath(dir, envFile)
+		case []interface{}:
+			for i, f := range envFile {
+				envFile[i] = absPath(dir, fmt.Sprint(f))
+			}
+		}
+		if extends, ok := service["extends"].(map[interface{}]interface{}); ok {
+			if file, ok := extends["file"].(string); ok {
+				extends["file"] = absPath(dir, file)
+			}
+		}
+		volumes, _ := service["volumes"].([]interface{})
+		for i, v := range volumes {
+			switch volume := v.(type) {
+			case string:
+				parts := strings.SplitN(volume, ":", 2)
+				if len(parts) == 2 && isHostPath(parts[0]) {
+					volumes[i] = absPath(dir, parts[0]) + ":" + parts[1]
+				}
+			case map[interface{}]interface{}:
+				if source, ok := volume["source"].(string); ok && volume["type"] == "bind" {
+					volume["source"] = absPath(dir, source)
+				}
+			}
+		}
+	}
+	for _, section := range []string{"secrets", "configs"} {
+		resources, _ := config[section].(map[interface{}]interface{})
+		for _, r := range resources {
+			if resource, ok := r.(map[interface{}]interface{}); ok {
+				if file, ok := resource["file"].(string); ok {
+					resource["file"] = absPath(dir, file)
+				}
+			}
+		}
+	}
+}
+
+// checkIncludeConflicts rejects included resources also declared by the project or by
+// another included file, as the compose specification doesn't merge them
+func checkIncludeConflicts(main []map[string]interface{}, included []map[string]interface{}) error {
+	for _, section := range includedResources {
+		declared := map[string]bool{}
+		for _, config := range main {
+			resources, _ := config[section].(map[interface{}]interface{})
+			for name := range resources {
+				declared[fmt.Sprint(name)] = true
+			}
+		}
+		for _, config := range included {
+			resources, _ := config[section].(map[interface{}]interface{})
+			for name := range resources {
+				key := fmt.Sprint(name)
+				if declared[key] {
+					return errors.Errorf("%s.%s conflicts with imported resource", section, key)
+				}
+				declared[key] = true
+			}
+		}
+	}
+	return nil
+}
+
+func absPath(dir string, path string) string {
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "~") {
+		return path
+	}
+	abs, err := filepath.Abs(filepath.Join(dir, path))
+	if err != nil {
+		return filepath.Join(dir, path)
+	}
+	return abs
+}
+
+// absContext leaves remote build contexts untouched
+func absContext(dir string, context string) string {
+	if isRemoteReference(context) || strings.Contains(context, "://") {
+		return context
+	}
+	return absPath(dir, context)
+}
+
+func isHostPath(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
@@ -0,0 +1,142 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestResolveIncludes(t *testing.T) {
+	dir := fs.NewDir(t, "include",
+		fs.WithFile("compose.yaml", `include:
+  - db/compose.yaml
+services:
+  web:
+    image: nginx
+    depends_on:
+      - db
+`),
+		fs.WithDir("db",
+			fs.WithFile("compose.yaml", `services:
+  db:
+    build: ./image
+    env_file: db.env
+    volumes:
+      - ./data:/var/lib/db
+      - dbdata:/backup
+volumes:
+  dbdata: {}
+`)))
+	defer dir.Remove()
+
+	paths, workingDir, cleanup, err := preprocessComposeFiles([]string{dir.Join("compose.yaml")}, "", nil)
+	defer cleanup()
+	assert.NilError(t, err)
+	assert.Equal(t, workingDir, dir.Path())
+	assert.Equal(t, len(paths), 2)
+
+	main, err := readComposeFile(paths[0])
+	assert.NilError(t, err)
+	_, ok := main["include"]
+	assert.Assert(t, !ok)
+
+	included, err := readComposeFile(paths[1])
+	assert.NilError(t, err)
+	db := included["services"].(map[interface{}]interface{})["db"].(map[interface{}]interface{})
+	assert.Equal(t, db["build"], dir.Join("db", "image"))
+	assert.Equal(t, db["env_file"], dir.Join("db", "db.env"))
+	volumes := db["volumes"].([]interface{})
+	assert.Equal(t, volumes[0], dir.Join("db", "data")+":/var/lib/db")
+	assert.Equal(t, volumes[1], "dbdata:/backup")
+}
+
+func TestResolveIncludesWithoutInclude(t *testing.T) {
+	dir := fs.NewDir(t, "include", fs.WithFile("compose.yaml", "services:\n  web:\n    image: nginx\n"))
+	defer dir.Remove()
+
+	paths, _, cleanup, err := preprocessComposeFiles([]string{dir.Join("compose.yaml")}, "", nil)
+	defer cleanup()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, paths, []string{dir.Join("compose.yaml")})
+}
+
+func TestResolveIncludesConflict(t *testing.T) {
+	dir := fs.NewDir(t, "include",
+		fs.WithFile("compose.yaml", "include:\n  - other.yaml\nservices:\n  web:\n    image: nginx\n"),
+		fs.WithFile("other.yaml", "services:\n  web:\n    image: httpd\n"))
+	defer dir.Remove()
+
+	_, _, cleanup, err := preprocessComposeFiles([]string{dir.Join("compose.yaml")}, "", nil)
+	defer cleanup()
+	assert.Error(t, err, "services.web conflicts with imported resource")
+}
+
+func TestResolveIncludesCycle(t *testing.T) {
+	dir := fs.NewDir(t, "include",
+		fs.WithFile("a.yaml", "include:\n  - b.yaml\n"),
+		fs.WithFile("b.yaml", "include:\n  - a.yaml\n"))
+	defer dir.Remove()
+
+	_, _, cleanup, err := preprocessComposeFiles([]string{dir.Join("a.yaml")}, "", nil)
+	defer cleanup()
+	assert.ErrorContains(t, err, "include cycle detected")
+}
+
+func TestResolveIncludesInterpolation(t *testing.T) {
+	dir := fs.NewDir(t, "include",
+		fs.WithFile("compose.yaml", `include:
+  - db/compose.yaml
+  - path: cache/compose.yaml
+    env_file: cache.env
+services:
+  web:
+    image: nginx
+`),
+		fs.WithFile("cache.env", "CACHE_TAG=6\n"),
+		fs.WithDir("db",
+			fs.WithFile(".env", "DB_TAG=13\nDB_USER=admin\n"),
+			fs.WithFile("compose.yaml", `services:
+  db:
+    image: postgres:${DB_TAG}
+    command: echo $$HOME
+    environment:
+      USER: ${DB_USER}
+`)),
+		fs.WithDir("cache",
+			fs.WithFile("compose.yaml", "services:\n  cache:\n    image: redis:${CACHE_TAG}\n")))
+	defer dir.Remove()
+
+	opts := composeOptions{
+		ConfigPaths: []string{dir.Join("compose.yaml")},
+		Environment: []string{"DB_USER=root"},
+	}
+	project, err := opts.toProject()
+	assert.NilError(t, err)
+
+	db, err := project.GetService("db")
+	assert.NilError(t, err)
+	assert.Equal(t, db.Image, "postgres:13")
+	assert.DeepEqual(t, []string(db.Command), []string{"echo", "$HOME"})
+	assert.Equal(t, *db.Environment["USER"], "root")
+
+	cache, err := project.GetService("cache")
+	assert.NilError(t, err)
+	assert.Equal(t, cache.Image, "redis:6")
+}
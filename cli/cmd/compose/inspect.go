@@ -0,0 +1,88 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/formatter"
+)
+
+func inspectCommand() *cobra.Command {
+	opts := composeOptions{}
+	inspectCmd := &cobra.Command{
+		Use:   "inspect SERVICE",
+		Short: "Display the resolved configuration and runtime state of a service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInspect(cmd.Context(), opts, args[0])
+		},
+	}
+	inspectCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	inspectCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	inspectCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+
+	return inspectCmd
+}
+
+type serviceInspectView struct {
+	Name       string
+	Config     types.ServiceConfig
+	Containers []compose.ContainerInspect
+}
+
+func runInspect(ctx context.Context, opts composeOptions, service string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+
+	view := serviceInspectView{Name: service}
+	found := false
+	for _, s := range project.Services {
+		if s.Name == service {
+			view.Config = s
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no such service: %q", service)
+	}
+
+	view.Containers, err = c.ComposeService().Inspect(ctx, project.Name, service)
+	if err != nil {
+		return err
+	}
+
+	j, err := formatter.ToStandardJSON(view)
+	if err != nil {
+		return err
+	}
+	fmt.Print(j)
+	return nil
+}
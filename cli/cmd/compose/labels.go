@@ -0,0 +1,76 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+)
+
+// reservedLabelPrefix is used by the labels tracking the resources of a project, they can't be overridden
+const reservedLabelPrefix = "com.docker.compose."
+
+// applyLabels parses the `key=value` labels set by the --label flags and adds them to the services,
+// networks and volumes of the project, so they are set on every resource created for it
+func applyLabels(project *types.Project, labels []string) error {
+	parsed := types.Labels{}
+	for _, label := range labels {
+		parts := strings.SplitN(label, "=", 2)
+		if parts[0] == "" {
+			return fmt.Errorf("invalid label %q, expected key=value", label)
+		}
+		if strings.HasPrefix(parts[0], reservedLabelPrefix) {
+			return fmt.Errorf("label %q is reserved by compose", parts[0])
+		}
+		value := ""
+		if len(parts) == 2 {
+			value = parts[1]
+		}
+		parsed[parts[0]] = value
+	}
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	for i, service := range project.Services {
+		for k, v := range parsed {
+			service.Labels = service.Labels.Add(k, v)
+		}
+		project.Services[i] = service
+	}
+	for name, network := range project.Networks {
+		if network.External.External {
+			continue
+		}
+		for k, v := range parsed {
+			network.Labels = network.Labels.Add(k, v)
+		}
+		project.Networks[name] = network
+	}
+	for name, volume := range project.Volumes {
+		if volume.External.External {
+			continue
+		}
+		for k, v := range parsed {
+			volume.Labels = volume.Labels.Add(k, v)
+		}
+		project.Volumes[name] = volume
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestApplyLabels(t *testing.T) {
+	project := &types.Project{
+		Services: []types.ServiceConfig{
+			{Name: "web", Labels: types.Labels{"tier": "front"}},
+			{Name: "db"},
+		},
+		Networks: types.Networks{
+			"default":  types.NetworkConfig{},
+			"external": types.NetworkConfig{External: types.External{External: true}},
+		},
+		Volumes: types.Volumes{
+			"data": types.VolumeConfig{},
+		},
+	}
+
+	assert.NilError(t, applyLabels(project, []string{"ci.job=1234", "ci.trigger"}))
+	assert.DeepEqual(t, project.Services[0].Labels, types.Labels{"tier": "front", "ci.job": "1234", "ci.trigger": ""})
+	assert.DeepEqual(t, project.Services[1].Labels, types.Labels{"ci.job": "1234", "ci.trigger": ""})
+	assert.DeepEqual(t, project.Networks["default"].Labels, types.Labels{"ci.job": "1234", "ci.trigger": ""})
+	assert.Assert(t, project.Networks["external"].Labels == nil)
+	assert.DeepEqual(t, project.Volumes["data"].Labels, types.Labels{"ci.job": "1234", "ci.trigger": ""})
+}
+
+func TestApplyLabelsErrors(t *testing.T) {
+	project := &types.Project{}
+	assert.ErrorContains(t, applyLabels(project, []string{"=value"}), `invalid label "=value"`)
+	assert.ErrorContains(t, applyLabels(project, []string{"com.docker.compose.project=other"}), "reserved by compose")
+}
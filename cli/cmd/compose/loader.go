@@ -0,0 +1,164 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/sanathkr/go-yaml"
+)
+
+// preprocessComposeFiles rewrites the compose files using features compose-go doesn't
+// support (`include` sections, `!reset` and `!override` merge tags, `x-templates`, `x-`
+// extensions declared by override files, optional dependencies, secrets sourced from the
+// environment, the top-level `name`) into a set of files it can load. Files which don't use them are loaded as is. Included
+// files are interpolated with environment, unless it is nil. The returned func removes the
+// temporary files.
+func preprocessComposeFiles(configPaths []string, workingDir string, environment map[string]string) ([]string, string, func(), error) {
+	var dirs []string
+	cleanup := func() {
+		for _, d := range dirs {
+			_ = os.RemoveAll(d)
+		}
+	}
+	paths := configPaths
+	if len(paths) == 0 {
+		file, ok := findDefaultComposeFile(workingDir)
+		if !ok {
+			return configPaths, workingDir, cleanup, nil
+		}
+		paths = []string{file}
+	}
+
+	var (
+		main     []map[string]interface{}
+		included []map[string]interface{}
+		changed  bool
+	)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, "", cleanup, err
+		}
+		config, err := parseComposeFile(path, data)
+		if err != nil {
+			return nil, "", cleanup, err
+		}
+		tags, err := parseMergeTags(data)
+		if err != nil {
+			return nil, "", cleanup, errors.Wrapf(err, "failed to parse %s", path)
+		}
+		if len(tags) > 0 {
+			changed = true
+			applyMergeTags(main, config, tags)
+		}
+		if _, ok := config["include"]; ok {
+			changed = true
+			dir := workingDir
+			if dir == "" {
+				dir = filepath.Dir(path)
+			}
+			abs, err := filepath.Abs(path)
+			if err != nil {
+				return nil, "", cleanup, err
+			}
+			files, err := loadIncludes(config, dir, environment, []string{abs})
+			if err != nil {
+				return nil, "", cleanup, err
+			}
+			included = append(included, files...)
+		}
+		main = append(main, config)
+	}
+	if len(included) > 0 {
+		if err := checkIncludeConflicts(main, included); err != nil {
+			return nil, "", cleanup, err
+		}
+	}
+	all := append(append([]map[string]interface{}{}, main...), included...)
+	templated, err := expandTemplates(all)
+	if err != nil {
+		return nil, "", cleanup, err
+	}
+	hoisted := hoistExtensions(main, included)
+	optional := resolveOptionalDependencies(all)
+	secretsEnv := resolveSecretsEnvironment(all)
+	configsContent := resolveConfigsContent(all)
+	ipv6 := resolveNetworksIPv6(all)
+	hostIPs := resolvePortHostIPs(all)
+	pullPolicy := resolvePullPolicy(all)
+	develop := resolveDevelop(all)
+	name := resolveProjectName(all)
+	if templated || hoisted || optional || secretsEnv || configsContent || ipv6 || hostIPs || pullPolicy || develop || name {
+		changed = true
+	}
+	if !changed {
+		return configPaths, workingDir, cleanup, nil
+	}
+
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		return nil, "", cleanup, err
+	}
+	dirs = append(dirs, dir)
+	if workingDir == "" {
+		workingDir = filepath.Dir(paths[0])
+	}
+	var files []string
+	for i, config := range all {
+		data, err := yaml.Marshal(config)
+		if err != nil {
+			return nil, "", cleanup, err
+		}
+		file := filepath.Join(dir, fmt.Sprintf("compose-%d.yaml", i))
+		if err := ioutil.WriteFile(file, data, 0644); err != nil {
+			return nil, "", cleanup, err
+		}
+		files = append(files, file)
+	}
+	return files, workingDir, cleanup, nil
+}
+
+func readComposeFile(path string) (map[string]interface{}, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseComposeFile(path, data)
+}
+
+func parseComposeFile(path string, data []byte) (map[string]interface{}, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return config, nil
+}
+
+func findDefaultComposeFile(dir string) (string, bool) {
+	for _, f := range defaultComposeFiles {
+		file := filepath.Join(dir, f)
+		if _, err := os.Stat(file); err == nil {
+			return file, true
+		}
+	}
+	return "", false
+}
@@ -18,37 +18,99 @@ package compose
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
 )
 
+type logsOptions struct {
+	composeOptions
+	projects projectsOptions
+	filter   string
+	tail     string
+	since    string
+	until    string
+}
+
 func logsCommand() *cobra.Command {
-	opts := composeOptions{}
+	opts := logsOptions{}
 	logsCmd := &cobra.Command{
-		Use: "logs",
+		Use: "logs [SERVICE...]",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runLogs(cmd.Context(), opts)
+			return runLogs(cmd.Context(), opts, args)
 		},
 	}
-	logsCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	addProjectsFlags(logsCmd.Flags(), &opts.projects)
 	logsCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
 	logsCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	logsCmd.Flags().StringVar(&opts.filter, "filter", "", "Only display log lines matching the regular expression")
+	logsCmd.Flags().StringVar(&opts.tail, "tail", "all", "Number of lines to show from the end of the logs for each container")
+	logsCmd.Flags().StringVar(&opts.since, "since", "", "Show logs since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	logsCmd.Flags().StringVar(&opts.until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
 
 	return logsCmd
 }
 
-func runLogs(ctx context.Context, opts composeOptions) error {
+func runLogs(ctx context.Context, opts logsOptions, services []string) error {
+	if err := checkLogsBounds(opts.since, opts.until, time.Now()); err != nil {
+		return err
+	}
+
 	c, err := client.New(ctx)
 	if err != nil {
 		return err
 	}
 
-	projectName, err := opts.toProjectName()
+	projectNames, err := opts.projects.projectNames(ctx, c.ComposeService(), opts.composeOptions)
 	if err != nil {
 		return err
 	}
-	return c.ComposeService().Logs(ctx, projectName, os.Stdout)
+	logOpts := compose.LogOptions{
+		Services: services,
+		Filter:   opts.filter,
+		Tail:     opts.tail,
+		Since:    opts.since,
+		Until:    opts.until,
+	}
+	if len(projectNames) == 1 {
+		return c.ComposeService().Logs(ctx, projectNames[0], os.Stdout, logOpts)
+	}
+
+	// the lines of the projects are prefixed with the project name
+	var lock sync.Mutex
+	prefixes := projectPrefixes(projectNames)
+	return forEachProject(ctx, projectNames, func(ctx context.Context, projectName string) error {
+		w := &prefixedWriter{prefix: prefixes[projectName], out: os.Stdout, lock: &lock}
+		err := c.ComposeService().Logs(ctx, projectName, w, logOpts)
+		if flushErr := w.Flush(); err == nil {
+			err = flushErr
+		}
+		return err
+	})
+}
+
+// checkLogsBounds validates the --since and --until values before the backend is called
+func checkLogsBounds(since, until string, now time.Time) error {
+	var sinceTime, untilTime time.Time
+	var err error
+	if since != "" {
+		if sinceTime, err = compose.ParseLogTime(since, now); err != nil {
+			return err
+		}
+	}
+	if until != "" {
+		if untilTime, err = compose.ParseLogTime(until, now); err != nil {
+			return err
+		}
+	}
+	if since != "" && until != "" && !untilTime.After(sinceTime) {
+		return fmt.Errorf("--until %q must be after --since %q", until, since)
+	}
+	return nil
 }
@@ -0,0 +1,35 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckLogsBounds(t *testing.T) {
+	now := time.Date(2020, 11, 20, 12, 0, 0, 0, time.Local)
+
+	assert.NilError(t, checkLogsBounds("", "", now))
+	assert.NilError(t, checkLogsBounds("2h", "10m", now))
+	assert.NilError(t, checkLogsBounds("2020-11-20T10:00:00", "30m", now))
+
+	assert.ErrorContains(t, checkLogsBounds("10m", "2h", now), `--until "2h" must be after --since "10m"`)
+	assert.ErrorContains(t, checkLogsBounds("", "tomorrow", now), `invalid timestamp "tomorrow"`)
+}
@@ -0,0 +1,128 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	// resetTag removes the attribute as declared by the previous compose files
+	resetTag = "!reset"
+	// overrideTag replaces the attribute as declared by the previous compose files rather than merging it
+	overrideTag = "!override"
+)
+
+// mergeTag is an attribute of an override file tagged with !reset or !override
+type mergeTag struct {
+	path  []string
+	tag   string
+	value interface{}
+}
+
+// parseMergeTags lists the attributes tagged with !reset or !override
+func parseMergeTags(data []byte) ([]mergeTag, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	var tags []mergeTag
+	var walk func(node *yamlv3.Node, path []string) error
+	walk = func(node *yamlv3.Node, path []string) error {
+		if node.Kind != yamlv3.MappingNode {
+			return nil
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			p := append(append([]string{}, path...), key.Value)
+			switch value.Tag {
+			case resetTag, overrideTag:
+				tag := mergeTag{path: p, tag: value.Tag}
+				value.Tag = ""
+				if err := value.Decode(&tag.value); err != nil {
+					return err
+				}
+				tags = append(tags, tag)
+			default:
+				if err := walk(value, p); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return tags, walk(doc.Content[0], nil)
+}
+
+// applyMergeTags removes the tagged attributes from the previous compose files, so the
+// compose-go merge only considers the value set by config, if any
+func applyMergeTags(previous []map[string]interface{}, config map[string]interface{}, tags []mergeTag) {
+	for _, t := range tags {
+		for _, p := range previous {
+			deleteAttribute(p, t.path)
+		}
+		if t.tag == resetTag {
+			deleteAttribute(config, t.path)
+		} else {
+			setAttribute(config, t.path, t.value)
+		}
+	}
+}
+
+func deleteAttribute(config map[string]interface{}, path []string) {
+	if len(path) == 1 {
+		delete(config, path[0])
+		return
+	}
+	switch parent := lookupAttribute(config[path[0]], path[1:len(path)-1]).(type) {
+	case map[interface{}]interface{}:
+		delete(parent, path[len(path)-1])
+	case map[string]interface{}:
+		delete(parent, path[len(path)-1])
+	}
+}
+
+// setAttribute replaces the value decoded by yaml.v2, which doesn't resolve the type of tagged scalars
+func setAttribute(config map[string]interface{}, path []string, value interface{}) {
+	if len(path) == 1 {
+		config[path[0]] = value
+		return
+	}
+	switch parent := lookupAttribute(config[path[0]], path[1:len(path)-1]).(type) {
+	case map[interface{}]interface{}:
+		parent[path[len(path)-1]] = value
+	case map[string]interface{}:
+		parent[path[len(path)-1]] = value
+	}
+}
+
+func lookupAttribute(node interface{}, path []string) interface{} {
+	for _, key := range path {
+		switch m := node.(type) {
+		case map[interface{}]interface{}:
+			node = m[key]
+		case map[string]interface{}:
+			node = m[key]
+		default:
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestMergeTags(t *testing.T) {
+	dir := fs.NewDir(t, "merge",
+		fs.WithFile("compose.yaml", `services:
+  web:
+    image: nginx
+    ports:
+      - 8080:80
+    environment:
+      DEBUG: "1"
+    privileged: false
+`),
+		fs.WithFile("override.yaml", `services:
+  web:
+    ports: !reset []
+    environment: !override
+      LOG_LEVEL: info
+    privileged: !override true
+`))
+	defer dir.Remove()
+
+	paths, workingDir, cleanup, err := preprocessComposeFiles([]string{dir.Join("compose.yaml"), dir.Join("override.yaml")}, "", nil)
+	defer cleanup()
+	assert.NilError(t, err)
+	assert.Equal(t, workingDir, dir.Path())
+	assert.Equal(t, len(paths), 2)
+
+	base, err := readComposeFile(paths[0])
+	assert.NilError(t, err)
+	web := base["services"].(map[interface{}]interface{})["web"].(map[interface{}]interface{})
+	assert.DeepEqual(t, web, map[interface{}]interface{}{"image": "nginx"})
+
+	override, err := readComposeFile(paths[1])
+	assert.NilError(t, err)
+	web = override["services"].(map[interface{}]interface{})["web"].(map[interface{}]interface{})
+	_, ok := web["ports"]
+	assert.Assert(t, !ok)
+	assert.DeepEqual(t, web["environment"], map[interface{}]interface{}{"LOG_LEVEL": "info"})
+	assert.Equal(t, web["privileged"], true)
+}
+
+func TestParseMergeTags(t *testing.T) {
+	tags, err := parseMergeTags([]byte("services:\n  web:\n    image: nginx\n    ports: !reset []\n"))
+	assert.NilError(t, err)
+	assert.Equal(t, len(tags), 1)
+	assert.DeepEqual(t, tags[0].path, []string{"services", "web", "ports"})
+	assert.Equal(t, tags[0].tag, resetTag)
+}
@@ -0,0 +1,77 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"strings"
+
+	"github.com/compose-spec/compose-go/cli"
+	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// resolveProjectName moves the top-level `name` of the compose files, which compose-go
+// doesn't support, to the x-project-name extension. The last file declaring it wins.
+func resolveProjectName(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		if name, ok := config["name"]; ok {
+			config[compose.ProjectNameExtension] = name
+			delete(config, "name")
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyProjectName sets the name of the loaded project, by order of precedence, from the
+// --project-name flag, the COMPOSE_PROJECT_NAME variable, the `name` of the compose files or
+// the working directory, then normalizes it
+func (o *composeOptions) applyProjectName(project *types.Project) error {
+	name := project.Name
+	if o.Name == "" && !o.hasProjectNameEnv() {
+		if fromFile, ok := project.Extensions[compose.ProjectNameExtension].(string); ok && fromFile != "" {
+			name = fromFile
+		}
+	}
+	delete(project.Extensions, compose.ProjectNameExtension)
+	normalized, err := normalizeProjectName(name)
+	if err != nil {
+		return err
+	}
+	project.Name = normalized
+	return nil
+}
+
+func normalizeProjectName(name string) (string, error) {
+	if err := compose.CheckProjectName(name, true); err != nil {
+		return "", err
+	}
+	return compose.NormalizeProjectName(name), nil
+}
+
+func (o *composeOptions) hasProjectNameEnv() bool {
+	for _, e := range o.Environment {
+		if strings.HasPrefix(e, cli.ComposeProjectName+"=") {
+			return true
+		}
+	}
+	_, ok := os.LookupEnv(cli.ComposeProjectName)
+	return ok
+}
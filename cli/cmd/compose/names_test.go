@@ -0,0 +1,83 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveProjectName(t *testing.T) {
+	configs := []map[string]interface{}{
+		{"name": "base", "services": map[interface{}]interface{}{}},
+		{"services": map[interface{}]interface{}{}},
+		{"name": "override"},
+	}
+
+	assert.Assert(t, resolveProjectName(configs))
+	assert.DeepEqual(t, configs[0], map[string]interface{}{"x-project-name": "base", "services": map[interface{}]interface{}{}})
+	assert.DeepEqual(t, configs[2], map[string]interface{}{"x-project-name": "override"})
+
+	assert.Assert(t, !resolveProjectName(configs))
+}
+
+func TestApplyProjectName(t *testing.T) {
+	fromFile := func() *types.Project {
+		return &types.Project{
+			Name:       "directory",
+			Extensions: map[string]interface{}{"x-project-name": "My.App"},
+		}
+	}
+
+	project := fromFile()
+	assert.NilError(t, (&composeOptions{}).applyProjectName(project))
+	assert.Equal(t, project.Name, "myapp")
+	_, ok := project.Extensions["x-project-name"]
+	assert.Assert(t, !ok)
+
+	project = fromFile()
+	project.Name = "Flag_Name"
+	assert.NilError(t, (&composeOptions{Name: "Flag_Name"}).applyProjectName(project))
+	assert.Equal(t, project.Name, "flag_name")
+
+	project = fromFile()
+	project.Name = "from-env"
+	assert.NilError(t, (&composeOptions{Environment: []string{"COMPOSE_PROJECT_NAME=from-env"}}).applyProjectName(project))
+	assert.Equal(t, project.Name, "from-env")
+
+	project = &types.Project{Name: "directory", Extensions: map[string]interface{}{"x-project-name": "..."}}
+	assert.ErrorContains(t, (&composeOptions{}).applyProjectName(project), `invalid project name "..."`)
+}
+
+func TestNormalizeProjectName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"myapp":      "myapp",
+		"My App":     "myapp",
+		"my_app-2":   "my_app-2",
+		"-_my.app":   "myapp",
+		"2019.Stack": "2019stack",
+	} {
+		normalized, err := normalizeProjectName(name)
+		assert.NilError(t, err)
+		assert.Equal(t, normalized, expected, name)
+	}
+
+	_, err := normalizeProjectName("__")
+	assert.ErrorContains(t, err, "at least one letter or digit")
+}
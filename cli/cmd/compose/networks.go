@@ -0,0 +1,100 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"fmt"
+
+	"github.com/docker/go-connections/nat"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// resolveNetworksIPv6 moves the `enable_ipv6` attribute of the networks, which compose-go
+// doesn't support, to the x-enable-ipv6 network extension
+func resolveNetworksIPv6(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		networks, _ := config["networks"].(map[interface{}]interface{})
+		for _, n := range networks {
+			network, ok := n.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if enabled, ok := network["enable_ipv6"]; ok {
+				network[compose.EnableIPv6Extension] = enabled
+				delete(network, "enable_ipv6")
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// resolvePortHostIPs records the host IP the service ports are published on in the
+// x-port-host-ips service extension, as compose-go drops it when loading ports. The
+// `host_ip` attribute of the long port syntax is removed.
+func resolvePortHostIPs(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for _, s := range services {
+			service, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			ports, ok := service["ports"].([]interface{})
+			if !ok {
+				continue
+			}
+			hostIPs := map[interface{}]interface{}{}
+			for _, p := range ports {
+				switch port := p.(type) {
+				case string:
+					mappings, err := nat.ParsePortSpec(port)
+					if err != nil {
+						continue
+					}
+					for _, m := range mappings {
+						if m.Binding.HostIP != "" && m.Binding.HostPort != "" {
+							hostIPs[fmt.Sprintf("%s:%s", m.Binding.HostPort, m.Port)] = m.Binding.HostIP
+						}
+					}
+				case map[interface{}]interface{}:
+					hostIP, ok := port["host_ip"]
+					if !ok {
+						continue
+					}
+					delete(port, "host_ip")
+					changed = true
+					protocol, ok := port["protocol"]
+					if !ok {
+						protocol = "tcp"
+					}
+					if published, ok := port["published"]; ok {
+						hostIPs[fmt.Sprintf("%v:%v/%v", published, port["target"], protocol)] = hostIP
+					}
+				}
+			}
+			if len(hostIPs) > 0 {
+				service[compose.PortHostIPsExtension] = hostIPs
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,67 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveNetworksIPv6(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"networks": map[interface{}]interface{}{
+			"front": map[interface{}]interface{}{"enable_ipv6": true},
+			"back":  map[interface{}]interface{}{"internal": true},
+		},
+	}}
+
+	assert.Assert(t, resolveNetworksIPv6(configs))
+	networks := configs[0]["networks"].(map[interface{}]interface{})
+	assert.DeepEqual(t, networks["front"], map[interface{}]interface{}{"x-enable-ipv6": true})
+	assert.DeepEqual(t, networks["back"], map[interface{}]interface{}{"internal": true})
+}
+
+func TestResolvePortHostIPs(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"services": map[interface{}]interface{}{
+			"web": map[interface{}]interface{}{
+				"ports": []interface{}{
+					"127.0.0.1:8080:80",
+					"[::1]:8443:443",
+					"9000:9000",
+					map[interface{}]interface{}{"target": 53, "published": 5353, "protocol": "udp", "host_ip": "::"},
+				},
+			},
+			"db": map[interface{}]interface{}{
+				"ports": []interface{}{"5432"},
+			},
+		},
+	}}
+
+	assert.Assert(t, resolvePortHostIPs(configs))
+	services := configs[0]["services"].(map[interface{}]interface{})
+	web := services["web"].(map[interface{}]interface{})
+	assert.DeepEqual(t, web["x-port-host-ips"], map[interface{}]interface{}{
+		"8080:80/tcp":  "127.0.0.1",
+		"8443:443/tcp": "::1",
+		"5353:53/udp":  "::",
+	})
+	assert.DeepEqual(t, web["ports"].([]interface{})[3], map[interface{}]interface{}{"target": 53, "published": 5353, "protocol": "udp"})
+	_, ok := services["db"].(map[interface{}]interface{})["x-port-host-ips"]
+	assert.Assert(t, !ok)
+}
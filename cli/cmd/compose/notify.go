@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/webhook"
+)
+
+type notifyOptions struct {
+	composeOptions
+	Interval time.Duration
+}
+
+func notifyCommand() *cobra.Command {
+	opts := notifyOptions{}
+	notifyCmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Watch the services and send their lifecycle events to the webhooks declared by the project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNotify(cmd.Context(), opts)
+		},
+	}
+	notifyCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	notifyCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	notifyCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	notifyCmd.Flags().DurationVar(&opts.Interval, "interval", 2*time.Second, "Interval between services status checks")
+
+	return notifyCmd
+}
+
+func runNotify(ctx context.Context, opts notifyOptions) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	hooks, err := webhook.Load(project)
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return fmt.Errorf("project %s does not declare any webhook, use %s to declare some", project.Name, webhook.Extension)
+	}
+	return webhook.Watch(ctx, c.ComposeService(), project.Name, hooks, webhook.WatchOptions{
+		Interval: opts.Interval,
+	})
+}
@@ -0,0 +1,148 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// projectsOptions select the projects of the commands which can run for several projects at once
+type projectsOptions struct {
+	Names []string
+	All   bool
+}
+
+func addProjectsFlags(f *pflag.FlagSet, opts *projectsOptions) {
+	f.StringSliceVarP(&opts.Names, "project-name", "p", []string{}, "Project name, repeated or comma separated to select several projects")
+	f.BoolVar(&opts.All, "all-projects", false, "Select all the projects of the current context")
+}
+
+// projectNames returns the selected projects, the project of the compose files when none is selected
+func (o projectsOptions) projectNames(ctx context.Context, service compose.Service, opts composeOptions) ([]string, error) {
+	if o.All {
+		if len(o.Names) > 0 {
+			return nil, errors.New("--all-projects and --project-name can't be combined")
+		}
+		stacks, err := service.List(ctx, "")
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for _, s := range stacks {
+			names = append(names, s.Name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+	if len(o.Names) == 0 {
+		name, err := opts.toProjectName()
+		if err != nil {
+			return nil, err
+		}
+		return []string{name}, nil
+	}
+	names := []string{}
+	seen := map[string]bool{}
+	for _, n := range o.Names {
+		name, err := normalizeProjectName(n)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// forEachProject runs fn for the projects in parallel, returning the first error
+func forEachProject(ctx context.Context, names []string, fn func(ctx context.Context, projectName string) error) error {
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, name := range names {
+		name := name
+		eg.Go(func() error {
+			return fn(ctx, name)
+		})
+	}
+	return eg.Wait()
+}
+
+// prefixedWriter writes the lines written to it with a prefix, the writers sharing a lock don't interleave their lines
+type prefixedWriter struct {
+	prefix string
+	out    io.Writer
+	lock   *sync.Mutex
+	buf    []byte
+}
+
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		if err := w.writeLine(w.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[i+1:]
+	}
+}
+
+// Flush writes the last line when it isn't terminated by a line feed
+func (w *prefixedWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := append(w.buf, '\n')
+	w.buf = nil
+	return w.writeLine(line)
+}
+
+func (w *prefixedWriter) writeLine(line []byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+	return err
+}
+
+// projectPrefixes returns the prefixes of the lines of the projects, padded to the same width
+func projectPrefixes(names []string) map[string]string {
+	width := 0
+	for _, name := range names {
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	prefixes := map[string]string{}
+	for _, name := range names {
+		prefixes[name] = fmt.Sprintf("%-*s | ", width, name)
+	}
+	return prefixes
+}
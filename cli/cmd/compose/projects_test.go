@@ -0,0 +1,74 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type listService struct {
+	compose.Service
+	stacks []compose.Stack
+}
+
+func (s listService) List(ctx context.Context, projectName string) ([]compose.Stack, error) {
+	return s.stacks, nil
+}
+
+func TestProjectNames(t *testing.T) {
+	service := listService{stacks: []compose.Stack{{Name: "web"}, {Name: "api"}}}
+
+	names, err := projectsOptions{Names: []string{"Shop", "api", "shop"}}.projectNames(context.TODO(), service, composeOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"shop", "api"})
+
+	names, err = projectsOptions{All: true}.projectNames(context.TODO(), service, composeOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"api", "web"})
+
+	_, err = projectsOptions{All: true, Names: []string{"api"}}.projectNames(context.TODO(), service, composeOptions{})
+	assert.Error(t, err, "--all-projects and --project-name can't be combined")
+}
+
+func TestPrefixedWriter(t *testing.T) {
+	var b bytes.Buffer
+	var lock sync.Mutex
+	prefixes := projectPrefixes([]string{"api", "frontend"})
+	api := &prefixedWriter{prefix: prefixes["api"], out: &b, lock: &lock}
+	frontend := &prefixedWriter{prefix: prefixes["frontend"], out: &b, lock: &lock}
+
+	fmt.Fprint(api, "db_1  | ready\ndb_1  | accepting")
+	fmt.Fprint(frontend, "web_1  | listening\n")
+	fmt.Fprint(api, " connections\n")
+	assert.NilError(t, frontend.Flush())
+	fmt.Fprint(api, "db_1  | shutting down")
+	assert.NilError(t, api.Flush())
+
+	assert.Equal(t, b.String(), `api      | db_1  | ready
+frontend | web_1  | listening
+api      | db_1  | accepting connections
+api      | db_1  | shutting down
+`)
+}
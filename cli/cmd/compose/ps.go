@@ -21,8 +21,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"strings"
+	"sync"
 
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/api/client"
@@ -30,67 +34,280 @@ import (
 	"github.com/docker/compose-cli/formatter"
 )
 
+type psOptions struct {
+	Projects projectsOptions
+	Expand   bool
+	Services bool
+	Filter   []string
+	Status   []string
+}
+
 func psCommand() *cobra.Command {
 	opts := composeOptions{}
+	psOpts := psOptions{}
 	psCmd := &cobra.Command{
 		Use: "ps",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPs(cmd.Context(), opts)
+			return runPs(cmd.Context(), opts, psOpts)
 		},
 	}
 	psCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
 	psCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
-	addComposeCommonFlags(psCmd.Flags(), &opts)
+	psCmd.Flags().BoolVar(&psOpts.Expand, "expand", false, "Display individual containers of scaled services")
+	psCmd.Flags().BoolVar(&psOpts.Services, "services", false, "Display services")
+	psCmd.Flags().StringArrayVar(&psOpts.Filter, "filter", []string{}, "Filter output based on conditions provided (status, service, label)")
+	psCmd.Flags().StringArrayVar(&psOpts.Status, "status", []string{}, "Filter services by status. Values: [running | exited | paused | restarting | created | dead | healthy | unhealthy]")
+	addProjectsFlags(psCmd.Flags(), &psOpts.Projects)
+	addOutputFlags(psCmd.Flags(), &opts)
 	return psCmd
 }
 
-func runPs(ctx context.Context, opts composeOptions) error {
+func runPs(ctx context.Context, opts composeOptions, psOpts psOptions) error {
+	psFilters, err := psOpts.filters()
+	if err != nil {
+		return err
+	}
+
 	c, err := client.New(ctx)
 	if err != nil {
 		return err
 	}
 
-	projectName, err := opts.toProjectName()
+	projectNames, err := psOpts.Projects.projectNames(ctx, c.ComposeService(), opts)
 	if err != nil {
 		return err
 	}
-	serviceList, err := c.ComposeService().Ps(ctx, projectName)
+	var lock sync.Mutex
+	servicesByProject := map[string][]compose.ServiceStatus{}
+	err = forEachProject(ctx, projectNames, func(ctx context.Context, projectName string) error {
+		serviceList, err := c.ComposeService().Ps(ctx, projectName)
+		if err != nil {
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		servicesByProject[projectName] = filterServiceStatus(serviceList, psFilters)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-	if opts.Quiet {
+	// the services of several projects are listed with their project name
+	multiProject := len(projectNames) > 1
+	serviceList := []compose.ServiceStatus{}
+	for _, projectName := range projectNames {
+		for _, s := range servicesByProject[projectName] {
+			if multiProject {
+				s.Name = projectName + "/" + s.Name
+			}
+			serviceList = append(serviceList, s)
+		}
+	}
+
+	if psOpts.Services {
 		for _, s := range serviceList {
-			fmt.Println(s.ID)
+			fmt.Println(s.Name)
+		}
+		return nil
+	}
+	if opts.Quiet {
+		// like docker-compose v1, scripts get container IDs to pipe into docker commands
+		for _, id := range containerIDs(serviceList) {
+			fmt.Println(id)
 		}
 		return nil
 	}
+	if psOpts.Expand {
+		return printExpanded(serviceList, opts)
+	}
 	view := viewFromServiceStatusList(serviceList)
 	return formatter.Print(view, opts.Format, os.Stdout,
 		func(w io.Writer) {
 			for _, service := range view {
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%d/%d\t%s\n", service.ID, service.Name, service.Replicas, service.Desired, strings.Join(service.Ports, ", "))
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", service.ID, service.Name, replicasSummary(service), strings.Join(service.Ports, ", "))
 			}
 		},
 		"ID", "NAME", "REPLICAS", "PORTS")
 }
 
+func printExpanded(serviceList []compose.ServiceStatus, opts composeOptions) error {
+	view := viewFromContainerSummaries(serviceList)
+	return formatter.Print(view, opts.Format, os.Stdout,
+		func(w io.Writer) {
+			for _, c := range view {
+				state := c.State
+				if c.Health != "" {
+					state = fmt.Sprintf("%s (%s)", state, c.Health)
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ID, c.Name, c.Service, state, strings.Join(c.Ports, ", "))
+			}
+		},
+		"ID", "NAME", "SERVICE", "STATE", "PORTS")
+}
+
+var psAcceptedFilters = map[string]bool{
+	"status":  true,
+	"service": true,
+	"label":   true,
+}
+
+// filters parses the --filter and --status flags, values for the same key are OR'ed, distinct keys are AND'ed
+func (o psOptions) filters() (filters.Args, error) {
+	args := filters.NewArgs()
+	for _, f := range o.Filter {
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return args, errors.Errorf("bad format of filter %q, expected name=value", f)
+		}
+		args.Add(strings.ToLower(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1]))
+	}
+	for _, status := range o.Status {
+		args.Add("status", status)
+	}
+	if err := args.Validate(psAcceptedFilters); err != nil {
+		return args, err
+	}
+	for _, pattern := range args.Get("service") {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return args, errors.Wrapf(err, "invalid service pattern %q", pattern)
+		}
+	}
+	return args, nil
+}
+
+// filterServiceStatus only keeps the services, and their containers, matching the filters
+func filterServiceStatus(serviceList []compose.ServiceStatus, args filters.Args) []compose.ServiceStatus {
+	if args.Len() == 0 {
+		return serviceList
+	}
+	res := []compose.ServiceStatus{}
+	for _, s := range serviceList {
+		if !matchServiceName(s.Name, args) {
+			continue
+		}
+		if len(s.Containers) == 0 {
+			// backend doesn't expose individual replicas, match the service summary
+			summary := compose.ContainerSummary{State: "exited"}
+			if s.Replicas > 0 {
+				summary.State = "running"
+			}
+			if s.Unhealthy > 0 {
+				summary.Health = "unhealthy"
+			}
+			if matchContainer(summary, args) {
+				res = append(res, s)
+			}
+			continue
+		}
+		containers := []compose.ContainerSummary{}
+		for _, c := range s.Containers {
+			if matchContainer(c, args) {
+				containers = append(containers, c)
+			}
+		}
+		if len(containers) == 0 {
+			continue
+		}
+		s.Containers = containers
+		res = append(res, s)
+	}
+	return res
+}
+
+func matchServiceName(name string, args filters.Args) bool {
+	patterns := args.Get("service")
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchContainer(c compose.ContainerSummary, args filters.Args) bool {
+	if args.Contains("status") && !args.ExactMatch("status", c.State) && (c.Health == "" || !args.ExactMatch("status", c.Health)) {
+		return false
+	}
+	return args.MatchKVList("label", c.Labels)
+}
+
+// replicasSummary renders the running replicas count, along with unhealthy replicas if any
+func replicasSummary(service serviceStatusView) string {
+	summary := fmt.Sprintf("%d/%d running", service.Replicas, service.Desired)
+	if service.Unhealthy > 0 {
+		summary = fmt.Sprintf("%s, %d unhealthy", summary, service.Unhealthy)
+	}
+	return summary
+}
+
 type serviceStatusView struct {
-	ID       string
-	Name     string
-	Replicas int
-	Desired  int
-	Ports    []string
+	ID        string
+	Name      string
+	Replicas  int
+	Desired   int
+	Unhealthy int
+	Ports     []string
 }
 
 func viewFromServiceStatusList(serviceStatusList []compose.ServiceStatus) []serviceStatusView {
 	retList := make([]serviceStatusView, len(serviceStatusList))
 	for i, s := range serviceStatusList {
 		retList[i] = serviceStatusView{
-			ID:       s.ID,
-			Name:     s.Name,
-			Replicas: s.Replicas,
-			Desired:  s.Desired,
-			Ports:    s.Ports,
+			ID:        s.ID,
+			Name:      s.Name,
+			Replicas:  s.Replicas,
+			Desired:   s.Desired,
+			Unhealthy: s.Unhealthy,
+			Ports:     s.Ports,
+		}
+	}
+	return retList
+}
+
+type containerSummaryView struct {
+	ID      string
+	Name    string
+	Service string
+	State   string
+	Health  string
+	Ports   []string
+}
+
+// containerIDs returns the IDs of the containers of the services, or the service ID when the backend doesn't expose its containers
+func containerIDs(serviceList []compose.ServiceStatus) []string {
+	ids := []string{}
+	for _, c := range viewFromContainerSummaries(serviceList) {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}
+
+func viewFromContainerSummaries(serviceStatusList []compose.ServiceStatus) []containerSummaryView {
+	retList := []containerSummaryView{}
+	for _, s := range serviceStatusList {
+		if len(s.Containers) == 0 {
+			// backend doesn't expose individual replicas, fallback to the service summary
+			retList = append(retList, containerSummaryView{
+				ID:      s.ID,
+				Name:    s.Name,
+				Service: s.Name,
+				State:   fmt.Sprintf("%d/%d", s.Replicas, s.Desired),
+				Ports:   s.Ports,
+			})
+			continue
+		}
+		for _, c := range s.Containers {
+			retList = append(retList, containerSummaryView{
+				ID:      c.ID,
+				Name:    c.Name,
+				Service: s.Name,
+				State:   c.State,
+				Health:  c.Health,
+				Ports:   c.Ports,
+			})
 		}
 	}
 	return retList
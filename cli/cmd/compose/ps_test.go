@@ -0,0 +1,114 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestPsFilters(t *testing.T) {
+	_, err := psOptions{Filter: []string{"name=foo"}}.filters()
+	assert.ErrorContains(t, err, "filter 'name'")
+
+	_, err = psOptions{Filter: []string{"service=["}}.filters()
+	assert.ErrorContains(t, err, `invalid service pattern "["`)
+
+	args, err := psOptions{Filter: []string{"service=web*", "label=tier"}, Status: []string{"running"}}.filters()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args.Get("status"), []string{"running"})
+	assert.DeepEqual(t, args.Get("service"), []string{"web*"})
+}
+
+func TestFilterServiceStatus(t *testing.T) {
+	services := []compose.ServiceStatus{
+		{
+			Name:     "web",
+			Replicas: 1,
+			Desired:  2,
+			Containers: []compose.ContainerSummary{
+				{ID: "1", State: "running", Health: "unhealthy", Labels: map[string]string{"tier": "front"}},
+				{ID: "2", State: "exited", Labels: map[string]string{"tier": "front"}},
+			},
+		},
+		{
+			Name:     "db",
+			Replicas: 1,
+			Desired:  1,
+			Containers: []compose.ContainerSummary{
+				{ID: "3", State: "running", Labels: map[string]string{"tier": "back"}},
+			},
+		},
+		{
+			Name:     "worker",
+			Replicas: 0,
+			Desired:  1,
+		},
+	}
+
+	filter := func(opts psOptions) []string {
+		args, err := opts.filters()
+		assert.NilError(t, err)
+		var ids []string
+		for _, s := range filterServiceStatus(services, args) {
+			if len(s.Containers) == 0 {
+				ids = append(ids, s.Name)
+			}
+			for _, c := range s.Containers {
+				ids = append(ids, c.ID)
+			}
+		}
+		return ids
+	}
+
+	assert.DeepEqual(t, filter(psOptions{}), []string{"1", "2", "3", "worker"})
+	assert.DeepEqual(t, filter(psOptions{Status: []string{"running"}}), []string{"1", "3"})
+	assert.DeepEqual(t, filter(psOptions{Status: []string{"exited"}}), []string{"2", "worker"})
+	assert.DeepEqual(t, filter(psOptions{Status: []string{"unhealthy"}}), []string{"1"})
+	assert.DeepEqual(t, filter(psOptions{Filter: []string{"status=exited", "status=unhealthy"}}), []string{"1", "2", "worker"})
+	assert.DeepEqual(t, filter(psOptions{Filter: []string{"service=w*"}}), []string{"1", "2", "worker"})
+	assert.DeepEqual(t, filter(psOptions{Filter: []string{"label=tier=back"}}), []string{"3"})
+	assert.DeepEqual(t, filter(psOptions{Filter: []string{"label=tier", "status=exited"}}), []string{"2"})
+}
+
+func TestReplicasSummary(t *testing.T) {
+	assert.Equal(t, replicasSummary(serviceStatusView{Replicas: 3, Desired: 3}), "3/3 running")
+	assert.Equal(t, replicasSummary(serviceStatusView{Replicas: 3, Desired: 3, Unhealthy: 1}), "3/3 running, 1 unhealthy")
+}
+
+func TestContainerIDs(t *testing.T) {
+	services := []compose.ServiceStatus{
+		{
+			ID:   "web",
+			Name: "web",
+			Containers: []compose.ContainerSummary{
+				{ID: "c1", State: "running"},
+				{ID: "c2", State: "running"},
+			},
+		},
+		{
+			ID:       "db",
+			Name:     "db",
+			Replicas: 1,
+			Desired:  1,
+		},
+	}
+	assert.DeepEqual(t, containerIDs(services), []string{"c1", "c2", "db"})
+}
@@ -0,0 +1,243 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/distribution/reference"
+	"github.com/joho/godotenv"
+	"github.com/pkg/errors"
+	"github.com/sanathkr/go-yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/oci"
+)
+
+func publishCommand() *cobra.Command {
+	opts := composeOptions{}
+	publishCmd := &cobra.Command{
+		Use:   "publish [OPTIONS] REPOSITORY[:TAG]",
+		Short: "Publish the resolved compose project to a registry as an OCI artifact",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPublish(cmd.Context(), opts, args[0])
+		},
+	}
+	publishCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	publishCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	publishCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	publishCmd.Flags().StringArrayVarP(&opts.Environment, "environment", "e", []string{}, "Environment variables")
+
+	return publishCmd
+}
+
+func runPublish(ctx context.Context, opts composeOptions, ref string) error {
+	project, err := opts.toPublishedProject()
+	if err != nil {
+		return err
+	}
+	envFile, err := ioutil.ReadFile(filepath.Join(project.WorkingDir, ".env"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	envFile, err = envFileKeys(envFile)
+	if err != nil {
+		return err
+	}
+	warnings, err := normalizeForPublish(project)
+	if err != nil {
+		return err
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", w)
+	}
+	composeFile, err := yaml.Marshal(project)
+	if err != nil {
+		return err
+	}
+
+	digest, err := oci.Push(ctx, ref, oci.Artifact{
+		ProjectName: project.Name,
+		ComposeFile: composeFile,
+		EnvFile:     envFile,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s@%s\n", ref, digest)
+	return nil
+}
+
+// toPublishedProject loads the compose project as it is written: neither the compose files nor
+// the files they include are interpolated, and the env_file of the services are referenced but
+// not read, so that no variable or secret value of this host ends up in the published artifact
+func (o *composeOptions) toPublishedProject() (*types.Project, error) {
+	configPaths, workingDir, cleanup, err := preprocessComposeFiles(o.ConfigPaths, o.WorkingDir, nil)
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+	if len(configPaths) == 0 {
+		file, ok := findDefaultComposeFile(workingDir)
+		if !ok {
+			return nil, errors.New("can't find a suitable configuration file in this directory or any parent")
+		}
+		configPaths = []string{file}
+	}
+	if workingDir == "" {
+		workingDir = filepath.Dir(configPaths[0])
+	}
+	workingDir, err = filepath.Abs(workingDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []types.ConfigFile
+	envFiles := map[string][]string{}
+	for _, path := range configPaths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		config, err := loader.ParseYAML(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", path)
+		}
+		// the loader reads the env files into the environment of the services, they are set back once loaded
+		services, _ := config["services"].(map[string]interface{})
+		for name, s := range services {
+			service, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			switch f := service["env_file"].(type) {
+			case string:
+				envFiles[name] = []string{f}
+			case []interface{}:
+				envFiles[name] = nil
+				for _, e := range f {
+					envFiles[name] = append(envFiles[name], fmt.Sprint(e))
+				}
+			}
+			delete(service, "env_file")
+		}
+		files = append(files, types.ConfigFile{Filename: path, Config: config})
+	}
+
+	project, err := loader.Load(types.ConfigDetails{
+		ConfigFiles: files,
+		WorkingDir:  workingDir,
+		Environment: map[string]string{},
+	}, func(options *loader.Options) {
+		options.SkipInterpolation = true
+		options.Name = filepath.Base(workingDir)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the compose files without interpolating them")
+	}
+	for i, service := range project.Services {
+		if files, ok := envFiles[service.Name]; ok {
+			project.Services[i].EnvFile = files
+		}
+	}
+	if err := o.applyProjectName(project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// normalizeForPublish strips the project from what only makes sense on this host: host paths
+// within the project directory are made relative to it and image references are fully qualified.
+// Absolute paths outside of the project directory are kept and reported as warnings.
+func normalizeForPublish(project *types.Project) ([]string, error) {
+	var warnings []string
+	relative := func(what string, p string) string {
+		if p == "" || !filepath.IsAbs(p) {
+			return p
+		}
+		rel, err := filepath.Rel(project.WorkingDir, p)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			warnings = append(warnings, fmt.Sprintf("%s %q is outside of the project directory and is published as is", what, p))
+			return p
+		}
+		return "./" + filepath.ToSlash(rel)
+	}
+
+	for i, service := range project.Services {
+		if service.Image != "" {
+			named, err := reference.ParseNormalizedNamed(service.Image)
+			if err != nil {
+				return nil, errors.Wrapf(err, "service %q: invalid image reference %q", service.Name, service.Image)
+			}
+			service.Image = reference.TagNameOnly(named).String()
+		}
+		if service.Build != nil {
+			service.Build.Context = relative(fmt.Sprintf("service %q build context", service.Name), service.Build.Context)
+		}
+		for j, volume := range service.Volumes {
+			if volume.Type == types.VolumeTypeBind {
+				service.Volumes[j].Source = relative(fmt.Sprintf("service %q bind mount", service.Name), volume.Source)
+			}
+		}
+		for j, file := range service.EnvFile {
+			service.EnvFile[j] = relative(fmt.Sprintf("service %q env_file", service.Name), file)
+		}
+		project.Services[i] = service
+	}
+	for name, secret := range project.Secrets {
+		secret.File = relative(fmt.Sprintf("secret %q file", name), secret.File)
+		project.Secrets[name] = secret
+	}
+	for name, config := range project.Configs {
+		config.File = relative(fmt.Sprintf("config %q file", name), config.File)
+		project.Configs[name] = config
+	}
+	project.WorkingDir = ""
+	return warnings, nil
+}
+
+// envFileKeys only keeps the variable names declared by a .env file, so that
+// the values it holds on this host are never uploaded
+func envFileKeys(content []byte) ([]byte, error) {
+	if len(content) == 0 {
+		return nil, nil
+	}
+	env, err := godotenv.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s=\n", k)
+	}
+	return b.Bytes(), nil
+}
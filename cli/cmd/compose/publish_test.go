@@ -0,0 +1,107 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sanathkr/go-yaml"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/env"
+	"gotest.tools/v3/fs"
+)
+
+func TestNormalizeForPublish(t *testing.T) {
+	project := &types.Project{
+		Name:       "app",
+		WorkingDir: "/home/user/app",
+		Services: types.Services{
+			{
+				Name:    "web",
+				Image:   "nginx",
+				Build:   &types.BuildConfig{Context: "/home/user/app/web"},
+				EnvFile: []string{"/home/user/app/web.env"},
+				Volumes: []types.ServiceVolumeConfig{
+					{Type: types.VolumeTypeBind, Source: "/home/user/app/html", Target: "/usr/share/nginx/html"},
+					{Type: types.VolumeTypeBind, Source: "/var/run/docker.sock", Target: "/var/run/docker.sock"},
+					{Type: types.VolumeTypeVolume, Source: "data", Target: "/data"},
+				},
+			},
+		},
+		Secrets: types.Secrets{
+			"token": types.SecretConfig{File: "/home/user/app/token.txt"},
+		},
+	}
+	warnings, err := normalizeForPublish(project)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, warnings, []string{`service "web" bind mount "/var/run/docker.sock" is outside of the project directory and is published as is`})
+
+	web := project.Services[0]
+	assert.Equal(t, web.Image, "docker.io/library/nginx:latest")
+	assert.Equal(t, web.Build.Context, "./web")
+	assert.DeepEqual(t, []string(web.EnvFile), []string{"./web.env"})
+	assert.Equal(t, web.Volumes[0].Source, "./html")
+	assert.Equal(t, web.Volumes[1].Source, "/var/run/docker.sock")
+	assert.Equal(t, web.Volumes[2].Source, "data")
+	assert.Equal(t, project.Secrets["token"].File, "./token.txt")
+	assert.Equal(t, project.WorkingDir, "")
+}
+
+func TestEnvFileKeys(t *testing.T) {
+	keys, err := envFileKeys([]byte("TAG=1.0\n# comment\nPASSWORD=s3cr3t\n"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(keys), "PASSWORD=\nTAG=\n")
+
+	keys, err = envFileKeys(nil)
+	assert.NilError(t, err)
+	assert.Assert(t, keys == nil)
+}
+
+func TestPublishedProjectKeepsValuesOut(t *testing.T) {
+	dir := fs.NewDir(t, "publish",
+		fs.WithFile("compose.yaml", `services:
+  web:
+    image: nginx
+    env_file: web.env
+    environment:
+      TOKEN: ${SECRET}
+      PASSWORD:
+`),
+		fs.WithFile("web.env", "API_KEY=from-env-file\n"),
+		fs.WithFile(".env", "SECRET=from-dot-env\n"))
+	defer dir.Remove()
+	defer env.Patch(t, "SECRET", "from-os-env")()
+	defer env.Patch(t, "PASSWORD", "from-os-env")()
+
+	opts := composeOptions{ConfigPaths: []string{dir.Join("compose.yaml")}}
+	project, err := opts.toPublishedProject()
+	assert.NilError(t, err)
+	_, err = normalizeForPublish(project)
+	assert.NilError(t, err)
+	composeFile, err := yaml.Marshal(project)
+	assert.NilError(t, err)
+
+	assert.Assert(t, !strings.Contains(string(composeFile), "from-"), string(composeFile))
+	web := project.Services[0]
+	assert.Equal(t, *web.Environment["TOKEN"], "${SECRET}")
+	assert.Assert(t, web.Environment["PASSWORD"] == nil)
+	_, ok := web.Environment["API_KEY"]
+	assert.Assert(t, !ok)
+	assert.DeepEqual(t, []string(web.EnvFile), []string{"web.env"})
+}
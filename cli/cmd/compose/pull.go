@@ -0,0 +1,100 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/progress"
+)
+
+func pullCommand() *cobra.Command {
+	opts := composeOptions{}
+	pullCmd := &cobra.Command{
+		Use:   "pull [SERVICE...]",
+		Short: "Pull service images",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPull(cmd.Context(), opts, args)
+		},
+	}
+	pullCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	pullCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	pullCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	pullCmd.Flags().StringArrayVarP(&opts.Environment, "environment", "e", []string{}, "Environment variables")
+
+	return pullCmd
+}
+
+func runPull(ctx context.Context, opts composeOptions, services []string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	if len(services) > 0 {
+		selected := types.Services{}
+		for _, name := range services {
+			found := false
+			for _, s := range project.Services {
+				if s.Name == name {
+					selected = append(selected, s)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("no such service: %q", name)
+			}
+		}
+		project.Services = selected
+	}
+
+	_, err = progress.Run(ctx, func(ctx context.Context) (string, error) {
+		return "", c.ComposeService().Pull(ctx, project)
+	})
+	return err
+}
+
+// resolvePullPolicy moves the `pull_policy` attribute of the services, which compose-go
+// doesn't support, to the x-pull-policy service extension
+func resolvePullPolicy(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for _, s := range services {
+			service, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if policy, ok := service["pull_policy"]; ok {
+				service[compose.PullPolicyExtension] = policy
+				delete(service, "pull_policy")
+				changed = true
+			}
+		}
+	}
+	return changed
+}
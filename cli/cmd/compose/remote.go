@@ -0,0 +1,260 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/oci"
+)
+
+// defaultComposeFiles are looked up in remote git projects, by order of preference
+var defaultComposeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml"}
+
+// isRemoteReference returns true when the compose file is an OCI artifact, a git repository or an HTTP URL
+func isRemoteReference(s string) bool {
+	return oci.IsReference(s) || isGitReference(s) || isHTTPReference(s)
+}
+
+// isGitReference returns true for `git://`, `git@` and `https://….git` references, which may
+// be suffixed with `#REF:SUBDIR`
+func isGitReference(s string) bool {
+	repo := strings.SplitN(s, "#", 2)[0]
+	if strings.HasPrefix(repo, "git://") || strings.HasPrefix(repo, "git@") {
+		return true
+	}
+	return isHTTPReference(repo) && strings.HasSuffix(repo, ".git")
+}
+
+func isHTTPReference(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// resolveRemoteReferences fetches the remote compose files and replaces them
+// with local copies, the returned func removes the copies
+func (o *composeOptions) resolveRemoteReferences(ctx context.Context) (func(), error) {
+	var dirs []string
+	cleanup := func() {
+		for _, d := range dirs {
+			_ = os.RemoveAll(d)
+		}
+	}
+	for i, ref := range o.ConfigPaths {
+		if !isRemoteReference(ref) {
+			continue
+		}
+		dir, err := ioutil.TempDir("", "compose-remote")
+		if err != nil {
+			return cleanup, err
+		}
+		dirs = append(dirs, dir)
+
+		var file, name string
+		switch {
+		case oci.IsReference(ref):
+			file, name, err = fetchOCI(ctx, ref, dir)
+		case isGitReference(ref):
+			file, name, err = fetchGit(ctx, ref, dir)
+		default:
+			file, name, err = fetchHTTP(ctx, ref, dir)
+		}
+		if err != nil {
+			return cleanup, err
+		}
+		o.ConfigPaths[i] = file
+		if o.WorkingDir == "" {
+			o.WorkingDir = filepath.Dir(file)
+		}
+		if o.Name == "" {
+			o.Name = name
+		}
+	}
+	return cleanup, nil
+}
+
+func fetchOCI(ctx context.Context, ref string, dir string) (string, string, error) {
+	artifact, err := oci.Pull(ctx, ref)
+	if err != nil {
+		return "", "", err
+	}
+	file := filepath.Join(dir, "compose.yaml")
+	if err := ioutil.WriteFile(file, artifact.ComposeFile, 0644); err != nil {
+		return "", "", err
+	}
+	if len(artifact.EnvFile) > 0 {
+		if err := ioutil.WriteFile(filepath.Join(dir, ".env"), artifact.EnvFile, 0644); err != nil {
+			return "", "", err
+		}
+	}
+	return file, artifact.ProjectName, nil
+}
+
+// fetchHTTP downloads the compose file over https, the expected content digest can be pinned with a `#sha256:HEX` suffix
+func fetchHTTP(ctx context.Context, ref string, dir string) (string, string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Scheme != "https" {
+		return "", "", errors.Errorf("insecure reference %s, only https is supported", ref)
+	}
+	pinned := u.Fragment
+	u.Fragment = ""
+	if pinned != "" && !strings.HasPrefix(pinned, "sha256:") {
+		return "", "", errors.Errorf("unsupported digest %q, only sha256 is supported", pinned)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return "", "", errors.Errorf("failed to fetch %s: %s", u, resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if pinned != "" {
+		sum := sha256.Sum256(data)
+		if actual := "sha256:" + hex.EncodeToString(sum[:]); actual != pinned {
+			return "", "", errors.Errorf("digest mismatch for %s: expected %s, got %s", u, pinned, actual)
+		}
+	}
+
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = "compose.yaml"
+	}
+	file := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return "", "", err
+	}
+	return file, httpProjectName(u), nil
+}
+
+// httpProjectName is the name of the directory holding the compose file, or the
+// host name when the file is served from the root of the server
+func httpProjectName(u *url.URL) string {
+	name := path.Base(path.Dir(u.Path))
+	if name == "/" || name == "." {
+		name = strings.ReplaceAll(u.Hostname(), ".", "-")
+	}
+	return compose.NormalizeProjectName(name)
+}
+
+// fetchGit checks out the repository at REF (a branch, a tag or a commit, default is
+// HEAD) and returns the compose file found in SUBDIR
+func fetchGit(ctx context.Context, ref string, dir string) (string, string, error) {
+	repo, gitRef, subdir := parseGitReference(ref)
+	if strings.HasPrefix(repo, "-") || strings.HasPrefix(gitRef, "-") {
+		return "", "", errors.Errorf("invalid git reference %q", ref)
+	}
+	if strings.HasPrefix(repo, "http://") {
+		return "", "", errors.Errorf("insecure reference %s, only https is supported", ref)
+	}
+	projectDir, err := gitProjectDir(dir, subdir)
+	if err != nil {
+		return "", "", err
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "--", "origin", repo},
+		{"fetch", "--quiet", "--depth", "1", "--", "origin", gitRef},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", "", errors.Wrapf(err, "git %s: %s", args[0], strings.TrimSpace(string(out)))
+		}
+	}
+
+	// the checkout may hold symlinks pointing out of it
+	if resolved, err := filepath.EvalSymlinks(projectDir); err == nil {
+		if _, err := gitProjectDir(dir, resolved); err != nil {
+			return "", "", err
+		}
+	}
+	name := strings.TrimSuffix(path.Base(strings.TrimSuffix(repo, "/")), ".git")
+	if subdir != "" {
+		name = path.Base(subdir)
+	}
+	info, err := os.Stat(projectDir)
+	if err != nil {
+		return "", "", err
+	}
+	if !info.IsDir() {
+		return projectDir, name, nil
+	}
+	if file, ok := findDefaultComposeFile(projectDir); ok {
+		return file, name, nil
+	}
+	return "", "", errors.Errorf("no compose file found in %s", ref)
+}
+
+// gitProjectDir returns the path of SUBDIR within the clone, which it must not escape
+func gitProjectDir(dir string, subdir string) (string, error) {
+	projectDir := filepath.Join(dir, filepath.FromSlash(subdir))
+	if filepath.IsAbs(subdir) {
+		projectDir = filepath.Clean(subdir)
+	}
+	root, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		root = dir
+	}
+	for _, d := range []string{dir, root} {
+		if rel, err := filepath.Rel(d, projectDir); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return projectDir, nil
+		}
+	}
+	return "", errors.Errorf("invalid git subdirectory %q: it is outside of the repository", subdir)
+}
+
+// parseGitReference splits a `REPOSITORY#REF:SUBDIR` reference
+func parseGitReference(ref string) (string, string, string) {
+	parts := strings.SplitN(ref, "#", 2)
+	repo, gitRef, subdir := parts[0], "HEAD", ""
+	if len(parts) == 2 {
+		refAndDir := strings.SplitN(parts[1], ":", 2)
+		if refAndDir[0] != "" {
+			gitRef = refAndDir[0]
+		}
+		if len(refAndDir) == 2 {
+			subdir = refAndDir[1]
+		}
+	}
+	return repo, gitRef, subdir
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsRemoteReference(t *testing.T) {
+	assert.Assert(t, isRemoteReference("oci://docker.io/user/app"))
+	assert.Assert(t, isRemoteReference("https://example.com/compose.yaml"))
+	assert.Assert(t, isRemoteReference("git://github.com/user/app#main:deploy"))
+	assert.Assert(t, !isRemoteReference("docker-compose.yaml"))
+	assert.Assert(t, !isRemoteReference("web"))
+
+	assert.Assert(t, isGitReference("git@github.com:user/app.git"))
+	assert.Assert(t, isGitReference("https://github.com/user/app.git#v1.0"))
+	assert.Assert(t, !isGitReference("https://example.com/compose.yaml"))
+}
+
+func TestParseGitReference(t *testing.T) {
+	repo, ref, dir := parseGitReference("git://github.com/user/app#main:deploy/prod")
+	assert.Equal(t, repo, "git://github.com/user/app")
+	assert.Equal(t, ref, "main")
+	assert.Equal(t, dir, "deploy/prod")
+
+	repo, ref, dir = parseGitReference("https://github.com/user/app.git")
+	assert.Equal(t, repo, "https://github.com/user/app.git")
+	assert.Equal(t, ref, "HEAD")
+	assert.Equal(t, dir, "")
+
+	_, ref, dir = parseGitReference("https://github.com/user/app.git#:deploy")
+	assert.Equal(t, ref, "HEAD")
+	assert.Equal(t, dir, "deploy")
+}
+
+func TestHTTPProjectName(t *testing.T) {
+	u, _ := url.Parse("https://example.com/apps/blog/compose.yaml")
+	assert.Equal(t, httpProjectName(u), "blog")
+	u, _ = url.Parse("https://compose.example.com/compose.yaml")
+	assert.Equal(t, httpProjectName(u), "compose-example-com")
+	u, _ = url.Parse("https://example.com")
+	assert.Equal(t, httpProjectName(u), "example-com")
+}
+
+func TestFetchHTTPRequiresHTTPS(t *testing.T) {
+	_, _, err := fetchHTTP(context.Background(), "http://example.com/compose.yaml", t.TempDir())
+	assert.ErrorContains(t, err, "only https is supported")
+}
+
+func TestFetchGitRejectsOptions(t *testing.T) {
+	_, _, err := fetchGit(context.Background(), "https://github.com/user/app.git#--upload-pack=touch", t.TempDir())
+	assert.ErrorContains(t, err, "invalid git reference")
+}
+
+func TestFetchGitRequiresHTTPS(t *testing.T) {
+	_, _, err := fetchGit(context.Background(), "http://github.com/user/app.git#main", t.TempDir())
+	assert.ErrorContains(t, err, "only https is supported")
+}
+
+func TestGitProjectDir(t *testing.T) {
+	dir := t.TempDir()
+	projectDir, err := gitProjectDir(dir, "deploy/prod")
+	assert.NilError(t, err)
+	assert.Equal(t, projectDir, filepath.Join(dir, "deploy", "prod"))
+
+	_, err = gitProjectDir(dir, "../../etc")
+	assert.ErrorContains(t, err, "outside of the repository")
+	_, err = gitProjectDir(dir, "deploy/../../etc")
+	assert.ErrorContains(t, err, "outside of the repository")
+	_, err = gitProjectDir(dir, string(os.PathSeparator)+"etc")
+	assert.ErrorContains(t, err, "outside of the repository")
+}
@@ -0,0 +1,181 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/containerd/console"
+	"github.com/mattn/go-shellwords"
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/progress"
+)
+
+type runOptions struct {
+	composeOptions
+	Service      string
+	Command      []string
+	NoTty        bool
+	ServicePorts bool
+	UseAliases   bool
+	Rm           bool
+	Entrypoint   string
+	User         string
+	Env          []string
+	ContainerDir string
+	Volumes      []string
+	Pull         string
+	Labels       []string
+}
+
+func runCommand() *cobra.Command {
+	opts := runOptions{}
+	runCmd := &cobra.Command{
+		Use:   "run [options] SERVICE [COMMAND] [ARGS...]",
+		Short: "Run a one-off command on a service.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.Service = args[0]
+			if len(args) > 1 {
+				opts.Command = args[1:]
+			}
+			return runRun(cmd.Context(), opts)
+		},
+	}
+	runCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	runCmd.Flags().StringVar(&opts.WorkingDir, "project-directory", "", "Specify an alternate working directory for the project")
+	runCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	runCmd.Flags().BoolVarP(&opts.Detach, "detach", "d", false, "Run container in background and print container ID")
+	runCmd.Flags().BoolVarP(&opts.NoTty, "no-TTY", "T", false, "Disable pseudo-tty allocation. By default a TTY is allocated when stdin is a terminal")
+	runCmd.Flags().BoolVar(&opts.ServicePorts, "service-ports", false, "Run command with the service's ports enabled and mapped to the host")
+	runCmd.Flags().BoolVar(&opts.UseAliases, "use-aliases", false, "Use the service's network aliases in the network(s) the container connects to")
+	runCmd.Flags().BoolVar(&opts.Rm, "rm", false, "Remove container after run. Ignored in detached mode")
+	runCmd.Flags().StringVar(&opts.Entrypoint, "entrypoint", "", "Override the entrypoint of the image")
+	runCmd.Flags().StringVarP(&opts.User, "user", "u", "", "Run as specified username or uid")
+	runCmd.Flags().StringArrayVarP(&opts.Env, "env", "e", []string{}, "Set environment variables")
+	runCmd.Flags().StringVarP(&opts.ContainerDir, "workdir", "w", "", "Working directory inside the container")
+	runCmd.Flags().StringArrayVarP(&opts.Volumes, "volume", "v", []string{}, "Bind mount a volume")
+	runCmd.Flags().StringVar(&opts.Pull, "pull", "", "Pull images before creating containers (\"always\"|\"missing\"|\"never\"), overrides the pull_policy of the services")
+	runCmd.Flags().StringArrayVarP(&opts.Labels, "label", "l", []string{}, "Add a key=value label to the container and the resources created for the project")
+	runCmd.Flags().SetInterspersed(false)
+
+	return runCmd
+}
+
+func runRun(ctx context.Context, opts runOptions) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	if err := applyLabels(project, opts.Labels); err != nil {
+		return err
+	}
+
+	dependencies, err := withDependencies(project, []string{opts.Service})
+	if err != nil {
+		return err
+	}
+	if len(dependencies) > 1 {
+		err = startDependencies(ctx, c, *project, dependencies[1:], opts.Pull)
+		if err != nil {
+			return err
+		}
+	}
+
+	entrypoint, err := shellwords.Parse(opts.Entrypoint)
+	if err != nil {
+		return err
+	}
+
+	_, isTerminal := term.GetFdInfo(os.Stdin)
+	runOpts := compose.RunOptions{
+		Service:      opts.Service,
+		Command:      opts.Command,
+		Detach:       opts.Detach,
+		Tty:          isTerminal && !opts.NoTty && !opts.Detach,
+		ServicePorts: opts.ServicePorts,
+		UseAliases:   opts.UseAliases,
+		AutoRemove:   opts.Rm && !opts.Detach,
+		Entrypoint:   entrypoint,
+		User:         opts.User,
+		Environment:  resolveEnvironment(opts.Env),
+		WorkingDir:   opts.ContainerDir,
+		Volumes:      opts.Volumes,
+		Pull:         opts.Pull,
+		Stdin:        os.Stdin,
+		Stdout:       os.Stdout,
+		Stderr:       os.Stderr,
+	}
+
+	if runOpts.Tty {
+		con := console.Current()
+		if err := con.SetRaw(); err != nil {
+			return err
+		}
+		defer func() {
+			if err := con.Reset(); err != nil {
+				fmt.Println("Unable to close the console")
+			}
+		}()
+
+		runOpts.Stdin = con
+		runOpts.Stdout = con
+		runOpts.Stderr = con
+	}
+
+	return c.ComposeService().RunOneOffContainer(ctx, project, runOpts)
+}
+
+func startDependencies(ctx context.Context, c *client.Client, project types.Project, services []string, pull string) error {
+	err := filterServices(&project, services)
+	if err != nil {
+		return err
+	}
+	_, err = progress.Run(ctx, func(ctx context.Context) (string, error) {
+		return "", c.ComposeService().Up(ctx, &project, compose.UpOptions{Detach: true, Pull: pull})
+	})
+	return err
+}
+
+// resolveEnvironment sets the value of variables passed without one from the current environment
+func resolveEnvironment(environment []string) []string {
+	var resolved []string
+	for _, e := range environment {
+		if !strings.Contains(e, "=") {
+			value, ok := os.LookupEnv(e)
+			if !ok {
+				continue
+			}
+			e = fmt.Sprintf("%s=%s", e, value)
+		}
+		resolved = append(resolved, e)
+	}
+	return resolved
+}
@@ -0,0 +1,90 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/audit"
+	"github.com/docker/compose-cli/progress"
+)
+
+func scaleCommand() *cobra.Command {
+	opts := composeOptions{}
+	scaleCmd := &cobra.Command{
+		Use:   "scale SERVICE=REPLICAS...",
+		Short: "Set the number of containers of services",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScale(cmd.Context(), opts, args)
+		},
+	}
+	scaleCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	scaleCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	scaleCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+
+	return scaleCmd
+}
+
+func runScale(ctx context.Context, opts composeOptions, args []string) error {
+	services, err := parseScaleArgs(args)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	return audited(ctx, audit.Record{Project: project.Name, Operation: "scale", Scale: services}, project, func() error {
+		_, err := progress.Run(ctx, func(ctx context.Context) (string, error) {
+			return "", c.ComposeService().Scale(ctx, project, compose.ScaleOptions{
+				Services: services,
+			})
+		})
+		return err
+	})
+}
+
+// parseScaleArgs parses SERVICE=REPLICAS arguments
+func parseScaleArgs(args []string) (map[string]int, error) {
+	services := map[string]int{}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("invalid scale %q, expected SERVICE=REPLICAS", arg)
+		}
+		replicas, err := strconv.Atoi(parts[1])
+		if err != nil || replicas < 0 {
+			return nil, errors.Errorf("invalid number of replicas %q for service %q", parts[1], parts[0])
+		}
+		services[parts[0]] = replicas
+	}
+	return services, nil
+}
@@ -0,0 +1,38 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseScaleArgs(t *testing.T) {
+	services, err := parseScaleArgs([]string{"web=5", "worker=0"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, services, map[string]int{"web": 5, "worker": 0})
+
+	_, err = parseScaleArgs([]string{"web"})
+	assert.ErrorContains(t, err, `invalid scale "web", expected SERVICE=REPLICAS`)
+
+	_, err = parseScaleArgs([]string{"web=-1"})
+	assert.ErrorContains(t, err, `invalid number of replicas "-1" for service "web"`)
+
+	_, err = parseScaleArgs([]string{"web=many"})
+	assert.ErrorContains(t, err, `invalid number of replicas "many" for service "web"`)
+}
@@ -0,0 +1,43 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"github.com/docker/compose-cli/api/secrets"
+)
+
+// resolveSecretsEnvironment moves the `environment` attribute of the secrets, which compose-go
+// doesn't support, to the x-environment secret extension. Backends read the secret content from
+// this environment variable of the invoking shell.
+func resolveSecretsEnvironment(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		declared, _ := config["secrets"].(map[interface{}]interface{})
+		for _, s := range declared {
+			secret, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if env, ok := secret["environment"]; ok {
+				secret[secrets.EnvironmentExtension] = env
+				delete(secret, "environment")
+				changed = true
+			}
+		}
+	}
+	return changed
+}
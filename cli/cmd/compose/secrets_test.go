@@ -0,0 +1,37 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveSecretsEnvironment(t *testing.T) {
+	configs := []map[string]interface{}{{
+		"secrets": map[interface{}]interface{}{
+			"token":    map[interface{}]interface{}{"environment": "API_TOKEN"},
+			"password": map[interface{}]interface{}{"file": "./password.txt"},
+		},
+	}}
+
+	assert.Assert(t, resolveSecretsEnvironment(configs))
+	declared := configs[0]["secrets"].(map[interface{}]interface{})
+	assert.DeepEqual(t, declared["token"], map[interface{}]interface{}{"x-environment": "API_TOKEN"})
+	assert.DeepEqual(t, declared["password"], map[interface{}]interface{}{"file": "./password.txt"})
+}
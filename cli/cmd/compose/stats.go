@@ -0,0 +1,102 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/go-units"
+	"github.com/moby/term"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/formatter"
+)
+
+type statsOptions struct {
+	composeOptions
+	NoStream bool
+}
+
+func statsCommand() *cobra.Command {
+	opts := statsOptions{}
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Display the resource usage of the services, summed over their replicas",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd.Context(), opts)
+		},
+	}
+	statsCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	statsCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	statsCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	statsCmd.Flags().StringVar(&opts.Format, "format", "", formatter.FormatUsage)
+	statsCmd.Flags().BoolVar(&opts.NoStream, "no-stream", false, "Disable streaming stats and only pull the first result")
+
+	return statsCmd
+}
+
+func runStats(ctx context.Context, opts statsOptions) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+
+	_, isTerminal := term.GetFdInfo(os.Stdout)
+	var printErr error
+	err = c.ComposeService().Stats(ctx, projectName, compose.StatsOptions{
+		Stream: !opts.NoStream,
+		Consumer: func(stats []compose.ServiceStats) {
+			if printErr != nil {
+				return
+			}
+			if !opts.NoStream && isTerminal && opts.Format != formatter.JSON {
+				// redraw the table in place
+				fmt.Print("\033[2J\033[H")
+			}
+			printErr = printStats(stats, opts.Format, os.Stdout)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return printErr
+}
+
+func printStats(stats []compose.ServiceStats, format string, out io.Writer) error {
+	return formatter.Print(stats, format, out,
+		func(w io.Writer) {
+			for _, s := range stats {
+				memPercentage := 0.0
+				if s.MemoryLimit > 0 {
+					memPercentage = float64(s.MemoryUsage) / float64(s.MemoryLimit) * 100
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%.2f%%\t%s / %s\t%.2f%%\n", s.Service, s.Replicas, s.CPUPercentage,
+					units.BytesSize(float64(s.MemoryUsage)), units.BytesSize(float64(s.MemoryLimit)), memPercentage)
+			}
+		},
+		"SERVICE", "REPLICAS", "CPU %", "MEM USAGE / LIMIT", "MEM %")
+}
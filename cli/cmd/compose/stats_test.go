@@ -0,0 +1,37 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestPrintStats(t *testing.T) {
+	var b bytes.Buffer
+	err := printStats([]compose.ServiceStats{
+		{Service: "web", Replicas: 2, CPUPercentage: 15.5, MemoryUsage: 150 * 1024 * 1024, MemoryLimit: 2 * 1024 * 1024 * 1024},
+	}, "", &b)
+	assert.NilError(t, err)
+	assert.Equal(t, b.String(), `SERVICE             REPLICAS            CPU %               MEM USAGE / LIMIT   MEM %
+web                 2                   15.50%              150MiB / 2GiB       7.32%
+`)
+}
@@ -18,22 +18,50 @@ package compose
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/compose-spec/compose-go/types"
+	mobyclient "github.com/docker/docker/client"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
-	"github.com/compose-spec/compose-go/cli"
-
 	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/audit"
+	"github.com/docker/compose-cli/builder"
+	apicontext "github.com/docker/compose-cli/context"
 	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
 	"github.com/docker/compose-cli/progress"
+	"github.com/docker/compose-cli/prompt"
+	"github.com/docker/compose-cli/quota"
+	"github.com/docker/compose-cli/webhook"
 )
 
+type upOptions struct {
+	composeOptions
+	AttachDependencies bool
+	WaitTimeout        time.Duration
+	HealthInterval     time.Duration
+	AssumeYes          bool
+	Pull               string
+	Labels             []string
+	Build              bool
+	Strict             bool
+	NoDeps             bool
+}
+
 func upCommand(contextType string) *cobra.Command {
-	opts := composeOptions{}
+	opts := upOptions{}
 	upCmd := &cobra.Command{
-		Use: "up",
+		Use: "up [REMOTE_PROJECT] [SERVICE...]",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUp(cmd.Context(), opts)
+			return runUp(cmd.Context(), contextType, opts, args)
 		},
 	}
 	upCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
@@ -41,6 +69,20 @@ func upCommand(contextType string) *cobra.Command {
 	upCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
 	upCmd.Flags().StringArrayVarP(&opts.Environment, "environment", "e", []string{}, "Environment variables")
 	upCmd.Flags().BoolVarP(&opts.Detach, "detach", "d", false, " Detached mode: Run containers in the background")
+	upCmd.Flags().BoolVar(&opts.AttachDependencies, "attach-dependencies", false, "Attach to dependent services")
+	upCmd.Flags().BoolVar(&opts.NoDeps, "no-deps", false, "Don't start linked services")
+	upCmd.Flags().DurationVar(&opts.WaitTimeout, "wait-timeout", 0, "Maximum duration to wait for dependencies to be healthy, no limit if zero")
+	upCmd.Flags().DurationVar(&opts.HealthInterval, "health-interval", 0, "Interval between dependencies health checks when the engine reports no event (Default: 5s)")
+	upCmd.Flags().BoolVarP(&opts.AssumeYes, "yes", "y", false, "Apply changes to the existing deployment without confirmation")
+	upCmd.Flags().StringVar(&opts.Pull, "pull", "", "Pull images before creating containers (\"always\"|\"missing\"|\"never\"), overrides the pull_policy of the services")
+	upCmd.Flags().StringArrayVarP(&opts.Labels, "label", "l", []string{}, "Add a key=value label to every resource created for the project")
+	upCmd.Flags().BoolVar(&opts.Strict, "strict", false, "Fail before creating any resource when the project uses fields the backend doesn't support, instead of ignoring them with a warning")
+	if contextType == store.AciContextType || contextType == store.EcsContextType {
+		upCmd.Flags().BoolVar(&opts.Build, "build", false, "Build images with the local engine and push them to a registry provisioned by the backend")
+	} else {
+		upCmd.Flags().Bool("build", false, "Build images before starting containers")
+		_ = upCmd.Flags().MarkDeprecated("build", "the local backend doesn't build images yet, this flag has no effect and missing images are pulled")
+	}
 
 	if contextType == store.AciContextType {
 		upCmd.Flags().StringVar(&opts.DomainName, "domainname", "", "Container NIS domain name")
@@ -49,26 +91,228 @@ func upCommand(contextType string) *cobra.Command {
 	return upCmd
 }
 
-func runUp(ctx context.Context, opts composeOptions) error {
+func runUp(ctx context.Context, contextType string, opts upOptions, services []string) error {
 	c, err := client.New(ctx)
 	if err != nil {
 		return err
 	}
 
-	_, err = progress.Run(ctx, func(ctx context.Context) (string, error) {
-		options, err := opts.toProjectOptions()
-		if err != nil {
-			return "", err
+	if len(services) > 0 && isRemoteReference(services[0]) {
+		opts.ConfigPaths = append(opts.ConfigPaths, services[0])
+		services = services[1:]
+	}
+	cleanup, err := opts.resolveRemoteReferences(ctx)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	if opts.DomainName != "" {
+		//arbitrarily set the domain name on the first service ; ACI backend will expose the entire project
+		project.Services[0].DomainName = opts.DomainName
+	}
+	if opts.NoDeps {
+		err = selectServices(project, services)
+	} else {
+		err = filterServices(project, services)
+	}
+	if err != nil {
+		return err
+	}
+	if err := applyLabels(project, opts.Labels); err != nil {
+		return err
+	}
+
+	warnings := &compose.Warnings{Strict: opts.Strict}
+	ctx = compose.WithWarnings(ctx, warnings)
+	if err := warnUnsupportedFeatures(ctx, c.ComposeService(), project); err != nil {
+		return err
+	}
+
+	if err := checkQuotas(ctx, project, contextType); err != nil {
+		return err
+	}
+
+	if opts.Build {
+		if err := buildAndPush(ctx, c.ComposeService(), project); err != nil {
+			return err
 		}
-		project, err := cli.ProjectFromOptions(options)
-		if opts.DomainName != "" {
-			//arbitrarily set the domain name on the first service ; ACI backend will expose the entire project
-			project.Services[0].DomainName = opts.DomainName
+	}
+
+	attach := !opts.Detach && contextType == store.LocalContextType
+	hooks, err := webhook.Load(project)
+	if err != nil {
+		return err
+	}
+	if attach && len(hooks) > 0 {
+		// notify webhooks of the containers lifecycle while attached, comparing with the status before up
+		baseline, err := c.ComposeService().Ps(ctx, project.Name)
+		if err != nil || baseline == nil {
+			baseline = []compose.ServiceStatus{}
 		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go webhook.Watch(watchCtx, c.ComposeService(), project.Name, hooks, webhook.WatchOptions{ //nolint:errcheck
+			Baseline: baseline,
+		})
+	}
+
+	plan, err := reviewUpPlan(ctx, c.ComposeService(), project, opts.AssumeYes, prompt.User{})
+	if err != nil {
+		return err
+	}
+
+	err = audited(ctx, audit.Record{Project: project.Name, Operation: "up", Services: services}, project, func() error {
+		_, err := progress.Run(ctx, func(ctx context.Context) (string, error) {
+			return "", c.ComposeService().Up(ctx, project, compose.UpOptions{
+				Detach:         opts.Detach,
+				WaitTimeout:    opts.WaitTimeout,
+				HealthInterval: opts.HealthInterval,
+				Plan:           plan,
+				Pull:           opts.Pull,
+			})
+		})
+		return err
+	})
+	printWarnings(os.Stderr, project.Name, warnings.List())
+	if err != nil || !attach {
+		return err
+	}
+
+	attached := services
+	if opts.AttachDependencies && len(services) > 0 {
+		attached, err = withDependencies(project, services)
 		if err != nil {
-			return "", err
+			return err
 		}
-		return "", c.ComposeService().Up(ctx, project, opts.Detach)
+	}
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	return attachLogs(c.ComposeService(), project, attached, signals)
+}
+
+// buildAndPush builds the images of the services with the local engine and pushes them to the
+// repositories provisioned by the cloud backend, which then deploys the pushed images
+func buildAndPush(ctx context.Context, service compose.Service, project *types.Project) error {
+	apiClient, err := mobyclient.NewClientWithOpts(mobyclient.FromEnv, mobyclient.WithAPIVersionNegotiation())
+	if err != nil {
+		return err
+	}
+	_, err = progress.Run(ctx, func(ctx context.Context) (string, error) {
+		return "", builder.BuildAndPush(ctx, apiClient, project, func(ctx context.Context, name string) (compose.ImageRepository, error) {
+			return service.EnsureImageRepository(ctx, project.Name, name)
+		})
 	})
 	return err
 }
+
+// attachLogs follows the logs of the services until their containers exit. On a first interruption
+// the project is gracefully stopped, a second interruption escalates to killing the containers.
+func attachLogs(service compose.Service, project *types.Project, services []string, signals <-chan os.Signal) error {
+	// the command context is cancelled on interruption, the logs and the stop must outlive it
+	logsCtx, cancelLogs := context.WithCancel(context.Background())
+	defer cancelLogs()
+	logs := make(chan error, 1)
+	go func() {
+		logs <- service.Logs(logsCtx, project.Name, os.Stdout, compose.LogOptions{
+			Services: services,
+		})
+	}()
+	select {
+	case err := <-logs:
+		return err
+	case <-signals:
+		cancelLogs()
+	}
+
+	fmt.Fprintln(os.Stderr, "Gracefully stopping... (press Ctrl+C again to force)")
+	stopCtx, cancelStop := context.WithCancel(context.Background())
+	defer cancelStop()
+	stopped := make(chan error, 1)
+	go func() {
+		_, err := progress.Run(stopCtx, func(ctx context.Context) (string, error) {
+			return "", service.Stop(ctx, project)
+		})
+		stopped <- err
+	}()
+	select {
+	case err := <-stopped:
+		return err
+	case <-signals:
+		cancelStop()
+		// wait for the pending stop requests to be cancelled before rendering the kill progress
+		<-stopped
+	}
+	_, err := progress.Run(context.Background(), func(ctx context.Context) (string, error) {
+		return "", service.Kill(ctx, project.Name)
+	})
+	return err
+}
+
+// checkQuotas validates the project against the quotas of the current context
+func checkQuotas(ctx context.Context, project *types.Project, contextType string) error {
+	name := apicontext.CurrentContext(ctx)
+	if name == store.DefaultContextName {
+		// quotas can't be set on the default context
+		return nil
+	}
+	dockerContext, err := store.ContextStore(ctx).Get(name)
+	if err != nil {
+		return err
+	}
+	return quota.Check(project, contextType, dockerContext.Metadata.Quotas)
+}
+
+// reviewUpPlan displays the changes applied to an existing deployment and asks for confirmation,
+// it returns the ID of the plan to apply, empty when the backend doesn't support reviewing changes
+func reviewUpPlan(ctx context.Context, service compose.Service, project *types.Project, assumeYes bool, ui prompt.UI) (string, error) {
+	plan, err := service.UpPlan(ctx, project)
+	if errdefs.IsErrNotImplemented(err) {
+		return "", nil
+	}
+	if err != nil || plan.ID == "" {
+		return "", err
+	}
+	if len(plan.Changes) == 0 {
+		fmt.Println("No changes to apply")
+		return plan.ID, nil
+	}
+	printUpPlan(os.Stdout, plan)
+	if assumeYes {
+		return plan.ID, nil
+	}
+	confirmed, err := ui.Confirm("Apply these changes?", false)
+	if err != nil {
+		return "", err
+	}
+	if !confirmed {
+		return "", errors.New("update cancelled, no change has been applied")
+	}
+	return plan.ID, nil
+}
+
+// printUpPlan lists the changes of a plan, replaced resources being deleted then created again
+func printUpPlan(w io.Writer, plan compose.ChangePlan) {
+	symbols := map[string]string{
+		compose.ChangeAdd:    "+",
+		compose.ChangeModify: "~",
+		compose.ChangeRemove: "-",
+	}
+	fmt.Fprintln(w, "Changes:")
+	for _, c := range plan.Changes {
+		symbol, ok := symbols[c.Action]
+		if !ok {
+			symbol = "?"
+		}
+		line := fmt.Sprintf("  %s %s %s", symbol, c.Type, c.Name)
+		if c.Replacement {
+			line += " (replacement)"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/golang/mock/gomock"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/prompt"
+)
+
+type planService struct {
+	compose.Service
+	plan compose.ChangePlan
+	err  error
+}
+
+func (s planService) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return s.plan, s.err
+}
+
+var changes = compose.ChangePlan{
+	ID: "Update",
+	Changes: []compose.ResourceChange{
+		{Action: compose.ChangeAdd, Type: "AWS::ECS::Service", Name: "WorkerService"},
+		{Action: compose.ChangeModify, Type: "AWS::ECS::TaskDefinition", Name: "WebTaskDefinition", Replacement: true},
+		{Action: compose.ChangeRemove, Type: "AWS::Logs::LogGroup", Name: "LogGroup"},
+	},
+}
+
+type signalService struct {
+	compose.Service
+	calls   chan string
+	blockOn string
+}
+
+func (s signalService) Logs(ctx context.Context, projectName string, w io.Writer, options compose.LogOptions) error {
+	s.calls <- "logs"
+	<-ctx.Done()
+	return nil
+}
+
+func (s signalService) Stop(ctx context.Context, project *types.Project) error {
+	s.calls <- "stop"
+	if s.blockOn == "stop" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (s signalService) Kill(ctx context.Context, projectName string) error {
+	s.calls <- "kill"
+	return nil
+}
+
+func TestAttachLogsStopsOnSignal(t *testing.T) {
+	project := &types.Project{Name: "demo"}
+	signals := make(chan os.Signal, 2)
+	service := signalService{calls: make(chan string, 3)}
+
+	done := make(chan error)
+	go func() {
+		done <- attachLogs(service, project, nil, signals)
+	}()
+	assert.Equal(t, <-service.calls, "logs")
+	signals <- os.Interrupt
+	assert.Equal(t, <-service.calls, "stop")
+	assert.NilError(t, <-done)
+	assert.Equal(t, len(service.calls), 0)
+}
+
+func TestAttachLogsKillsOnSecondSignal(t *testing.T) {
+	project := &types.Project{Name: "demo"}
+	signals := make(chan os.Signal, 2)
+	service := signalService{calls: make(chan string, 3), blockOn: "stop"}
+
+	done := make(chan error)
+	go func() {
+		done <- attachLogs(service, project, nil, signals)
+	}()
+	assert.Equal(t, <-service.calls, "logs")
+	signals <- os.Interrupt
+	assert.Equal(t, <-service.calls, "stop")
+	signals <- os.Interrupt
+	assert.Equal(t, <-service.calls, "kill")
+	assert.NilError(t, <-done)
+}
+
+func TestPrintUpPlan(t *testing.T) {
+	var b bytes.Buffer
+	printUpPlan(&b, changes)
+	assert.Equal(t, b.String(), `Changes:
+  + AWS::ECS::Service WorkerService
+  ~ AWS::ECS::TaskDefinition WebTaskDefinition (replacement)
+  - AWS::Logs::LogGroup LogGroup
+`)
+}
+
+func TestReviewUpPlan(t *testing.T) {
+	ctx := context.Background()
+	project := &types.Project{Name: "demo"}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ui := prompt.NewMockUI(ctrl)
+
+	plan, err := reviewUpPlan(ctx, planService{err: errdefs.ErrNotImplemented}, project, false, ui)
+	assert.NilError(t, err)
+	assert.Equal(t, plan, "")
+
+	plan, err = reviewUpPlan(ctx, planService{plan: changes}, project, true, ui)
+	assert.NilError(t, err)
+	assert.Equal(t, plan, "Update")
+
+	ui.EXPECT().Confirm("Apply these changes?", false).Return(true, nil)
+	plan, err = reviewUpPlan(ctx, planService{plan: changes}, project, false, ui)
+	assert.NilError(t, err)
+	assert.Equal(t, plan, "Update")
+
+	ui.EXPECT().Confirm("Apply these changes?", false).Return(false, nil)
+	_, err = reviewUpPlan(ctx, planService{plan: changes}, project, false, ui)
+	assert.ErrorContains(t, err, "update cancelled")
+
+	// nothing to confirm when the deployment doesn't change
+	plan, err = reviewUpPlan(ctx, planService{plan: compose.ChangePlan{ID: "Update"}}, project, false, ui)
+	assert.NilError(t, err)
+	assert.Equal(t, plan, "Update")
+}
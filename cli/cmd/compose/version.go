@@ -0,0 +1,115 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/internal"
+)
+
+type versionOptions struct {
+	composeOptions
+	check bool
+}
+
+type featureView struct {
+	Feature   string
+	Supported bool
+}
+
+func versionCommand() *cobra.Command {
+	opts := versionOptions{}
+	versionCmd := &cobra.Command{
+		Use:   "version",
+		Short: "Show the compose version and the compose specification features the current backend supports",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVersion(cmd.Context(), opts)
+		},
+	}
+	versionCmd.Flags().StringVar(&opts.Format, "format", "", formatter.FormatUsage)
+	versionCmd.Flags().BoolVar(&opts.check, "check", false, "Check the compose files only use features the current backend supports")
+	versionCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	versionCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+
+	return versionCmd
+}
+
+func runVersion(ctx context.Context, opts versionOptions) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+	capabilities, err := c.ComposeService().Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	if opts.check {
+		project, err := opts.toProject()
+		if err != nil {
+			return err
+		}
+		return checkCapabilities(project.Name, capabilities, compose.UnsupportedFeatures(project, capabilities))
+	}
+
+	view := featuresView(capabilities)
+	if opts.Format == "" || opts.Format == formatter.PRETTY {
+		fmt.Printf("Compose version %s, %s backend\n", strings.TrimPrefix(internal.Version, "v"), capabilities.Backend)
+	}
+	return formatter.Print(view, opts.Format, os.Stdout,
+		func(w io.Writer) {
+			for _, f := range view {
+				supported := "no"
+				if f.Supported {
+					supported = "yes"
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", f.Feature, supported)
+			}
+		},
+		"FEATURE", "SUPPORTED")
+}
+
+func featuresView(capabilities compose.Capabilities) []featureView {
+	view := []featureView{}
+	for _, f := range compose.Features {
+		view = append(view, featureView{
+			Feature:   f,
+			Supported: capabilities.Supports(f),
+		})
+	}
+	return view
+}
+
+// checkCapabilities fails when a project uses features the backend doesn't support
+func checkCapabilities(projectName string, capabilities compose.Capabilities, unsupported []string) error {
+	if len(unsupported) == 0 {
+		return nil
+	}
+	return errors.Errorf("project %q uses features the %s backend doesn't support: %s", projectName, capabilities.Backend, strings.Join(unsupported, ", "))
+}
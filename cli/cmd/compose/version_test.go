@@ -0,0 +1,55 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+var aciCapabilities = compose.Capabilities{
+	Backend:   "aci",
+	Supported: []string{compose.FeatureHealthcheck, compose.FeatureSecrets, compose.FeatureVolumes},
+}
+
+func TestFeaturesView(t *testing.T) {
+	view := featuresView(aciCapabilities)
+	assert.Equal(t, len(view), len(compose.Features))
+	assert.DeepEqual(t, view[0], featureView{Feature: compose.FeatureBuild, Supported: false})
+	assert.DeepEqual(t, view[len(view)-1], featureView{Feature: compose.FeatureVolumes, Supported: true})
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	replicas := uint64(3)
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "web", Build: &types.BuildConfig{Context: "."}, Deploy: &types.DeployConfig{Replicas: &replicas}},
+			{Name: "db", HealthCheck: &types.HealthCheckConfig{Test: []string{"CMD", "pg_isready"}}},
+		},
+	}
+	unsupported := compose.UnsupportedFeatures(project, aciCapabilities)
+	assert.DeepEqual(t, unsupported, []string{compose.FeatureBuild, compose.FeatureScale})
+
+	err := checkCapabilities(project.Name, aciCapabilities, unsupported)
+	assert.Error(t, err, `project "demo" uses features the aci backend doesn't support: build, scale`)
+	assert.NilError(t, checkCapabilities(project.Name, aciCapabilities, []string{}))
+}
@@ -0,0 +1,69 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/formatter"
+)
+
+func waitCommand() *cobra.Command {
+	opts := composeOptions{}
+	waitCmd := &cobra.Command{
+		Use:   "wait [SERVICE...]",
+		Short: "Block until the containers of the services exit, then print their exit codes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWait(cmd.Context(), opts, args)
+		},
+	}
+	waitCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	waitCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	waitCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+	waitCmd.Flags().StringVar(&opts.Format, "format", "", formatter.FormatUsage)
+
+	return waitCmd
+}
+
+func runWait(ctx context.Context, opts composeOptions, services []string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	projectName, err := opts.toProjectName()
+	if err != nil {
+		return err
+	}
+	exits, err := c.ComposeService().Wait(ctx, projectName, services)
+	if err != nil {
+		return err
+	}
+	return formatter.Print(exits, opts.Format, os.Stdout,
+		func(w io.Writer) {
+			for _, e := range exits {
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%d\n", e.Name, e.Service, e.ExitCode)
+			}
+		},
+		"NAME", "SERVICE", "EXIT CODE")
+}
@@ -0,0 +1,66 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// warnUnsupportedFeatures reports the features the project uses which the backend doesn't support, it fails
+// on the first one in strict mode
+func warnUnsupportedFeatures(ctx context.Context, service compose.Service, project *types.Project) error {
+	capabilities, err := service.Capabilities(ctx)
+	if errdefs.IsErrNotImplemented(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return warnFeatures(ctx, project, capabilities)
+}
+
+func warnFeatures(ctx context.Context, project *types.Project, capabilities compose.Capabilities) error {
+	for _, f := range compose.UnsupportedFeatures(project, capabilities) {
+		err := compose.Warn(ctx, compose.Warning{
+			Field:   f,
+			Message: fmt.Sprintf("not supported by the %s backend, ignored", capabilities.Backend),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printWarnings shows the warnings collected while the project was deployed
+func printWarnings(w io.Writer, projectName string, warnings []compose.Warning) {
+	if len(warnings) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "WARNING: project %q was deployed ignoring unsupported fields:\n", projectName)
+	for _, warning := range warnings {
+		fmt.Fprintf(w, " - %s\n", warning)
+	}
+	fmt.Fprintln(w, "Use --strict to fail instead of ignoring them.")
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestWarnFeatures(t *testing.T) {
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "web", Build: &types.BuildConfig{Context: "."}, Privileged: true},
+		},
+	}
+	capabilities := compose.Capabilities{Backend: "aci", Supported: []string{compose.FeatureHealthcheck}}
+
+	warnings := &compose.Warnings{}
+	ctx := compose.WithWarnings(context.TODO(), warnings)
+	assert.NilError(t, warnFeatures(ctx, project, capabilities))
+	assert.DeepEqual(t, warnings.List(), []compose.Warning{
+		{Field: compose.FeatureBuild, Message: "not supported by the aci backend, ignored"},
+		{Field: compose.FeaturePrivileged, Message: "not supported by the aci backend, ignored"},
+	})
+
+	strict := compose.WithWarnings(context.TODO(), &compose.Warnings{Strict: true})
+	err := warnFeatures(strict, project, capabilities)
+	assert.Assert(t, errdefs.IsErrNotImplemented(err))
+	assert.ErrorContains(t, err, "build: not supported by the aci backend")
+}
+
+func TestPrintWarnings(t *testing.T) {
+	var out bytes.Buffer
+	printWarnings(&out, "demo", nil)
+	assert.Equal(t, out.String(), "")
+
+	printWarnings(&out, "demo", []compose.Warning{
+		{Service: "web", Field: "services.ulimits", Message: "not supported by Fargate"},
+		{Message: "services.logging.driver syslog is not supported"},
+	})
+	assert.Equal(t, out.String(), `WARNING: project "demo" was deployed ignoring unsupported fields:
+ - service "web": services.ulimits: not supported by Fargate
+ - services.logging.driver syslog is not supported
+Use --strict to fail instead of ignoring them.
+`)
+}
@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func watchCommand() *cobra.Command {
+	opts := composeOptions{}
+	watchCmd := &cobra.Command{
+		Use:   "watch [SERVICE...]",
+		Short: "Watch the files of the services and sync, restart or rebuild them on changes, as configured by their develop section",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWatch(cmd.Context(), opts, args)
+		},
+	}
+	watchCmd.Flags().StringVarP(&opts.Name, "project-name", "p", "", "Project name")
+	watchCmd.Flags().StringVar(&opts.WorkingDir, "workdir", "", "Work dir")
+	watchCmd.Flags().StringArrayVarP(&opts.ConfigPaths, "file", "f", []string{}, "Compose configuration files")
+
+	return watchCmd
+}
+
+func runWatch(ctx context.Context, opts composeOptions, services []string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := opts.toProject()
+	if err != nil {
+		return err
+	}
+	return c.ComposeService().Watch(ctx, project, compose.WatchOptions{
+		Services: services,
+		Out:      os.Stdout,
+	})
+}
+
+// resolveDevelop moves the `develop` section of the services, which compose-go
+// doesn't support, to the x-develop service extension
+func resolveDevelop(configs []map[string]interface{}) bool {
+	changed := false
+	for _, config := range configs {
+		services, _ := config["services"].(map[interface{}]interface{})
+		for _, s := range services {
+			service, ok := s.(map[interface{}]interface{})
+			if !ok {
+				continue
+			}
+			if develop, ok := service["develop"]; ok {
+				service[compose.DevelopExtension] = develop
+				delete(service, "develop")
+				changed = true
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,45 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compose
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestResolveDevelop(t *testing.T) {
+	watch := []interface{}{
+		map[interface{}]interface{}{"path": "./src", "action": "sync", "target": "/app/src"},
+	}
+	configs := []map[string]interface{}{{
+		"services": map[interface{}]interface{}{
+			"web": map[interface{}]interface{}{"image": "nginx", "develop": map[interface{}]interface{}{"watch": watch}},
+			"db":  map[interface{}]interface{}{"image": "postgres"},
+		},
+	}}
+
+	assert.Assert(t, resolveDevelop(configs))
+	services := configs[0]["services"].(map[interface{}]interface{})
+	assert.DeepEqual(t, services["web"], map[interface{}]interface{}{
+		"image":     "nginx",
+		"x-develop": map[interface{}]interface{}{"watch": watch},
+	})
+	assert.DeepEqual(t, services["db"], map[interface{}]interface{}{"image": "postgres"})
+
+	assert.Assert(t, !resolveDevelop(configs))
+}
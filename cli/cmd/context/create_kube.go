@@ -0,0 +1,71 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package context
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/kube"
+)
+
+func init() {
+	extraCommands = append(extraCommands, createKubeCommand)
+	extraHelp = append(extraHelp, `
+Create Kubernetes context:
+$ docker context create kube CONTEXT [flags]
+(see docker context create kube --help)
+`)
+}
+
+func createKubeCommand() *cobra.Command {
+	var opts kube.ContextParams
+	cmd := &cobra.Command{
+		Use:   "kube CONTEXT [flags]",
+		Short: "Create a context for a Kubernetes cluster",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreateKube(cmd.Context(), args[0], opts)
+		},
+	}
+
+	addDescriptionFlag(cmd, &opts.Description)
+	cmd.Flags().StringVar(&opts.KubeConfig, "kubeconfig", "", "Path to the kubeconfig file, kubectl defaults when not set")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", "default", "Namespace compose applications are deployed to")
+
+	return cmd
+}
+
+func runCreateKube(ctx context.Context, contextName string, opts kube.ContextParams) error {
+	if contextExists(ctx, contextName) {
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "context %s", contextName)
+	}
+	cs, err := client.GetCloudService(ctx, store.KubeContextType)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to Kubernetes backend")
+	}
+	contextData, description, err := cs.CreateContextData(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return createDockerContext(ctx, contextName, store.KubeContextType, description, contextData)
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
-	"github.com/docker/compose-cli/cli/mobycli"
 	apicontext "github.com/docker/compose-cli/context"
 	"github.com/docker/compose-cli/context/store"
 	"github.com/docker/compose-cli/formatter"
@@ -34,17 +33,21 @@ import (
 
 type lsOpts struct {
 	quiet  bool
-	json   bool
 	format string
 }
 
 func (o lsOpts) validate() error {
-	if o.quiet && o.json {
+	format := o.normalizedFormat()
+	if o.quiet && (format == formatter.JSON || format == formatter.TemplateLegacyJSON) {
 		return errors.New(`cannot combine "quiet" and "json" options`)
 	}
 	return nil
 }
 
+func (o lsOpts) normalizedFormat() string {
+	return strings.ToLower(strings.ReplaceAll(o.format, " ", ""))
+}
+
 func listCommand() *cobra.Command {
 	var opts lsOpts
 	cmd := &cobra.Command{
@@ -57,7 +60,7 @@ func listCommand() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Only show context names")
-	cmd.Flags().StringVar(&opts.format, "format", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+	cmd.Flags().StringVar(&opts.format, "format", "", formatter.FormatUsage)
 
 	return cmd
 }
@@ -67,11 +70,7 @@ func runList(cmd *cobra.Command, opts lsOpts) error {
 	if err != nil {
 		return err
 	}
-	format := strings.ToLower(strings.ReplaceAll(opts.format, " ", ""))
-	if format != "" && format != formatter.JSON && format != formatter.PRETTY && format != formatter.TemplateLegacyJSON {
-		mobycli.Exec(cmd.Root())
-		return nil
-	}
+	format := opts.normalizedFormat()
 
 	ctx := cmd.Context()
 	currentContext := apicontext.CurrentContext(ctx)
@@ -92,14 +91,12 @@ func runList(cmd *cobra.Command, opts lsOpts) error {
 		return nil
 	}
 
-	if opts.json || format == formatter.JSON {
-		opts.format = formatter.JSON
-	}
-	if format == formatter.TemplateLegacyJSON {
-		opts.format = formatter.TemplateLegacyJSON
+	if format == formatter.JSON || format == formatter.TemplateLegacyJSON {
+		opts.format = format
 	}
 
 	view := viewFromContextList(contexts, currentContext)
+	pingContexts(ctx, contexts, view)
 	return formatter.Print(view, opts.format, os.Stdout,
 		func(w io.Writer) {
 			for _, c := range view {
@@ -107,16 +104,17 @@ func runList(cmd *cobra.Command, opts lsOpts) error {
 				if c.Current {
 					contextName += " *"
 				}
-				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 					contextName,
 					c.ContextType,
 					c.Description,
 					c.DockerEndpoint,
 					c.KubernetesEndpoint,
-					c.StackOrchestrator)
+					c.StackOrchestrator,
+					c.Status)
 			}
 		},
-		"NAME", "TYPE", "DESCRIPTION", "DOCKER ENDPOINT", "KUBERNETES ENDPOINT", "ORCHESTRATOR")
+		"NAME", "TYPE", "DESCRIPTION", "DOCKER ENDPOINT", "KUBERNETES ENDPOINT", "ORCHESTRATOR", "STATUS")
 }
 
 func getEndpoint(name string, meta map[string]interface{}) string {
@@ -145,6 +143,7 @@ type contextView struct {
 	ContextType        string
 	Name               string
 	StackOrchestrator  string
+	Status             string
 }
 
 func viewFromContextList(contextList []*store.DockerContext, currentContext string) []contextView {
@@ -0,0 +1,77 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package context
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestLsOptsValidate(t *testing.T) {
+	assert.NilError(t, lsOpts{quiet: true}.validate())
+	assert.NilError(t, lsOpts{quiet: true, format: "pretty"}.validate())
+	assert.NilError(t, lsOpts{format: "json"}.validate())
+	assert.Error(t, lsOpts{quiet: true, format: "JSON"}.validate(), `cannot combine "quiet" and "json" options`)
+	assert.Error(t, lsOpts{quiet: true, format: "{{ json . }}"}.validate(), `cannot combine "quiet" and "json" options`)
+}
+
+func TestViewFromContextList(t *testing.T) {
+	contexts := []*store.DockerContext{
+		{
+			Name: "default",
+			Metadata: store.ContextMetadata{
+				Type:        "moby",
+				Description: "default context",
+			},
+			Endpoints: map[string]interface{}{
+				"docker": &store.Endpoint{Host: "unix:///var/run/docker.sock"},
+			},
+		},
+		{
+			Name: "aci",
+			Metadata: store.ContextMetadata{
+				Type: "aci",
+			},
+		},
+	}
+	view := viewFromContextList(contexts, "aci")
+	assert.DeepEqual(t, view, []contextView{
+		{
+			Name:           "default",
+			ContextType:    "moby",
+			Description:    "default context",
+			DockerEndpoint: "unix:///var/run/docker.sock",
+		},
+		{
+			Current:     true,
+			Name:        "aci",
+			ContextType: "aci",
+		},
+	})
+}
+
+func TestPingStatus(t *testing.T) {
+	assert.Equal(t, pingStatus(nil), "OK")
+	assert.Equal(t, pingStatus(errors.Wrap(errdefs.ErrLoginRequired, "token expired")), "auth expired")
+	assert.Equal(t, pingStatus(errors.New("connection refused")), "unreachable")
+	assert.Equal(t, pingStatus(errdefs.ErrNotImplemented), "")
+}
@@ -0,0 +1,113 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package context
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/backend"
+	apicontext "github.com/docker/compose-cli/context"
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+const (
+	statusOK          = "OK"
+	statusAuthExpired = "auth expired"
+	statusUnreachable = "unreachable"
+
+	pingTimeout = 5 * time.Second
+)
+
+// pingContexts sets the reachability status of the contexts, pinging their backends concurrently
+func pingContexts(ctx context.Context, contexts []*store.DockerContext, view []contextView) {
+	var wg sync.WaitGroup
+	for i, c := range contexts {
+		wg.Add(1)
+		go func(i int, c *store.DockerContext) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+			defer cancel()
+			view[i].Status = pingStatus(pingContext(ctx, c))
+		}(i, c)
+	}
+	wg.Wait()
+}
+
+func pingContext(ctx context.Context, c *store.DockerContext) error {
+	if c.Type() == store.DefaultContextType {
+		return pingDockerEndpoint(ctx, c)
+	}
+	service, err := backend.Get(apicontext.WithCurrentContext(ctx, c.Name), c.Type())
+	if err != nil {
+		return err
+	}
+	return service.Ping(ctx)
+}
+
+func pingDockerEndpoint(ctx context.Context, c *store.DockerContext) error {
+	endpoint, ok := c.Endpoints["docker"].(*store.Endpoint)
+	if !ok {
+		return errdefs.ErrNotFound
+	}
+	opts := []client.Opt{client.WithHost(endpoint.Host), client.WithAPIVersionNegotiation()}
+	helper, err := connhelper.GetConnectionHelper(endpoint.Host)
+	if err != nil {
+		return err
+	}
+	if helper != nil {
+		opts = []client.Opt{
+			client.WithHost(helper.Host),
+			client.WithHTTPClient(&http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+						return helper.Dialer(ctx, network, addr)
+					},
+				},
+			}),
+			client.WithAPIVersionNegotiation(),
+		}
+	}
+	apiClient, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return err
+	}
+	defer apiClient.Close() //nolint:errcheck
+	_, err = apiClient.Ping(ctx)
+	return err
+}
+
+func pingStatus(err error) string {
+	switch {
+	case err == nil:
+		return statusOK
+	case errdefs.IsErrNotImplemented(err):
+		return ""
+	case errors.Is(err, errdefs.ErrLoginRequired):
+		return statusAuthExpired
+	default:
+		return statusUnreachable
+	}
+}
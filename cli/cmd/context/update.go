@@ -17,6 +17,7 @@
 package context
 
 import (
+	"github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -49,7 +50,22 @@ namespace-override   Overrides the namespace set in the kubernetes config file
 
 Example:
 
-$ docker context update my-context --description "some description" --docker "host=tcp://myserver:2376,ca=~/ca-file,cert=~/cert-file,key=~/key-file"`
+$ docker context update my-context --description "some description" --docker "host=tcp://myserver:2376,ca=~/ca-file,cert=~/cert-file,key=~/key-file"
+
+Quotas, validated by compose up, can be set on contexts of any type:
+
+$ docker context update my-aci-context --max-cpus 4 --max-memory 8g --max-monthly-cost 200
+
+The backend API calls of contexts of any type can be retried on network errors, the global --timeout flag
+overrides the timeout for a single command:
+
+$ docker context update my-ecs-context --api-timeout 30s --api-retries 5 --api-retry-delay 1s
+
+Contexts shared with people who should only observe the deployments can be made read-only, only the ps, logs,
+inspect and stats commands are then allowed with the context. Making it writable again requires --force:
+
+$ docker context update my-production-context --read-only
+$ docker context update my-production-context --read-only=false --force`
 
 	cmd := &cobra.Command{
 		Use:   "update",
@@ -67,12 +83,70 @@ $ docker context update my-context --description "some description" --docker "ho
 		"Default orchestrator for stack operations to use with this context (swarm|kubernetes|all)")
 	flags.StringToString("docker", nil, "Set the docker endpoint")
 	flags.StringToString("kubernetes", nil, "Set the kubernetes endpoint")
+	flags.Float64("max-cpus", 0, "Maximum number of CPUs of the projects deployed with the context (0 for no limit)")
+	flags.String("max-memory", "", "Maximum memory of the projects deployed with the context, e.g. 8g (0 for no limit)")
+	flags.Float64("max-monthly-cost", 0, "Maximum estimated monthly cost in USD of the projects deployed with the context on a cloud backend (0 for no limit)")
+	flags.Duration("api-timeout", 0, "Timeout of each backend API call (0 for the backend default)")
+	flags.Int("api-retries", 0, "Number of times a backend API call failing on a network error is retried (0 for the backend default)")
+	flags.Duration("api-retry-delay", 0, "Delay before the first retry of a backend API call, doubled on every retry (0 for the backend default)")
+	flags.Bool("read-only", false, "Only allow the commands which don't change the resources of the context (ps, logs, inspect, stats)")
+	flags.Bool("force", false, "Allow removing the read-only restriction of a context")
 
 	return cmd
 }
 
 func runUpdate(cmd *cobra.Command, name string) error {
 	s := store.ContextStore(cmd.Context())
+	flags := cmd.Flags()
+	updateQuotas := flags.Changed("max-cpus") || flags.Changed("max-memory") || flags.Changed("max-monthly-cost")
+	updateRetryPolicy := flags.Changed("api-timeout") || flags.Changed("api-retries") || flags.Changed("api-retry-delay")
+	updateReadOnly := flags.Changed("read-only")
+	if updateQuotas || updateRetryPolicy || updateReadOnly {
+		dockerContext, err := s.Get(name)
+		if err != nil {
+			return err
+		}
+		// all the flags are validated before the context is changed
+		var (
+			quotas *store.Quotas
+			policy *store.RetryPolicy
+		)
+		if updateQuotas {
+			if quotas, err = quotasFromFlags(cmd, dockerContext); err != nil {
+				return err
+			}
+		}
+		if updateRetryPolicy {
+			if policy, err = retryPolicyFromFlags(cmd, dockerContext); err != nil {
+				return err
+			}
+		}
+		readOnly, err := flags.GetBool("read-only")
+		if err != nil {
+			return err
+		}
+		if updateReadOnly && !readOnly && dockerContext.Metadata.ReadOnly {
+			if force, _ := flags.GetBool("force"); !force {
+				return errors.Wrapf(errdefs.ErrForbidden, "context %q is read-only, use --force to make it writable again", name)
+			}
+		}
+		if updateQuotas {
+			if err := s.SetQuotas(name, quotas); err != nil {
+				return err
+			}
+		}
+		if updateRetryPolicy {
+			if err := s.SetRetryPolicy(name, policy); err != nil {
+				return err
+			}
+		}
+		if updateReadOnly {
+			if err := s.SetReadOnly(name, readOnly); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	dockerContext, err := s.Get(name)
 	if err == nil && dockerContext != nil {
 		if dockerContext.Type() != store.DefaultContextType {
@@ -83,3 +157,73 @@ func runUpdate(cmd *cobra.Command, name string) error {
 	mobycli.Exec(cmd.Root())
 	return nil
 }
+
+// quotasFromFlags returns the quotas of a context updated with the flags, the quotas which flags are not set
+// are kept. It returns nil when the context has no quota left.
+func quotasFromFlags(cmd *cobra.Command, dockerContext *store.DockerContext) (*store.Quotas, error) {
+	var err error
+	quotas := store.Quotas{}
+	if dockerContext.Metadata.Quotas != nil {
+		quotas = *dockerContext.Metadata.Quotas
+	}
+	flags := cmd.Flags()
+	if flags.Changed("max-cpus") {
+		if quotas.MaxCPUs, err = flags.GetFloat64("max-cpus"); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Changed("max-memory") {
+		value, _ := flags.GetString("max-memory")
+		quotas.MaxMemory = 0
+		if value != "0" && value != "" {
+			if quotas.MaxMemory, err = units.RAMInBytes(value); err != nil {
+				return nil, errors.Wrapf(err, "invalid --max-memory %q", value)
+			}
+		}
+	}
+	if flags.Changed("max-monthly-cost") {
+		if quotas.MaxMonthlyCost, err = flags.GetFloat64("max-monthly-cost"); err != nil {
+			return nil, err
+		}
+	}
+	if quotas.MaxCPUs < 0 || quotas.MaxMemory < 0 || quotas.MaxMonthlyCost < 0 {
+		return nil, errors.New("quotas can't be negative")
+	}
+	if quotas == (store.Quotas{}) {
+		return nil, nil
+	}
+	return &quotas, nil
+}
+
+// retryPolicyFromFlags returns the API retry policy of a context updated with the flags, the settings which
+// flags are not set are kept. It returns nil when the policy has no setting left.
+func retryPolicyFromFlags(cmd *cobra.Command, dockerContext *store.DockerContext) (*store.RetryPolicy, error) {
+	var err error
+	policy := store.RetryPolicy{}
+	if dockerContext.Metadata.Retry != nil {
+		policy = *dockerContext.Metadata.Retry
+	}
+	flags := cmd.Flags()
+	if flags.Changed("api-timeout") {
+		if policy.Timeout, err = flags.GetDuration("api-timeout"); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Changed("api-retries") {
+		if policy.MaxRetries, err = flags.GetInt("api-retries"); err != nil {
+			return nil, err
+		}
+	}
+	if flags.Changed("api-retry-delay") {
+		if policy.RetryDelay, err = flags.GetDuration("api-retry-delay"); err != nil {
+			return nil, err
+		}
+	}
+	if policy.Timeout < 0 || policy.MaxRetries < 0 || policy.RetryDelay < 0 {
+		return nil, errors.New("API retry settings can't be negative")
+	}
+	if policy == (store.RetryPolicy{}) {
+		return nil, nil
+	}
+	return &policy, nil
+}
@@ -19,10 +19,12 @@ package context
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/docker/compose-cli/config"
+	apicontext "github.com/docker/compose-cli/context"
 	"github.com/docker/compose-cli/context/store"
 )
 
@@ -49,5 +51,8 @@ func runUse(ctx context.Context, name string) error {
 		return err
 	}
 	fmt.Println(name)
+	if os.Getenv(apicontext.EnvVar) != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s environment variable overrides the active context. To use %q, either set the global --context flag, or unset %s environment variable.\n", apicontext.EnvVar, name, apicontext.EnvVar)
+	}
 	return nil
 }
@@ -0,0 +1,61 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// DiffCommand inspects changes to a container filesystem
+func DiffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff CONTAINER",
+		Short: "Inspect changes to files or directories on a container's filesystem",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDiff(cmd.Context(), args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runDiff(ctx context.Context, id string) error {
+	c, err := client.New(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to backend")
+	}
+
+	changes, err := c.ContainerService().Diff(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFoundError(err) {
+			return fmt.Errorf("container %s not found", id)
+		}
+		return err
+	}
+	for _, change := range changes {
+		fmt.Printf("%s %s\n", change.Kind, change.Path)
+	}
+	return nil
+}
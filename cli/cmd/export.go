@@ -0,0 +1,73 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// ExportCommand exports a container filesystem as a tar archive
+func ExportCommand() *cobra.Command {
+	var output string
+	cmd := &cobra.Command{
+		Use:   "export [OPTIONS] CONTAINER",
+		Short: "Export a container's filesystem as a tar archive",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(cmd.Context(), args[0], output)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to a file, instead of STDOUT")
+
+	return cmd
+}
+
+func runExport(ctx context.Context, id string, output string) error {
+	var w io.Writer = os.Stdout
+	if output == "" {
+		if _, isTerminal := term.GetFdInfo(os.Stdout); isTerminal {
+			return errors.New("cowardly refusing to save to a terminal. Use the -o flag or redirect")
+		}
+	} else {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint:errcheck
+		w = f
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to backend")
+	}
+	err = c.ContainerService().Export(ctx, id, w)
+	if errdefs.IsNotFoundError(err) {
+		return fmt.Errorf("container %s not found", id)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/errdefs"
+	_ "github.com/docker/compose-cli/example"
+	"github.com/docker/compose-cli/tests/framework"
+)
+
+func TestExportNotImplemented(t *testing.T) {
+	c := framework.NewTestCLI(t)
+	err := runExport(c.Context(), "id", filepath.Join(t.TempDir(), "id.tar"))
+	assert.Assert(t, errdefs.IsErrNotImplemented(err))
+}
+
+func TestImportMissingFile(t *testing.T) {
+	c := framework.NewTestCLI(t)
+	err := runImport(c.Context(), importOpts{}, []string{filepath.Join(t.TempDir(), "missing.tar")})
+	assert.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestImportNotImplemented(t *testing.T) {
+	c := framework.NewTestCLI(t)
+	err := runImport(c.Context(), importOpts{}, []string{"https://example.com/rootfs.tar", "myimage"})
+	assert.Assert(t, errdefs.IsErrNotImplemented(err))
+}
@@ -0,0 +1,86 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/api/client"
+	"github.com/docker/compose-cli/api/containers"
+)
+
+type importOpts struct {
+	message string
+	changes []string
+}
+
+// ImportCommand creates an image from a filesystem tar archive
+func ImportCommand() *cobra.Command {
+	var opts importOpts
+	cmd := &cobra.Command{
+		Use:   "import [OPTIONS] file|URL|- [REPOSITORY[:TAG]]",
+		Short: "Import the contents from a tarball to create a filesystem image",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(cmd.Context(), opts, args)
+		},
+	}
+	cmd.Flags().StringVarP(&opts.message, "message", "m", "", "Set commit message for imported image")
+	cmd.Flags().StringArrayVarP(&opts.changes, "change", "c", []string{}, "Apply Dockerfile instruction to the created image")
+
+	return cmd
+}
+
+func runImport(ctx context.Context, opts importOpts, args []string) error {
+	request := containers.ImportRequest{
+		Message: opts.message,
+		Changes: opts.changes,
+	}
+	if len(args) == 2 {
+		request.Reference = args[1]
+	}
+	switch source := args[0]; {
+	case source == "-":
+		request.Source = os.Stdin
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		request.SourceURL = source
+	default:
+		f, err := os.Open(source)
+		if err != nil {
+			return err
+		}
+		defer f.Close() // nolint:errcheck
+		request.Source = f
+	}
+
+	c, err := client.New(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to backend")
+	}
+	id, err := c.ContainerService().Import(ctx, request)
+	if err != nil {
+		return err
+	}
+	fmt.Println(id)
+	return nil
+}
@@ -32,6 +32,8 @@ import (
 type logsOpts struct {
 	Follow bool
 	Tail   string
+	Since  string
+	Until  string
 }
 
 // LogsCommand fetches and shows logs of a container
@@ -48,6 +50,8 @@ func LogsCommand() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&opts.Follow, "follow", "f", false, "Follow log outut")
 	cmd.Flags().StringVar(&opts.Tail, "tail", "all", "Number of lines to show from the end of the logs")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Show logs since timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
+	cmd.Flags().StringVar(&opts.Until, "until", "", "Show logs before a timestamp (e.g. 2013-01-02T13:23:37) or relative (e.g. 42m for 42 minutes)")
 
 	return cmd
 }
@@ -61,6 +65,8 @@ func runLogs(ctx context.Context, containerName string, opts logsOpts) error {
 	req := containers.LogsRequest{
 		Follow: opts.Follow,
 		Tail:   opts.Tail,
+		Since:  opts.Since,
+		Until:  opts.Until,
 	}
 
 	var con io.Writer = os.Stdout
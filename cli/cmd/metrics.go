@@ -0,0 +1,71 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/docker/compose-cli/config"
+	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/metrics"
+)
+
+// MetricsCommand shows the metrics recorded locally, a developer aid to diagnose slow backends
+func MetricsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "metrics",
+		Short:  "Show locally recorded command metrics",
+		Hidden: true,
+	}
+	cmd.AddCommand(metricsSummaryCommand())
+	return cmd
+}
+
+func metricsSummaryCommand() *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "summary",
+		Short: "Show execution count and duration of commands per context type",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			summaries, err := metrics.LoadSummary(config.Dir(cmd.Context()))
+			if err != nil {
+				return err
+			}
+			if summaries == nil {
+				summaries = []metrics.Summary{}
+			}
+			return formatter.Print(summaries, format, os.Stdout, func(w io.Writer) {
+				for _, s := range summaries {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\n", s.Context, s.Command, s.Count, s.Failures,
+						formatDuration(s.AverageDuration()), formatDuration(s.MaxDuration))
+				}
+			}, "CONTEXT TYPE", "COMMAND", "COUNT", "FAILURES", "AVG DURATION", "MAX DURATION")
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "", formatter.FormatUsage)
+	return cmd
+}
+
+func formatDuration(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).String()
+}
@@ -149,7 +149,7 @@ func listSecrets() *cobra.Command {
 			}, "ID", "NAME")
 		},
 	}
-	cmd.Flags().StringVar(&opts.format, "format", "", "Format the output. Values: [pretty | json]. (Default: pretty)")
+	cmd.Flags().StringVar(&opts.format, "format", "", formatter.FormatUsage)
 	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Only display IDs")
 	return cmd
 }
@@ -62,7 +62,7 @@ func listVolume() *cobra.Command {
 			}, "ID", "DESCRIPTION")
 		},
 	}
-	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, "Format the output. Values: [pretty | json]. (Default: pretty)")
+	cmd.Flags().StringVar(&opts.format, "format", formatter.PRETTY, formatter.FormatUsage)
 	cmd.Flags().BoolVarP(&opts.quiet, "quiet", "q", false, "Only display IDs")
 	return cmd
 }
@@ -24,6 +24,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	"github.com/docker/compose-cli/cli/cmd"
 	"github.com/docker/compose-cli/cli/cmd/compose"
@@ -45,13 +47,16 @@ import (
 	apicontext "github.com/docker/compose-cli/context"
 	"github.com/docker/compose-cli/context/store"
 	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/formatter"
 	"github.com/docker/compose-cli/metrics"
 
 	// Backend registrations
 	_ "github.com/docker/compose-cli/aci"
+	_ "github.com/docker/compose-cli/api/secrets/vault"
 	_ "github.com/docker/compose-cli/ecs"
 	_ "github.com/docker/compose-cli/ecs/local"
 	_ "github.com/docker/compose-cli/example"
+	_ "github.com/docker/compose-cli/kube"
 	_ "github.com/docker/compose-cli/local"
 )
 
@@ -61,9 +66,31 @@ var (
 		"context": {},
 		"login":   {},
 		"logout":  {},
+		"metrics": {},
 		"serve":   {},
 		"version": {},
 	}
+	// readOnlyCommands are the commands allowed with read-only contexts, besides the context agnostic ones
+	readOnlyCommands = map[string]struct{}{
+		"ps":              {},
+		"logs":            {},
+		"inspect":         {},
+		"stats":           {},
+		"wait":            {},
+		"diff":            {},
+		"help":            {},
+		"compose":         {},
+		"compose ps":      {},
+		"compose ls":      {},
+		"compose logs":    {},
+		"compose inspect": {},
+		"compose stats":   {},
+		"compose convert": {},
+		"compose config":  {},
+		"compose env":     {},
+		"compose wait":    {},
+		"compose version": {},
+	}
 	unknownCommandRegexp = regexp.MustCompile(`unknown command "([^"]*)"`)
 )
 
@@ -99,7 +126,18 @@ func isContextAgnosticCommand(cmd *cobra.Command) bool {
 	return isContextAgnosticCommand(cmd.Parent())
 }
 
+// isStandaloneCompose returns true when the binary is invoked as `docker-compose`
+func isStandaloneCompose(binary string) bool {
+	name := strings.TrimSuffix(filepath.Base(binary), ".exe")
+	return name == "docker-compose"
+}
+
 func main() {
+	if isStandaloneCompose(os.Args[0]) {
+		standaloneCompose()
+		return
+	}
+
 	var opts cliopts.GlobalOpts
 	root := &cobra.Command{
 		Use:           "docker",
@@ -132,6 +170,13 @@ func main() {
 		cmd.KillCommand(),
 		cmd.SecretCommand(),
 		cmd.PruneCommand(),
+		cmd.MetricsCommand(),
+		cmd.CheckpointCommand(),
+		cmd.CommitCommand(),
+		cmd.WaitCommand(),
+		cmd.DiffCommand(),
+		cmd.ExportCommand(),
+		cmd.ImportCommand(),
 
 		// Place holders
 		cmd.EcsCommand(),
@@ -149,6 +194,8 @@ func main() {
 	root.PersistentFlags().StringVarP(&opts.Host, "host", "H", "", "Daemon socket(s) to connect to")
 	opts.AddConfigFlags(root.PersistentFlags())
 	opts.AddContextFlags(root.PersistentFlags())
+	opts.AddTimeoutFlag(root.PersistentFlags())
+	opts.AddErrorFormatFlag(root.PersistentFlags())
 	root.Flags().BoolVarP(&opts.Version, "version", "v", false, "Print version information and quit")
 
 	walk(root, func(c *cobra.Command) {
@@ -160,6 +207,9 @@ func main() {
 	if opts.Debug {
 		logrus.SetLevel(logrus.DebugLevel)
 	}
+	if err := opts.CheckErrorFormat(); err != nil {
+		fatal(err)
+	}
 
 	ctx, cancel := newSigContext()
 	defer cancel()
@@ -174,6 +224,7 @@ func main() {
 	}
 	configDir := opts.Config
 	ctx = config.WithDir(ctx, configDir)
+	metrics.EnableSummary(configDir)
 
 	currentContext := determineCurrentContext(opts.Context, configDir)
 
@@ -196,6 +247,11 @@ func main() {
 
 	ctx = apicontext.WithCurrentContext(ctx, currentContext)
 	ctx = store.WithContextStore(ctx, s)
+	ctx = store.WithRetryPolicy(ctx, retryPolicy(cc, opts.Timeout))
+
+	if err := checkReadOnly(root, cc, os.Args[1:]); err != nil {
+		exit(root, currentContext, err, ctype)
+	}
 
 	if err = root.ExecuteContext(ctx); err != nil {
 		// if user canceled request, simply exit without any error message
@@ -204,39 +260,198 @@ func main() {
 			os.Exit(130)
 		}
 		if ctype == store.AwsContextType {
-			exit(currentContext, errors.Errorf(`%q context type has been renamed. Recreate the context by running:
+			exit(root, currentContext, errors.Errorf(`%q context type has been renamed. Recreate the context by running:
 $ docker context create %s <name>`, cc.Type(), store.EcsContextType), ctype)
 		}
 
 		// Context should always be handled by new CLI
 		requiredCmd, _, _ := root.Find(os.Args[1:])
 		if requiredCmd != nil && isContextAgnosticCommand(requiredCmd) {
-			exit(currentContext, err, ctype)
+			exit(root, currentContext, err, ctype)
 		}
 		mobycli.ExecIfDefaultCtxType(ctx, root)
 
 		checkIfUnknownCommandExistInDefaultContext(err, currentContext, ctype)
 
-		exit(currentContext, err, ctype)
+		exit(root, currentContext, err, ctype)
 	}
 	metrics.Track(ctype, os.Args[1:], metrics.SuccessStatus)
 }
 
-func exit(ctx string, err error, ctype string) {
-	metrics.Track(ctype, os.Args[1:], metrics.FailureStatus)
+// retryPolicy returns the retry policy of the backend API calls of the current context, the --timeout flag
+// overriding its timeout
+func retryPolicy(cc *store.DockerContext, timeout time.Duration) store.RetryPolicy {
+	var policy store.RetryPolicy
+	if cc != nil && cc.Metadata.Retry != nil {
+		policy = *cc.Metadata.Retry
+	}
+	if timeout > 0 {
+		policy.Timeout = timeout
+	}
+	return policy
+}
+
+// checkReadOnly rejects the commands changing resources when the current context is read-only
+func checkReadOnly(root *cobra.Command, cc *store.DockerContext, args []string) error {
+	if cc == nil || !cc.Metadata.ReadOnly {
+		return nil
+	}
+	root.InitDefaultHelpCmd()
+	cmd, _, err := root.Find(args)
+	if err == nil && isReadOnlyCommand(cmd) {
+		return nil
+	}
+	return errors.Wrapf(errdefs.ErrForbidden, "context %q is read-only, only the %s commands are allowed", cc.Name, strings.Join(readOnlyCommandNames(), ", "))
+}
+
+// readOnlyCommandNames returns the sorted commands allowed with read-only contexts
+func readOnlyCommandNames() []string {
+	names := make([]string, 0, len(readOnlyCommands))
+	for name := range readOnlyCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isReadOnlyCommand returns true for the commands which don't change the resources of the current context
+func isReadOnlyCommand(cmd *cobra.Command) bool {
+	if !cmd.HasParent() {
+		return true
+	}
+	names := []string{}
+	for c := cmd; c.HasParent(); c = c.Parent() {
+		names = append([]string{c.Name()}, names...)
+	}
+	if cmd.Root().Name() == "docker-compose" {
+		names = append([]string{"compose"}, names...)
+	}
+	if names[0] != "compose" && isContextAgnosticCommand(cmd) {
+		return names[0] != "serve"
+	}
+	_, ok := readOnlyCommands[strings.Join(names, " ")]
+	return ok
+}
 
-	if errors.Is(err, errdefs.ErrLoginRequired) {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(errdefs.ExitCodeLoginRequired)
+// standaloneCompose runs compose as the root command, with docker-compose compatible argument parsing
+func standaloneCompose() {
+	var opts cliopts.GlobalOpts
+	globalFlags := func(flags *pflag.FlagSet) {
+		flags.BoolVarP(&opts.Debug, "debug", "D", false, "Enable debug output in the logs")
+		opts.AddConfigFlags(flags)
+		opts.AddContextFlags(flags)
+		opts.AddTimeoutFlag(flags)
+		opts.AddErrorFormatFlag(flags)
+	}
+
+	// populate the opts with the global flags
+	flags := pflag.NewFlagSet("docker-compose", pflag.ContinueOnError)
+	flags.ParseErrorsWhitelist.UnknownFlags = true
+	flags.Usage = func() {}
+	globalFlags(flags)
+	_ = flags.Parse(os.Args[1:])
+	if opts.Debug {
+		logrus.SetLevel(logrus.DebugLevel)
 	}
+	if err := opts.CheckErrorFormat(); err != nil {
+		fatal(err)
+	}
+
+	ctx, cancel := newSigContext()
+	defer cancel()
+
+	if opts.Config == "" {
+		fatal(errors.New("config path cannot be empty"))
+	}
+	configDir := opts.Config
+	ctx = config.WithDir(ctx, configDir)
+	metrics.EnableSummary(configDir)
+
+	currentContext := determineCurrentContext(opts.Context, configDir)
+
+	s, err := store.New(configDir)
+	if err != nil {
+		fatal(err)
+	}
+
+	ctype := store.DefaultContextType
+	cc, _ := s.Get(currentContext)
+	if cc != nil {
+		ctype = cc.Type()
+	}
+
+	root := compose.Command(ctype)
+	root.Use = "docker-compose"
+	root.SilenceErrors = true
+	root.SilenceUsage = true
+	globalFlags(root.PersistentFlags())
+	walk(root, func(c *cobra.Command) {
+		c.Flags().BoolP("help", "h", false, "Help for "+c.Name())
+	})
+
+	ctx = apicontext.WithCurrentContext(ctx, currentContext)
+	ctx = store.WithContextStore(ctx, s)
+	ctx = store.WithRetryPolicy(ctx, retryPolicy(cc, opts.Timeout))
+
+	if err := checkReadOnly(root, cc, os.Args[1:]); err != nil {
+		exit(root, currentContext, err, ctype)
+	}
+
+	args := append([]string{"compose"}, os.Args[1:]...)
+	if err = root.ExecuteContext(ctx); err != nil {
+		if errdefs.IsErrCanceled(err) || errors.Is(ctx.Err(), context.Canceled) {
+			metrics.Track(ctype, args, metrics.CanceledStatus)
+			os.Exit(130)
+		}
+		exit(root, currentContext, err, ctype)
+	}
+	metrics.Track(ctype, args, metrics.SuccessStatus)
+}
+
+func exit(root *cobra.Command, ctx string, err error, ctype string) {
+	metrics.Track(ctype, os.Args[1:], metrics.FailureStatus)
+
+	message := err.Error()
 	if errors.Is(err, errdefs.ErrNotImplemented) {
 		name := metrics.GetCommand(os.Args[1:])
-		fmt.Fprintf(os.Stderr, "Command %q not available in current context (%s)\n", name, ctx)
+		message = fmt.Sprintf("Command %q not available in current context (%s)", name, ctx)
+	}
 
-		os.Exit(1)
+	if errorFormat(root) == formatter.JSON {
+		view, jsonErr := formatter.ToStandardJSON(errorView{
+			Code:     errdefs.Code(err),
+			Message:  message,
+			ExitCode: errdefs.ExitCode(err),
+		})
+		if jsonErr == nil {
+			message = strings.TrimSpace(view)
+		}
 	}
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(errdefs.ExitCode(err))
+}
+
+// errorView is the machine-readable error output, used when the failed command was invoked with `--format json`
+type errorView struct {
+	Code     string
+	Message  string
+	ExitCode int
+}
 
-	fatal(err)
+// errorFormat returns the format of errors set globally, or the output format requested for the invoked command
+func errorFormat(root *cobra.Command) string {
+	if flag := root.PersistentFlags().Lookup("error-format"); flag != nil && flag.Value.String() != "" {
+		return strings.ToLower(strings.TrimSpace(flag.Value.String()))
+	}
+	cmd, _, err := root.Find(os.Args[1:])
+	if err != nil || cmd == nil {
+		return ""
+	}
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(flag.Value.String()))
 }
 
 func fatal(err error) {
@@ -268,8 +483,14 @@ func newSigContext() (context.Context, func()) {
 	return ctx, cancel
 }
 
+// determineCurrentContext resolves the context to use, by order of precedence:
+// the --context flag, the DOCKER_CONTEXT env var, the current context from the
+// config file and finally the default context
 func determineCurrentContext(flag string, configDir string) string {
 	res := flag
+	if res == "" {
+		res = os.Getenv(apicontext.EnvVar)
+	}
 	if res == "" {
 		config, err := config.LoadFile(configDir)
 		if err != nil {
@@ -21,14 +21,20 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"gotest.tools/v3/assert"
 
 	"github.com/docker/compose-cli/cli/cmd"
+	"github.com/docker/compose-cli/cli/cmd/compose"
 	"github.com/docker/compose-cli/cli/cmd/context"
 	"github.com/docker/compose-cli/cli/cmd/login"
 	"github.com/docker/compose-cli/cli/cmd/run"
+	cliopts "github.com/docker/compose-cli/cli/options"
 	"github.com/docker/compose-cli/config"
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
 )
 
 var contextSetConfig = []byte(`{
@@ -58,6 +64,14 @@ func TestDetermineCurrentContext(t *testing.T) {
 	// Ensure context flag overrides config
 	c = determineCurrentContext("other-context", d)
 	assert.Equal(t, "other-context", c)
+
+	// Ensure DOCKER_CONTEXT overrides config, but not the context flag
+	os.Setenv("DOCKER_CONTEXT", "env-context") // nolint:errcheck
+	defer os.Unsetenv("DOCKER_CONTEXT")        // nolint:errcheck
+	c = determineCurrentContext("", d)
+	assert.Equal(t, "env-context", c)
+	c = determineCurrentContext("other-context", d)
+	assert.Equal(t, "other-context", c)
 }
 
 func TestCheckOwnCommand(t *testing.T) {
@@ -74,3 +88,62 @@ func TestAppendPaths(t *testing.T) {
 	assert.Equal(t, appendPaths("", "/bin/path"), "/bin/path")
 	assert.Equal(t, appendPaths("path1", "binaryPath"), "path1"+string(os.PathListSeparator)+"binaryPath")
 }
+
+func TestIsStandaloneCompose(t *testing.T) {
+	assert.Assert(t, isStandaloneCompose("docker-compose"))
+	assert.Assert(t, isStandaloneCompose("/usr/local/bin/docker-compose"))
+	assert.Assert(t, isStandaloneCompose("docker-compose.exe"))
+	assert.Assert(t, !isStandaloneCompose("/usr/local/bin/docker"))
+	assert.Assert(t, !isStandaloneCompose("compose-cli"))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	assert.Equal(t, retryPolicy(nil, 0), store.RetryPolicy{})
+	assert.Equal(t, retryPolicy(nil, 10*time.Second), store.RetryPolicy{Timeout: 10 * time.Second})
+
+	cc := &store.DockerContext{
+		Metadata: store.ContextMetadata{
+			Retry: &store.RetryPolicy{Timeout: time.Minute, MaxRetries: 3},
+		},
+	}
+	assert.Equal(t, retryPolicy(cc, 0), store.RetryPolicy{Timeout: time.Minute, MaxRetries: 3})
+	assert.Equal(t, retryPolicy(cc, 5*time.Second), store.RetryPolicy{Timeout: 5 * time.Second, MaxRetries: 3})
+}
+
+func TestCheckReadOnly(t *testing.T) {
+	root := &cobra.Command{Use: "docker"}
+	root.AddCommand(context.Command(), cmd.PsCommand(), cmd.ServeCommand(), run.Command("default"), compose.Command("default"))
+
+	cc := &store.DockerContext{Name: "production"}
+	assert.NilError(t, checkReadOnly(root, cc, []string{"compose", "up"}))
+
+	cc.Metadata.ReadOnly = true
+	for _, args := range [][]string{{}, {"ps"}, {"compose", "logs", "web"}, {"compose", "ps", "-a"}, {"context", "use", "default"}, {"help"}, {"compose", "env"}, {"compose", "wait"}} {
+		assert.NilError(t, checkReadOnly(root, cc, args))
+	}
+	for _, args := range [][]string{{"run", "nginx"}, {"compose", "up"}, {"compose", "down"}, {"serve"}, {"build", "."}} {
+		err := checkReadOnly(root, cc, args)
+		assert.Assert(t, errdefs.IsForbiddenError(err), args)
+		assert.ErrorContains(t, err, `context "production" is read-only`)
+	}
+	err := checkReadOnly(root, cc, []string{"compose", "up"})
+	assert.ErrorContains(t, err, "only the compose, compose config, compose convert, compose env, compose inspect, compose logs, compose ls, "+
+		"compose ps, compose stats, compose version, compose wait, diff, help, inspect, logs, ps, stats, wait commands are allowed")
+
+	standalone := compose.Command("default")
+	standalone.Use = "docker-compose"
+	assert.NilError(t, checkReadOnly(standalone, cc, []string{"logs"}))
+	assert.Assert(t, errdefs.IsForbiddenError(checkReadOnly(standalone, cc, []string{"exec", "web", "sh"})))
+}
+
+func TestErrorFormat(t *testing.T) {
+	var opts cliopts.GlobalOpts
+	root := &cobra.Command{Use: "docker"}
+	opts.AddErrorFormatFlag(root.PersistentFlags())
+	assert.NilError(t, root.PersistentFlags().Parse([]string{"--error-format", "JSON"}))
+	assert.Equal(t, errorFormat(root), "json")
+	assert.NilError(t, opts.CheckErrorFormat())
+
+	opts.ErrorFormat = "yaml"
+	assert.Error(t, opts.CheckErrorFormat(), `unsupported error format "yaml", values are text and json`)
+}
@@ -59,6 +59,43 @@ func mustDelegateToMoby(ctxType string) bool {
 	return false
 }
 
+// cliOnlyFlags are the global flags of this CLI which the classic docker CLI doesn't know
+var cliOnlyFlags = map[string]bool{
+	"--error-format": true,
+}
+
+// classicValueFlags are the global flags of the classic docker CLI taking a value as a separate argument
+var classicValueFlags = map[string]bool{
+	"-c": true, "--context": true, "--config": true, "-H": true, "--host": true, "-l": true, "--log-level": true,
+	"--tlscacert": true, "--tlscert": true, "--tlskey": true,
+}
+
+// classicArgs removes the global flags only known by this CLI, before the command name, so they aren't
+// forwarded to the classic docker CLI
+func classicArgs(args []string) []string {
+	result := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "--" {
+			return append(result, args[i:]...)
+		}
+		name := strings.SplitN(arg, "=", 2)[0]
+		hasValue := strings.Contains(arg, "=")
+		if cliOnlyFlags[name] {
+			if !hasValue {
+				i++
+			}
+			continue
+		}
+		result = append(result, arg)
+		if classicValueFlags[name] && !hasValue && i+1 < len(args) {
+			i++
+			result = append(result, args[i])
+		}
+	}
+	return result
+}
+
 // Exec delegates to com.docker.cli if on moby context
 func Exec(root *cobra.Command) {
 	execBinary, err := resolvepath.LookPath(ComDockerCli)
@@ -66,7 +103,7 @@ func Exec(root *cobra.Command) {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	cmd := exec.Command(execBinary, os.Args[1:]...)
+	cmd := exec.Command(execBinary, classicArgs(os.Args[1:])...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
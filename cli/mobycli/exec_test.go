@@ -44,3 +44,10 @@ func TestDelegateContextTypeToMoby(t *testing.T) {
 		assert.Assert(t, !mustDelegateToMoby(ctx))
 	}
 }
+
+func TestClassicArgs(t *testing.T) {
+	assert.DeepEqual(t, classicArgs([]string{"--error-format", "json", "ps"}), []string{"ps"})
+	assert.DeepEqual(t, classicArgs([]string{"--context", "default", "--error-format=json", "ps", "-a"}), []string{"--context", "default", "ps", "-a"})
+	assert.DeepEqual(t, classicArgs([]string{"run", "alpine", "cmd", "--error-format", "json"}), []string{"run", "alpine", "cmd", "--error-format", "json"})
+	assert.DeepEqual(t, classicArgs([]string{"-H", "tcp://host", "--", "--error-format"}), []string{"-H", "tcp://host", "--", "--error-format"})
+}
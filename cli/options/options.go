@@ -17,6 +17,12 @@
 package options
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
 	cliconfig "github.com/docker/compose-cli/cli/config"
 	apicontext "github.com/docker/compose-cli/context"
 )
@@ -28,4 +34,27 @@ type GlobalOpts struct {
 	Debug   bool
 	Version bool
 	Host    string
+	Timeout time.Duration
+	// ErrorFormat is the format of the errors of failed commands, text or json
+	ErrorFormat string
+}
+
+// CheckErrorFormat validates the global error format
+func (o *GlobalOpts) CheckErrorFormat() error {
+	switch strings.ToLower(o.ErrorFormat) {
+	case "", "text", "json":
+		return nil
+	default:
+		return fmt.Errorf("unsupported error format %q, values are text and json", o.ErrorFormat)
+	}
+}
+
+// AddErrorFormatFlag adds the global flag formatting the errors of failed commands
+func (o *GlobalOpts) AddErrorFormatFlag(flags *pflag.FlagSet) {
+	flags.StringVar(&o.ErrorFormat, "error-format", "", "Format of the errors of failed commands. Values: [text | json] (default: json when the command is invoked with --format json)")
+}
+
+// AddTimeoutFlag adds the global flag bounding the backend API calls
+func (o *GlobalOpts) AddTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(&o.Timeout, "timeout", 0, "Timeout of each backend API call (e.g. 30s), overrides the retry policy of the context")
 }
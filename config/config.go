@@ -26,6 +26,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/utils"
 )
 
 type dirKey struct{}
@@ -74,7 +75,7 @@ func writeFile(path string, content map[string]interface{}) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to marshal config")
 	}
-	err = ioutil.WriteFile(path, d, 0644)
+	err = utils.AtomicWriteFile(path, d, 0644)
 	return errors.Wrap(err, "unable to write config file")
 }
 
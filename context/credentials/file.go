@@ -0,0 +1,162 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/utils"
+)
+
+const (
+	encryptedPrefix = "ENC1:"
+	saltSize        = 16
+	keySize         = 32
+)
+
+type fileStore struct {
+	dir        string
+	passphrase string
+}
+
+// NewFileStore returns a Store writing one file per key in dir. Secrets are
+// encrypted with AES-GCM using a key derived from passphrase when it is not
+// empty.
+func NewFileStore(dir string, passphrase string) Store {
+	return fileStore{
+		dir:        dir,
+		passphrase: passphrase,
+	}
+}
+
+func (s fileStore) path(key string) string {
+	return filepath.Join(s.dir, key)
+}
+
+func (s fileStore) Get(key string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.path(key))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(data, []byte(encryptedPrefix)) {
+		// secrets written before a passphrase was set are encrypted on first use
+		if s.passphrase != "" {
+			if err := s.Set(key, data); err != nil {
+				return nil, err
+			}
+		}
+		return data, nil
+	}
+	if s.passphrase == "" {
+		return nil, errors.Errorf("secret %q is encrypted, set %s to read it", key, PassphraseEnvVar)
+	}
+	return decrypt(s.passphrase, data[len(encryptedPrefix):])
+}
+
+func (s fileStore) Set(key string, data []byte) error {
+	if s.passphrase != "" {
+		encrypted, err := encrypt(s.passphrase, data)
+		if err != nil {
+			return err
+		}
+		data = append([]byte(encryptedPrefix), encrypted...)
+	}
+	return utils.AtomicWriteFile(s.path(key), data, 0600)
+}
+
+func (s fileStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	return err
+}
+
+func deriveKey(passphrase string, salt []byte) ([]byte, error) {
+	return scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, keySize)
+}
+
+func encrypt(passphrase string, data []byte) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	sealed := append(append(salt, nonce...), gcm.Seal(nil, nonce, data, nil)...)
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(sealed)))
+	base64.StdEncoding.Encode(encoded, sealed)
+	return encoded, nil
+}
+
+func decrypt(passphrase string, encoded []byte) ([]byte, error) {
+	sealed := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(sealed, bytes.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed encrypted secret")
+	}
+	sealed = sealed[:n]
+	if len(sealed) < saltSize {
+		return nil, errors.New("malformed encrypted secret")
+	}
+	gcm, err := newGCM(passphrase, sealed[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	sealed = sealed[saltSize:]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("malformed encrypted secret")
+	}
+	data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("could not decrypt secret, wrong passphrase?")
+	}
+	return data, nil
+}
+
+func newGCM(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
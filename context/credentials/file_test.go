@@ -0,0 +1,103 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestFileStorePlaintext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	store := NewFileStore(dir, "")
+	assert.NilError(t, store.Set("token", []byte("secret")))
+
+	data, err := store.Get("token")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "secret")
+
+	stat, err := os.Stat(filepath.Join(dir, "token"))
+	assert.NilError(t, err)
+	if os.PathSeparator == '/' {
+		assert.Equal(t, stat.Mode().Perm(), os.FileMode(0600))
+	}
+}
+
+func TestFileStoreEncrypted(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	store := NewFileStore(dir, "passphrase")
+	assert.NilError(t, store.Set("token", []byte("secret")))
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, "token"))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(string(raw), encryptedPrefix))
+	assert.Assert(t, !strings.Contains(string(raw), "secret"))
+
+	data, err := store.Get("token")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "secret")
+
+	_, err = NewFileStore(dir, "wrong").Get("token")
+	assert.Error(t, err, "could not decrypt secret, wrong passphrase?")
+
+	_, err = NewFileStore(dir, "").Get("token")
+	assert.Error(t, err, `secret "token" is encrypted, set `+PassphraseEnvVar+" to read it")
+}
+
+func TestFileStoreNotFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	store := NewFileStore(dir, "")
+	_, err = store.Get("missing")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+	assert.Assert(t, errdefs.IsNotFoundError(store.Delete("missing")))
+
+	assert.NilError(t, store.Set("token", []byte("secret")))
+	assert.NilError(t, store.Delete("token"))
+	_, err = store.Get("token")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestPlaintextStoreWarnsOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	plaintextWarning = sync.Once{}
+	var warnings bytes.Buffer
+	store := plaintextStore{Store: NewFileStore(dir, ""), dir: dir, warnings: &warnings}
+	assert.NilError(t, store.Set("token", []byte("secret")))
+	assert.NilError(t, store.Set("key", []byte("secret")))
+	assert.Equal(t, strings.Count(warnings.String(), "WARNING: cloud credentials are stored unencrypted in "+dir), 1)
+}
@@ -0,0 +1,70 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"github.com/docker/docker-credential-helpers/client"
+	helpers "github.com/docker/docker-credential-helpers/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+const (
+	helperPrefix    = "docker-credential-"
+	serverURLScheme = "docker-compose-cli://"
+	helperUsername  = "docker-compose-cli"
+)
+
+type helperStore struct {
+	program client.ProgramFunc
+}
+
+// NewHelperStore returns a Store backed by the docker credential helper
+// docker-credential-<helper>, which keeps secrets in the OS keychain
+func NewHelperStore(helper string) Store {
+	return helperStore{
+		program: client.NewShellProgramFunc(helperPrefix + helper),
+	}
+}
+
+func (s helperStore) Get(key string) ([]byte, error) {
+	creds, err := client.Get(s.program, serverURLScheme+key)
+	if helpers.IsErrCredentialsNotFound(err) {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(creds.Secret), nil
+}
+
+func (s helperStore) Set(key string, data []byte) error {
+	return client.Store(s.program, &helpers.Credentials{
+		ServerURL: serverURLScheme + key,
+		Username:  helperUsername,
+		Secret:    string(data),
+	})
+}
+
+func (s helperStore) Delete(key string) error {
+	err := client.Erase(s.program, serverURLScheme+key)
+	if helpers.IsErrCredentialsNotFound(err) {
+		return errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// migratingStore moves the secrets of a legacy store to the store it wraps the first time they are read
+type migratingStore struct {
+	Store
+	legacy Store
+}
+
+func (s migratingStore) Get(key string) ([]byte, error) {
+	data, err := s.Store.Get(key)
+	if !errdefs.IsNotFoundError(err) {
+		return data, err
+	}
+	data, legacyErr := s.legacy.Get(key)
+	if errdefs.IsNotFoundError(legacyErr) {
+		return nil, err
+	}
+	if legacyErr != nil {
+		return nil, legacyErr
+	}
+	if err := s.Store.Set(key, data); err != nil {
+		return nil, err
+	}
+	return data, s.legacy.Delete(key)
+}
+
+func (s migratingStore) Delete(key string) error {
+	err := s.Store.Delete(key)
+	if !errdefs.IsNotFoundError(err) {
+		return err
+	}
+	return s.legacy.Delete(key)
+}
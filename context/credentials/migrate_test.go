@@ -0,0 +1,93 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type mapStore map[string][]byte
+
+func (s mapStore) Get(key string) ([]byte, error) {
+	data, ok := s[key]
+	if !ok {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	return data, nil
+}
+
+func (s mapStore) Set(key string, data []byte) error {
+	s[key] = data
+	return nil
+}
+
+func (s mapStore) Delete(key string) error {
+	if _, ok := s[key]; !ok {
+		return errors.Wrapf(errdefs.ErrNotFound, "no secret stored for %q", key)
+	}
+	delete(s, key)
+	return nil
+}
+
+func TestMigratingStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	legacy := NewFileStore(dir, "")
+	assert.NilError(t, legacy.Set("token", []byte("secret")))
+
+	keychain := mapStore{}
+	store := migratingStore{Store: keychain, legacy: legacy}
+	data, err := store.Get("token")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "secret")
+	assert.Equal(t, string(keychain["token"]), "secret")
+	_, err = os.Stat(filepath.Join(dir, "token"))
+	assert.Assert(t, os.IsNotExist(err))
+
+	_, err = store.Get("missing")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+
+	assert.NilError(t, store.Delete("token"))
+	assert.Assert(t, errdefs.IsNotFoundError(store.Delete("token")))
+}
+
+func TestFileStoreEncryptsPlaintextSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	assert.NilError(t, NewFileStore(dir, "").Set("token", []byte("secret")))
+
+	data, err := NewFileStore(dir, "passphrase").Get("token")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "secret")
+
+	raw, err := ioutil.ReadFile(filepath.Join(dir, "token"))
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(string(raw), encryptedPrefix))
+}
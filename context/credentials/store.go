@@ -0,0 +1,111 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+const (
+	// HelperEnvVar names the docker credential helper (e.g. osxkeychain,
+	// wincred, secretservice) used to keep cloud secrets in the OS keychain,
+	// "file" stores them on disk even when the helper of the OS is installed
+	HelperEnvVar = "DOCKER_CLOUD_CREDENTIALS_HELPER"
+	// FileHelper disables the OS keychain when set as HelperEnvVar
+	FileHelper = "file"
+	// PassphraseEnvVar holds the passphrase used to encrypt cloud secrets
+	// written to disk when no credential helper is configured. Without it, they
+	// are written in plaintext and a warning is printed.
+	PassphraseEnvVar = "DOCKER_CLOUD_CREDENTIALS_PASSPHRASE"
+)
+
+// plaintextWarning makes sure the warning about secrets written in plaintext is only printed once
+var plaintextWarning sync.Once
+
+// Store keeps secrets of cloud contexts (tokens, keys) out of plaintext metadata
+type Store interface {
+	// Get returns the secret stored under key, or an errdefs.ErrNotFound error
+	Get(key string) ([]byte, error)
+	// Set stores the secret under key, replacing any previous value
+	Set(key string, data []byte) error
+	// Delete removes the secret stored under key, or returns an errdefs.ErrNotFound error
+	Delete(key string) error
+}
+
+// NewStore returns the secret store configured by the environment. The OS
+// keychain is used through the credential helper set in the environment, or the
+// helper of the OS when it is installed. Otherwise secrets are written to dir,
+// encrypted if a passphrase is set, in plaintext with a warning if not.
+// Secrets previously written to dir are moved to the keychain when read.
+func NewStore(dir string) Store {
+	passphrase := os.Getenv(PassphraseEnvVar)
+	files := NewFileStore(dir, passphrase)
+	helper := os.Getenv(HelperEnvVar)
+	if helper == "" {
+		helper = defaultHelper()
+	}
+	if helper == "" || helper == FileHelper {
+		if passphrase == "" {
+			return plaintextStore{Store: files, dir: dir, warnings: os.Stderr}
+		}
+		return files
+	}
+	return migratingStore{
+		Store:  NewHelperStore(helper),
+		legacy: files,
+	}
+}
+
+// defaultHelper returns the credential helper of the OS keychain when it is installed
+func defaultHelper() string {
+	var helper string
+	switch runtime.GOOS {
+	case "darwin":
+		helper = "osxkeychain"
+	case "windows":
+		helper = "wincred"
+	case "linux":
+		helper = "secretservice"
+	default:
+		return ""
+	}
+	if _, err := exec.LookPath(helperPrefix + helper); err != nil {
+		return ""
+	}
+	return helper
+}
+
+// plaintextStore warns that secrets are written to disk unencrypted
+type plaintextStore struct {
+	Store
+	dir      string
+	warnings io.Writer
+}
+
+func (s plaintextStore) Set(key string, data []byte) error {
+	plaintextWarning.Do(func() {
+		fmt.Fprintf(s.warnings, "WARNING: cloud credentials are stored unencrypted in %s\n"+
+			"Install a docker-credential helper to use the keychain of the OS, or set %s to encrypt them with a passphrase.\n",
+			s.dir, PassphraseEnvVar)
+	})
+	return s.Store.Set(key, data)
+}
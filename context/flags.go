@@ -17,11 +17,14 @@
 package context
 
 import (
-	"os"
-
 	"github.com/spf13/pflag"
 )
 
+// EnvVar is the environment variable selecting the context to use, it takes
+// precedence over the current context set with `docker context use` but is
+// overridden by the --context flag
+const EnvVar = "DOCKER_CONTEXT"
+
 // ContextFlags are the global CLI flags
 // nolint stutter
 type ContextFlags struct {
@@ -30,5 +33,5 @@ type ContextFlags struct {
 
 // AddContextFlags adds persistent (global) flags
 func (c *ContextFlags) AddContextFlags(flags *pflag.FlagSet) {
-	flags.StringVarP(&c.Context, "context", "c", os.Getenv("DOCKER_CONTEXT"), "context")
+	flags.StringVarP(&c.Context, "context", "c", "", `Name of the context to use to connect to the daemon (overrides `+EnvVar+` env var and default context set with "docker context use")`)
 }
@@ -16,7 +16,10 @@
 
 package store
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 // DockerContext represents the docker context metadata
 type DockerContext struct {
@@ -39,7 +42,34 @@ type ContextMetadata struct {
 	Type              string
 	Description       string
 	StackOrchestrator string
-	AdditionalFields  map[string]interface{}
+	// Quotas limits the resources of the projects deployed with the context
+	Quotas           *Quotas
+	AdditionalFields map[string]interface{}
+	// Retry controls how the backend API calls of the context are retried
+	Retry *RetryPolicy
+	// ReadOnly restricts the CLI to the commands which don't change the resources of the context
+	ReadOnly bool
+}
+
+// Quotas are the resource limits the projects deployed with a context are validated against, zero values are unlimited
+type Quotas struct {
+	// MaxCPUs is the maximum number of CPUs of a project
+	MaxCPUs float64 `json:",omitempty"`
+	// MaxMemory is the maximum memory of a project, in bytes
+	MaxMemory int64 `json:",omitempty"`
+	// MaxMonthlyCost is the maximum estimated monthly cost of a project deployed on a cloud backend, in USD
+	MaxMonthlyCost float64 `json:",omitempty"`
+}
+
+// RetryPolicy controls how the backend API calls (Docker engine, ARM, AWS) failing on network errors are
+// retried, zero values keep the backend defaults
+type RetryPolicy struct {
+	// Timeout bounds how long an API call waits for a response
+	Timeout time.Duration `json:",omitempty"`
+	// MaxRetries is the number of times a failing API call is retried
+	MaxRetries int `json:",omitempty"`
+	// RetryDelay is the delay before the first retry, doubled on every retry
+	RetryDelay time.Duration `json:",omitempty"`
 }
 
 // AciContext is the context for the ACI backend
@@ -53,6 +83,8 @@ type AciContext struct {
 type EcsContext struct {
 	CredentialsFromEnv bool   `json:",omitempty"`
 	Profile            string `json:",omitempty"`
+	// StoredCredentials is set when the access keys of the profile are kept in the credentials store
+	StoredCredentials bool `json:",omitempty"`
 }
 
 // AwsContext is the context for the ecs plugin
@@ -64,6 +96,12 @@ type LocalContext struct{}
 // ExampleContext is the context for the example backend
 type ExampleContext struct{}
 
+// KubeContext is the context for the Kubernetes backend
+type KubeContext struct {
+	KubeConfig string `json:",omitempty"`
+	Namespace  string `json:",omitempty"`
+}
+
 // MarshalJSON implements custom JSON marshalling
 func (dc ContextMetadata) MarshalJSON() ([]byte, error) {
 	s := map[string]interface{}{}
@@ -76,6 +114,15 @@ func (dc ContextMetadata) MarshalJSON() ([]byte, error) {
 	if dc.Type != "" {
 		s["Type"] = dc.Type
 	}
+	if dc.Quotas != nil {
+		s["Quotas"] = dc.Quotas
+	}
+	if dc.Retry != nil {
+		s["Retry"] = dc.Retry
+	}
+	if dc.ReadOnly {
+		s["ReadOnly"] = dc.ReadOnly
+	}
 	if dc.AdditionalFields != nil {
 		for k, v := range dc.AdditionalFields {
 			s[k] = v
@@ -98,6 +145,24 @@ func (dc *ContextMetadata) UnmarshalJSON(payload []byte) error {
 			dc.StackOrchestrator = v.(string)
 		case "Type":
 			dc.Type = v.(string)
+		case "Quotas":
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(b, &dc.Quotas); err != nil {
+				return err
+			}
+		case "Retry":
+			b, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(b, &dc.Retry); err != nil {
+				return err
+			}
+		case "ReadOnly":
+			dc.ReadOnly, _ = v.(bool)
 		default:
 			if dc.AdditionalFields == nil {
 				dc.AdditionalFields = make(map[string]interface{})
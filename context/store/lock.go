@@ -0,0 +1,35 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package store
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/docker/compose-cli/utils"
+)
+
+const (
+	lockFile    = ".lock"
+	lockTimeout = 10 * time.Second
+)
+
+// lock acquires an advisory lock on the context store, shared by all CLI processes using the same
+// config directory. The returned function releases the lock.
+func (s *store) lock() (func(), error) {
+	return utils.LockFile(filepath.Join(s.root, contextsDir, lockFile), lockTimeout)
+}
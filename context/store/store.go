@@ -29,6 +29,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/utils"
 )
 
 const (
@@ -58,6 +59,9 @@ const (
 	// ExampleContextType is the endpoint key in the context endpoints for an
 	// example backend
 	ExampleContextType = "example"
+	// KubeContextType is the endpoint key in the context endpoints for a
+	// Kubernetes backend
+	KubeContextType = "kube"
 )
 
 const (
@@ -80,6 +84,19 @@ func ContextStore(ctx context.Context) Store {
 	return s
 }
 
+type retryPolicyKey struct{}
+
+// WithRetryPolicy sets the retry policy of the backend API calls in the context
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// ContextRetryPolicy returns the retry policy of the backend API calls from the context
+func ContextRetryPolicy(ctx context.Context) RetryPolicy {
+	p, _ := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return p
+}
+
 // Store is the context store
 type Store interface {
 	// Get returns the context with name, it returns an error if the  context
@@ -97,6 +114,12 @@ type Store interface {
 	Remove(name string) error
 	// ContextExists checks if a context already exists
 	ContextExists(name string) bool
+	// SetQuotas sets the resource quotas of a context, nil quotas remove them
+	SetQuotas(name string, quotas *Quotas) error
+	// SetRetryPolicy sets the API retry policy of a context, a nil policy removes it
+	SetRetryPolicy(name string, policy *RetryPolicy) error
+	// SetReadOnly restricts, or not, the commands run with a context to the ones not changing its resources
+	SetReadOnly(name string, readOnly bool) error
 }
 
 // Endpoint holds the Docker or the Kubernetes endpoint, they both have the
@@ -224,13 +247,19 @@ func (s *store) ContextExists(name string) bool {
 }
 
 func (s *store) Create(name string, contextType string, description string, data interface{}) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if s.ContextExists(name) {
 		return errors.Wrap(errdefs.ErrAlreadyExists, objectName(name))
 	}
 	dir := contextDirOf(name)
 	metaDir := filepath.Join(s.root, contextsDir, metadataDir, dir)
 
-	err := os.Mkdir(metaDir, 0755)
+	err = os.Mkdir(metaDir, 0755)
 	if err != nil {
 		return err
 	}
@@ -252,7 +281,66 @@ func (s *store) Create(name string, contextType string, description string, data
 		return err
 	}
 
-	return ioutil.WriteFile(filepath.Join(metaDir, metaFile), bytes, 0644)
+	return utils.AtomicWriteFile(filepath.Join(metaDir, metaFile), bytes, 0644)
+}
+
+func (s *store) SetQuotas(name string, quotas *Quotas) error {
+	return s.updateMetadata(name, func(metadata *ContextMetadata) {
+		metadata.Quotas = quotas
+	})
+}
+
+func (s *store) SetRetryPolicy(name string, policy *RetryPolicy) error {
+	return s.updateMetadata(name, func(metadata *ContextMetadata) {
+		metadata.Retry = policy
+	})
+}
+
+func (s *store) SetReadOnly(name string, readOnly bool) error {
+	return s.updateMetadata(name, func(metadata *ContextMetadata) {
+		metadata.ReadOnly = readOnly
+	})
+}
+
+// updateMetadata changes the metadata of a context created by the CLI, the default context can't be changed
+func (s *store) updateMetadata(name string, update func(*ContextMetadata)) error {
+	if name == DefaultContextName {
+		return errors.Wrap(errdefs.ErrForbidden, objectName(name))
+	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	meta := filepath.Join(s.root, contextsDir, metadataDir, contextDirOf(name), metaFile)
+	if _, err := os.Stat(meta); os.IsNotExist(err) {
+		return errors.Wrap(errdefs.ErrNotFound, objectName(name))
+	}
+	bytes, err := ioutil.ReadFile(meta)
+	if err != nil {
+		return err
+	}
+	// endpoints are kept as they were written
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &raw); err != nil {
+		return err
+	}
+	var metadata ContextMetadata
+	if m, ok := raw["Metadata"]; ok {
+		if err := json.Unmarshal(m, &metadata); err != nil {
+			return err
+		}
+	}
+	update(&metadata)
+	if raw["Metadata"], err = json.Marshal(metadata); err != nil {
+		return err
+	}
+	bytes, err = json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return utils.AtomicWriteFile(meta, bytes, 0644)
 }
 
 func (s *store) List() ([]*DockerContext, error) {
@@ -267,6 +355,10 @@ func (s *store) List() ([]*DockerContext, error) {
 		if fi.IsDir() {
 			meta := filepath.Join(root, fi.Name(), metaFile)
 			r, err := read(meta)
+			if os.IsNotExist(err) {
+				// context is being created by a concurrent process
+				continue
+			}
 			if err != nil {
 				return nil, err
 			}
@@ -289,6 +381,12 @@ func (s *store) Remove(name string) error {
 	if name == DefaultContextName {
 		return errors.Wrap(errdefs.ErrForbidden, objectName(name))
 	}
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	dir := filepath.Join(s.root, contextsDir, metadataDir, contextDirOf(name))
 	// Check if directory exists because os.RemoveAll returns nil if it doesn't
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -334,5 +432,8 @@ func getters() map[string]func() interface{} {
 		ExampleContextType: func() interface{} {
 			return &ExampleContext{}
 		},
+		KubeContextType: func() interface{} {
+			return &KubeContext{}
+		},
 	}
 }
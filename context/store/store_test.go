@@ -18,9 +18,11 @@ package store
 
 import (
 	_ "crypto/sha256"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/assert/cmp"
@@ -69,6 +71,84 @@ func TestGetEndpoint(t *testing.T) {
 	assert.Error(t, err, "wrong context type")
 }
 
+func TestSetQuotas(t *testing.T) {
+	s := testStore(t)
+	err := s.Create("aci", "aci", "description", AciContext{
+		Location: "eu",
+	})
+	assert.NilError(t, err)
+
+	err = s.SetQuotas("aci", &Quotas{MaxCPUs: 4, MaxMonthlyCost: 100})
+	assert.NilError(t, err)
+	c, err := s.Get("aci")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, c.Metadata.Quotas, &Quotas{MaxCPUs: 4, MaxMonthlyCost: 100})
+	assert.Equal(t, c.Metadata.Description, "description")
+	var ctx AciContext
+	err = s.GetEndpoint("aci", &ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, ctx.Location, "eu")
+
+	err = s.SetQuotas("aci", nil)
+	assert.NilError(t, err)
+	c, err = s.Get("aci")
+	assert.NilError(t, err)
+	assert.Assert(t, cmp.Nil(c.Metadata.Quotas))
+
+	err = s.SetQuotas("unknown", &Quotas{MaxCPUs: 1})
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestSetRetryPolicy(t *testing.T) {
+	s := testStore(t)
+	err := s.Create("ecs", "ecs", "description", EcsContext{
+		Profile: "default",
+	})
+	assert.NilError(t, err)
+	err = s.SetQuotas("ecs", &Quotas{MaxCPUs: 2})
+	assert.NilError(t, err)
+
+	err = s.SetRetryPolicy("ecs", &RetryPolicy{Timeout: 30 * time.Second, MaxRetries: 5})
+	assert.NilError(t, err)
+	c, err := s.Get("ecs")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, c.Metadata.Retry, &RetryPolicy{Timeout: 30 * time.Second, MaxRetries: 5})
+	assert.DeepEqual(t, c.Metadata.Quotas, &Quotas{MaxCPUs: 2})
+
+	err = s.SetRetryPolicy("ecs", nil)
+	assert.NilError(t, err)
+	c, err = s.Get("ecs")
+	assert.NilError(t, err)
+	assert.Assert(t, cmp.Nil(c.Metadata.Retry))
+
+	err = s.SetRetryPolicy(DefaultContextName, &RetryPolicy{MaxRetries: 1})
+	assert.Assert(t, errdefs.IsForbiddenError(err))
+}
+
+func TestSetReadOnly(t *testing.T) {
+	s := testStore(t)
+	err := s.Create("aci", "aci", "description", AciContext{
+		Location: "eastus",
+	})
+	assert.NilError(t, err)
+
+	err = s.SetReadOnly("aci", true)
+	assert.NilError(t, err)
+	c, err := s.Get("aci")
+	assert.NilError(t, err)
+	assert.Assert(t, c.Metadata.ReadOnly)
+	assert.Equal(t, c.Metadata.Description, "description")
+
+	err = s.SetReadOnly("aci", false)
+	assert.NilError(t, err)
+	c, err = s.Get("aci")
+	assert.NilError(t, err)
+	assert.Assert(t, !c.Metadata.ReadOnly)
+
+	err = s.SetReadOnly(DefaultContextName, true)
+	assert.Assert(t, errdefs.IsForbiddenError(err))
+}
+
 func TestGetUnknown(t *testing.T) {
 	s := testStore(t)
 	meta, err := s.Get("unknown")
@@ -119,3 +199,23 @@ func TestRemove(t *testing.T) {
 	assert.Assert(t, cmp.Nil(meta))
 
 }
+
+func TestConcurrentCreate(t *testing.T) {
+	s := testStore(t)
+	errs := make(chan error)
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("context%d", i)
+		go func() {
+			errs <- s.Create(name, "test", "description", ContextMetadata{})
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.NilError(t, <-errs)
+	}
+
+	for i := 0; i < 10; i++ {
+		meta, err := s.Get(fmt.Sprintf("context%d", i))
+		assert.NilError(t, err)
+		assert.Equal(t, meta.Type(), "test")
+	}
+}
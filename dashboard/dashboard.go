@@ -0,0 +1,179 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// Dashboard serves a web UI showing the services of a project, their health, logs and dependencies
+type Dashboard struct {
+	service compose.Service
+	project *types.Project
+}
+
+// New returns a dashboard of the project, backed by a compose service
+func New(service compose.Service, project *types.Project) *Dashboard {
+	return &Dashboard{
+		service: service,
+		project: project,
+	}
+}
+
+// Handler returns the HTTP handler of the dashboard page and of the API it queries
+func (d *Dashboard) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.index)
+	mux.HandleFunc("/api/services", d.services)
+	mux.HandleFunc("/api/graph", d.graph)
+	mux.HandleFunc("/api/logs", d.logs)
+	return localHostOnly(mux)
+}
+
+// localHostOnly rejects the requests which don't address the dashboard as 127.0.0.1 or localhost on the port
+// it listens on, so a page of another site resolving its domain to the loopback interface (DNS rebinding)
+// can't read the project logs
+func localHostOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+		if !ok {
+			http.Error(w, "unknown local address", http.StatusForbidden)
+			return
+		}
+		_, port, err := net.SplitHostPort(addr.String())
+		if err != nil || (r.Host != net.JoinHostPort("127.0.0.1", port) && r.Host != net.JoinHostPort("localhost", port)) {
+			http.Error(w, fmt.Sprintf("invalid host %q", r.Host), http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Serve serves the dashboard on addr until the context is done
+func (d *Dashboard) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:    addr,
+		Handler: d.Handler(),
+	}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+	err := server.ListenAndServe()
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+func (d *Dashboard) index(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(page))
+}
+
+func (d *Dashboard) services(w http.ResponseWriter, r *http.Request) {
+	services, err := d.service.Ps(r.Context(), d.project.Name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, services)
+}
+
+func (d *Dashboard) graph(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, dependencyGraph(d.project))
+}
+
+// logs streams the logs of the project, or of the services selected by the `service` query parameters,
+// until the client disconnects
+func (d *Dashboard) logs(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	err := d.service.Logs(r.Context(), d.project.Name, flushWriter{w: w, flusher: flusher}, compose.LogOptions{
+		Services: r.URL.Query()["service"],
+		Tail:     r.URL.Query().Get("tail"),
+	})
+	if err != nil && r.Context().Err() == nil {
+		logrus.Warnf("dashboard: failed to stream logs: %v", err)
+	}
+}
+
+// dependencyGraph maps the services of a project to the sorted list of services they depend on
+func dependencyGraph(project *types.Project) map[string][]string {
+	graph := map[string][]string{}
+	for _, s := range project.Services {
+		dependencies := []string{}
+		for _, dependency := range s.GetDependencies() {
+			// links may declare an alias as `service:alias`
+			dependencies = append(dependencies, strings.SplitN(dependency, ":", 2)[0])
+		}
+		sort.Strings(dependencies)
+		graph[s.Name] = unique(dependencies)
+	}
+	return graph
+}
+
+func unique(sorted []string) []string {
+	result := sorted[:0]
+	for i, s := range sorted {
+		if i == 0 || sorted[i-1] != s {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+type flushWriter struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.flusher.Flush()
+	return n, err
+}
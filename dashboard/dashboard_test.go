@@ -0,0 +1,113 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type fakeService struct {
+	compose.Service
+}
+
+func (f fakeService) Ps(ctx context.Context, projectName string) ([]compose.ServiceStatus, error) {
+	return []compose.ServiceStatus{{Name: "web", Replicas: 1, Desired: 1}}, nil
+}
+
+func (f fakeService) Logs(ctx context.Context, projectName string, w io.Writer, options compose.LogOptions) error {
+	_, err := fmt.Fprintf(w, "%s %v\n", projectName, options.Services)
+	return err
+}
+
+var project = &types.Project{
+	Name: "myproject",
+	Services: types.Services{
+		{Name: "web", DependsOn: types.DependsOnConfig{"db": {}}, Links: []string{"cache:redis"}},
+		{Name: "db"},
+		{Name: "cache"},
+	},
+}
+
+func TestDependencyGraph(t *testing.T) {
+	assert.DeepEqual(t, dependencyGraph(project), map[string][]string{
+		"web":   {"cache", "db"},
+		"db":    {},
+		"cache": {},
+	})
+}
+
+func TestServicesHandler(t *testing.T) {
+	server := httptest.NewServer(New(fakeService{}, project).Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/services")
+	assert.NilError(t, err)
+	defer resp.Body.Close() // nolint:errcheck
+	var services []compose.ServiceStatus
+	assert.NilError(t, json.NewDecoder(resp.Body).Decode(&services))
+	assert.Equal(t, len(services), 1)
+	assert.Equal(t, services[0].Name, "web")
+}
+
+func TestLogsHandler(t *testing.T) {
+	server := httptest.NewServer(New(fakeService{}, project).Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/logs?service=web")
+	assert.NilError(t, err)
+	defer resp.Body.Close() // nolint:errcheck
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NilError(t, err)
+	assert.Equal(t, string(body), "myproject [web]\n")
+}
+
+func TestRejectsForeignHosts(t *testing.T) {
+	server := httptest.NewServer(New(fakeService{}, project).Handler())
+	defer server.Close()
+	port := server.Listener.Addr().(*net.TCPAddr).Port
+
+	get := func(path string, host string) int {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		assert.NilError(t, err)
+		req.Host = host
+		resp, err := http.DefaultClient.Do(req)
+		assert.NilError(t, err)
+		defer resp.Body.Close() // nolint:errcheck
+		return resp.StatusCode
+	}
+	for _, path := range []string{"/api/logs", "/api/services", "/api/graph"} {
+		assert.Equal(t, get(path, fmt.Sprintf("127.0.0.1:%d", port)), http.StatusOK, path)
+		assert.Equal(t, get(path, fmt.Sprintf("localhost:%d", port)), http.StatusOK, path)
+		// a rebound domain resolving to the loopback interface
+		assert.Equal(t, get(path, fmt.Sprintf("attacker.example.com:%d", port)), http.StatusForbidden, path)
+		assert.Equal(t, get(path, "localhost"), http.StatusForbidden, path)
+		assert.Equal(t, get(path, fmt.Sprintf("localhost:%d", port+1)), http.StatusForbidden, path)
+	}
+}
@@ -0,0 +1,110 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package dashboard
+
+// page is the single page dashboard, polling the services and graph endpoints and streaming the logs one
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Compose dashboard</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+.healthy { color: #2a7d2a; }
+.unhealthy { color: #c62828; }
+#logs { background: #111; color: #eee; height: 25em; overflow: auto; padding: 0.5em; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Compose dashboard</h1>
+<h2>Services</h2>
+<table>
+<thead><tr><th>Service</th><th>Replicas</th><th>Health</th><th>Ports</th><th>Depends on</th></tr></thead>
+<tbody id="services"></tbody>
+</table>
+<h2>Logs <select id="service"><option value="">all services</option></select></h2>
+<div id="logs"></div>
+<script>
+var graph = {};
+var logs = null;
+
+function text(value) {
+  var span = document.createElement("span");
+  span.textContent = value;
+  return span.innerHTML;
+}
+
+function refresh() {
+  fetch("api/graph").then(function (r) { return r.json(); }).then(function (g) {
+    graph = g;
+    var select = document.getElementById("service");
+    Object.keys(graph).sort().forEach(function (name) {
+      if (!select.querySelector("option[value='" + name + "']")) {
+        var option = document.createElement("option");
+        option.value = name;
+        option.textContent = name;
+        select.appendChild(option);
+      }
+    });
+    return fetch("api/services");
+  }).then(function (r) { return r.json(); }).then(function (services) {
+    var rows = (services || []).map(function (s) {
+      var health = s.Unhealthy > 0 ? '<span class="unhealthy">' + s.Unhealthy + ' unhealthy</span>' : '<span class="healthy">ok</span>';
+      return "<tr><td>" + text(s.Name) + "</td><td>" + s.Replicas + "/" + s.Desired + "</td><td>" + health +
+        "</td><td>" + text((s.Ports || []).join(", ")) + "</td><td>" + text((graph[s.Name] || []).join(", ")) + "</td></tr>";
+    });
+    document.getElementById("services").innerHTML = rows.join("");
+  });
+}
+
+function streamLogs() {
+  if (logs) {
+    logs.abort();
+  }
+  logs = new AbortController();
+  var output = document.getElementById("logs");
+  output.textContent = "";
+  var service = document.getElementById("service").value;
+  var url = "api/logs?tail=100" + (service ? "&service=" + encodeURIComponent(service) : "");
+  fetch(url, { signal: logs.signal }).then(function (r) {
+    var reader = r.body.getReader();
+    var decoder = new TextDecoder();
+    function read() {
+      return reader.read().then(function (chunk) {
+        if (chunk.done) {
+          return;
+        }
+        // strip the terminal colors of the log prefixes
+        output.textContent += decoder.decode(chunk.value, { stream: true }).replace(/\x1b\[[0-9;]*m/g, "");
+        output.scrollTop = output.scrollHeight;
+        return read();
+      });
+    }
+    return read();
+  }).catch(function () {});
+}
+
+document.getElementById("service").addEventListener("change", streamLogs);
+refresh();
+setInterval(refresh, 2000);
+streamLogs();
+</script>
+</body>
+</html>
+`
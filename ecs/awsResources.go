@@ -24,6 +24,7 @@ import (
 
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/utils"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/awslabs/goformation/v4/cloudformation"
@@ -71,6 +72,28 @@ func (r *awsResources) subnetsIDs() []string {
 	return ids
 }
 
+// serviceSubnetsIDs returns the subnets the service is pinned to by x-aws-subnets, or all the VPC subnets
+func (r *awsResources) serviceSubnetsIDs(service types.ServiceConfig) ([]string, error) {
+	x, ok := service.Extensions[extensionSubnets]
+	if !ok {
+		return r.subnetsIDs(), nil
+	}
+	list, ok := x.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("service %s: %s must be a non-empty list of subnet IDs", service.Name, extensionSubnets)
+	}
+	available := r.subnetsIDs()
+	var ids []string
+	for _, s := range list {
+		id := fmt.Sprint(s)
+		if !utils.StringContains(available, id) {
+			return nil, fmt.Errorf("service %s: subnet %s does not belong to VPC %s", service.Name, id, r.vpc)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 // awsResource is abstract representation for any (existing or future) AWS resource that we can refer both by ID or full ARN
 type awsResource interface {
 	ARN() string
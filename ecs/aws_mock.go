@@ -12,6 +12,7 @@ import (
 	secrets "github.com/docker/compose-cli/api/secrets"
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockAPI is a mock of API interface
@@ -37,6 +38,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// CheckCredentials mocks base method
+func (m *MockAPI) CheckCredentials(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckCredentials", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckCredentials indicates an expected call of CheckCredentials
+func (mr *MockAPIMockRecorder) CheckCredentials(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckCredentials", reflect.TypeOf((*MockAPI)(nil).CheckCredentials), arg0)
+}
+
 // CheckRequirements mocks base method
 func (m *MockAPI) CheckRequirements(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -80,6 +95,21 @@ func (mr *MockAPIMockRecorder) CreateChangeSet(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChangeSet", reflect.TypeOf((*MockAPI)(nil).CreateChangeSet), arg0, arg1, arg2, arg3)
 }
 
+// DescribeChangeSet mocks base method
+func (m *MockAPI) DescribeChangeSet(arg0 context.Context, arg1 string) ([]compose.ResourceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DescribeChangeSet", arg0, arg1)
+	ret0, _ := ret[0].([]compose.ResourceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DescribeChangeSet indicates an expected call of DescribeChangeSet
+func (mr *MockAPIMockRecorder) DescribeChangeSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DescribeChangeSet", reflect.TypeOf((*MockAPI)(nil).DescribeChangeSet), arg0, arg1)
+}
+
 // CreateCluster mocks base method
 func (m *MockAPI) CreateCluster(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -269,18 +299,49 @@ func (mr *MockAPIMockRecorder) GetLoadBalancerURL(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoadBalancerURL", reflect.TypeOf((*MockAPI)(nil).GetLoadBalancerURL), arg0, arg1)
 }
 
+// EnsureRepository mocks base method
+func (m *MockAPI) EnsureRepository(arg0 context.Context, arg1, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureRepository", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureRepository indicates an expected call of EnsureRepository
+func (mr *MockAPIMockRecorder) EnsureRepository(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRepository", reflect.TypeOf((*MockAPI)(nil).EnsureRepository), arg0, arg1, arg2)
+}
+
+// GetRegistryCredentials mocks base method
+func (m *MockAPI) GetRegistryCredentials(arg0 context.Context) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRegistryCredentials", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRegistryCredentials indicates an expected call of GetRegistryCredentials
+func (mr *MockAPIMockRecorder) GetRegistryCredentials(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRegistryCredentials", reflect.TypeOf((*MockAPI)(nil).GetRegistryCredentials), arg0)
+}
+
 // GetLogs mocks base method
-func (m *MockAPI) GetLogs(arg0 context.Context, arg1 string, arg2 func(string, string, string)) error {
+func (m *MockAPI) GetLogs(arg0 context.Context, arg1 string, arg2, arg3 time.Time, arg4 func(string, string, string)) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogs", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetLogs", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetLogs indicates an expected call of GetLogs
-func (mr *MockAPIMockRecorder) GetLogs(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockAPIMockRecorder) GetLogs(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockAPI)(nil).GetLogs), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockAPI)(nil).GetLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetParameter mocks base method
@@ -19,6 +19,7 @@ package ecs
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/api/containers"
@@ -32,6 +33,7 @@ import (
 	"github.com/docker/compose-cli/errdefs"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/session"
 )
 
@@ -46,6 +48,8 @@ type ContextParams struct {
 	Profile      string
 	Region       string
 	CredsFromEnv bool
+
+	storedCredentials bool
 }
 
 func (c ContextParams) haveRequiredEnvVars() bool {
@@ -58,8 +62,18 @@ func (c ContextParams) haveRequiredEnvVars() bool {
 	return false
 }
 
+// haveCredentials checks whether credentials can be resolved from the environment variables,
+// including a profile using a credential_process, or from the EC2 instance profile
+func (c ContextParams) haveCredentials() bool {
+	return c.haveRequiredEnvVars() || runningOnEC2()
+}
+
 func init() {
 	backend.Register(backendType, backendType, service, getCloudService)
+	compose.RegisterBackendConfig(compose.BackendConfig{
+		Capabilities: capabilities,
+		Normalize:    (&ecsAPIService{}).checkCompatibility,
+	})
 }
 
 func service(ctx context.Context) (backend.Service, error) {
@@ -71,17 +85,17 @@ func service(ctx context.Context) (backend.Service, error) {
 		return nil, err
 	}
 
-	return getEcsAPIService(ecsContext)
+	return getEcsAPIService(ecsContext, store.ContextRetryPolicy(ctx))
 }
 
-func getEcsAPIService(ecsCtx store.EcsContext) (*ecsAPIService, error) {
+func getEcsAPIService(ecsCtx store.EcsContext, policy store.RetryPolicy) (*ecsAPIService, error) {
 	region := ""
 	profile := ecsCtx.Profile
 
 	if ecsCtx.CredentialsFromEnv {
 		env := getEnvVars()
-		if !env.haveRequiredEnvVars() {
-			return nil, fmt.Errorf("context requires credentials to be passed as environment variables")
+		if !env.haveCredentials() {
+			return nil, fmt.Errorf("context requires credentials to be passed as environment variables or an EC2 instance profile")
 		}
 		profile = env.Profile
 		region = env.Region
@@ -95,12 +109,21 @@ func getEcsAPIService(ecsCtx store.EcsContext) (*ecsAPIService, error) {
 		region = r
 	}
 
+	config := aws.Config{
+		Region: aws.String(region),
+	}
+	if ecsCtx.StoredCredentials {
+		creds, err := loadCredentials(profile)
+		if err != nil {
+			return nil, err
+		}
+		config.Credentials = creds
+	}
+	applyRetryPolicy(&config, policy)
 	sess, err := session.NewSessionWithOptions(session.Options{
 		Profile:           profile,
 		SharedConfigState: session.SharedConfigEnable,
-		Config: aws.Config{
-			Region: aws.String(region),
-		},
+		Config:            config,
 	})
 	if err != nil {
 		return nil, err
@@ -114,6 +137,23 @@ func getEcsAPIService(ecsCtx store.EcsContext) (*ecsAPIService, error) {
 	}, nil
 }
 
+// applyRetryPolicy configures the retries and the timeout of the AWS API calls with the retry policy of the context
+func applyRetryPolicy(config *aws.Config, policy store.RetryPolicy) {
+	if policy.MaxRetries > 0 || policy.RetryDelay > 0 {
+		retryer := client.DefaultRetryer{
+			NumMaxRetries: client.DefaultRetryerMaxNumRetries,
+			MinRetryDelay: policy.RetryDelay,
+		}
+		if policy.MaxRetries > 0 {
+			retryer.NumMaxRetries = policy.MaxRetries
+		}
+		config.Retryer = retryer
+	}
+	if policy.Timeout > 0 {
+		config.HTTPClient = &http.Client{Timeout: policy.Timeout}
+	}
+}
+
 type ecsAPIService struct {
 	ctx    store.EcsContext
 	Region string
@@ -140,6 +180,10 @@ func (b *ecsAPIService) ResourceService() resources.Service {
 	return nil
 }
 
+func (b *ecsAPIService) Ping(ctx context.Context) error {
+	return b.aws.CheckCredentials(ctx)
+}
+
 func getCloudService() (cloud.Service, error) {
 	return ecsCloudService{}, nil
 }
@@ -0,0 +1,42 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+func TestApplyRetryPolicy(t *testing.T) {
+	config := aws.Config{}
+	applyRetryPolicy(&config, store.RetryPolicy{})
+	assert.Assert(t, config.Retryer == nil)
+	assert.Assert(t, config.HTTPClient == nil)
+
+	applyRetryPolicy(&config, store.RetryPolicy{Timeout: 30 * time.Second, RetryDelay: time.Second})
+	assert.DeepEqual(t, config.Retryer, client.DefaultRetryer{NumMaxRetries: client.DefaultRetryerMaxNumRetries, MinRetryDelay: time.Second})
+	assert.Equal(t, config.HTTPClient.Timeout, 30*time.Second)
+
+	applyRetryPolicy(&config, store.RetryPolicy{MaxRetries: 10})
+	assert.Equal(t, config.Retryer.(client.DefaultRetryer).NumMaxRetries, 10)
+}
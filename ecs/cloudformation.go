@@ -19,8 +19,8 @@ package ecs
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
 	"regexp"
+	"strconv"
 	"strings"
 
 	ecsapi "github.com/aws/aws-sdk-go/service/ecs"
@@ -35,6 +35,8 @@ import (
 	"github.com/awslabs/goformation/v4/cloudformation/secretsmanager"
 	cloudmap "github.com/awslabs/goformation/v4/cloudformation/servicediscovery"
 	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/secrets"
 )
 
 func (b *ecsAPIService) Convert(ctx context.Context, project *types.Project, format string) ([]byte, error) {
@@ -47,7 +49,7 @@ func (b *ecsAPIService) Convert(ctx context.Context, project *types.Project, for
 }
 
 func (b *ecsAPIService) convert(ctx context.Context, project *types.Project) (*cloudformation.Template, error) {
-	err := b.checkCompatibility(project)
+	err := b.checkCompatibility(ctx, project)
 	if err != nil {
 		return nil, err
 	}
@@ -64,13 +66,16 @@ func (b *ecsAPIService) convert(ctx context.Context, project *types.Project) (*c
 	}
 
 	for name, secret := range project.Secrets {
-		err := b.createSecret(project, name, secret, template)
+		err := b.createSecret(ctx, project, name, secret, template)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	b.createLogGroup(project, template)
+	err = b.createLogGroup(project, template)
+	if err != nil {
+		return nil, err
+	}
 
 	// Private DNS namespace will allow DNS name for the services to be <service>.<project>.local
 	b.createCloudMap(project, template, resources.vpc)
@@ -168,6 +173,24 @@ func (b *ecsAPIService) createService(project *types.Project, service types.Serv
 		launchType = ecsapi.LaunchTypeEc2
 		platformVersion = "" // The platform version must be null when specifying an EC2 launch type
 	}
+	if x, ok := service.Extensions[extensionAssignPublicIP]; ok {
+		assign, ok := x.(bool)
+		if !ok {
+			return fmt.Errorf("service %s: %s must be a boolean", service.Name, extensionAssignPublicIP)
+		}
+		if assign && launchType == ecsapi.LaunchTypeEc2 {
+			return fmt.Errorf("service %s: public IP can't be assigned to tasks running on EC2 instances", service.Name)
+		}
+		assignPublicIP = ecsapi.AssignPublicIpDisabled
+		if assign {
+			assignPublicIP = ecsapi.AssignPublicIpEnabled
+		}
+	}
+
+	subnets, err := resources.serviceSubnetsIDs(service)
+	if err != nil {
+		return err
+	}
 
 	template.Resources[serviceResourceName(service.Name)] = &ecs.Service{
 		AWSCloudFormationDependsOn: dependsOn,
@@ -187,7 +210,7 @@ func (b *ecsAPIService) createService(project *types.Project, service types.Serv
 			AwsvpcConfiguration: &ecs.Service_AwsVpcConfiguration{
 				AssignPublicIp: assignPublicIP,
 				SecurityGroups: resources.serviceSecurityGroups(service),
-				Subnets:        resources.subnetsIDs(),
+				Subnets:        subnets,
 			},
 		},
 		PlatformVersion:    platformVersion,
@@ -207,22 +230,29 @@ func (b *ecsAPIService) createIngress(service types.ServiceConfig, net string, p
 	if protocol == "" {
 		protocol = allProtocols
 	}
-	ingress := fmt.Sprintf("%s%dIngress", normalizeResourceName(net), port.Target)
-	template.Resources[ingress] = &ec2.SecurityGroupIngress{
-		CidrIp:      "0.0.0.0/0",
-		Description: fmt.Sprintf("%s:%d/%s on %s network", service.Name, port.Target, port.Protocol, net),
-		GroupId:     resources.securityGroups[net],
-		FromPort:    int(port.Target),
-		IpProtocol:  protocol,
-		ToPort:      int(port.Target),
+	ports := []uint32{port.Target}
+	if port.Published != 0 && port.Published != port.Target {
+		// load balancer listens on the published port
+		ports = append(ports, port.Published)
+	}
+	for _, p := range ports {
+		ingress := fmt.Sprintf("%s%dIngress", normalizeResourceName(net), p)
+		template.Resources[ingress] = &ec2.SecurityGroupIngress{
+			CidrIp:      "0.0.0.0/0",
+			Description: fmt.Sprintf("%s:%d/%s on %s network", service.Name, p, port.Protocol, net),
+			GroupId:     resources.securityGroups[net],
+			FromPort:    int(p),
+			IpProtocol:  protocol,
+			ToPort:      int(p),
+		}
 	}
 }
 
-func (b *ecsAPIService) createSecret(project *types.Project, name string, s types.SecretConfig, template *cloudformation.Template) error {
+func (b *ecsAPIService) createSecret(ctx context.Context, project *types.Project, name string, s types.SecretConfig, template *cloudformation.Template) error {
 	if s.External.External {
 		return nil
 	}
-	sensitiveData, err := ioutil.ReadFile(s.File)
+	sensitiveData, err := secrets.ReadContent(ctx, s)
 	if err != nil {
 		return err
 	}
@@ -238,16 +268,49 @@ func (b *ecsAPIService) createSecret(project *types.Project, name string, s type
 	return nil
 }
 
-func (b *ecsAPIService) createLogGroup(project *types.Project, template *cloudformation.Template) {
-	retention := 0
-	if v, ok := project.Extensions[extensionRetention]; ok {
-		retention = v.(int)
+func (b *ecsAPIService) createLogGroup(project *types.Project, template *cloudformation.Template) error {
+	retention, err := logsRetention(project)
+	if err != nil {
+		return err
 	}
 	logGroup := fmt.Sprintf("/docker-compose/%s", project.Name)
 	template.Resources["LogGroup"] = &logs.LogGroup{
 		LogGroupName:    logGroup,
 		RetentionInDays: retention,
 	}
+	return nil
+}
+
+// logsRetentionDays are the retention periods CloudWatch accepts for log groups
+var logsRetentionDays = []int{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653}
+
+// logsRetention returns the number of days the logs are kept, set by x-aws-logs_retention, 0 when they never expire
+func logsRetention(project *types.Project) (int, error) {
+	v, ok := project.Extensions[extensionRetention]
+	if !ok {
+		return 0, nil
+	}
+	var days int
+	switch value := v.(type) {
+	case int:
+		days = value
+	case string:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s: invalid number of days %q", extensionRetention, value)
+		}
+		days = parsed
+	default:
+		return 0, fmt.Errorf("%s: invalid number of days %v", extensionRetention, v)
+	}
+	supported := []string{}
+	for _, d := range logsRetentionDays {
+		if d == days {
+			return days, nil
+		}
+		supported = append(supported, strconv.Itoa(d))
+	}
+	return 0, fmt.Errorf("%s: CloudWatch doesn't support a retention of %d days, supported values are %s", extensionRetention, days, strings.Join(supported, ", "))
 }
 
 func computeRollingUpdateLimits(service types.ServiceConfig) (int, int, error) {
@@ -297,7 +360,7 @@ func (b *ecsAPIService) createListener(service types.ServiceConfig, port types.S
 		"%s%s%dListener",
 		normalizeResourceName(service.Name),
 		strings.ToUpper(port.Protocol),
-		port.Target,
+		port.Published,
 	)
 	//add listener to dependsOn
 	//https://stackoverflow.com/questions/53971873/the-target-group-does-not-have-an-associated-load-balancer
@@ -316,7 +379,7 @@ func (b *ecsAPIService) createListener(service types.ServiceConfig, port types.S
 		},
 		LoadBalancerArn: loadBalancer.ARN(),
 		Protocol:        protocol,
-		Port:            int(port.Target),
+		Port:            int(port.Published),
 	}
 	return listenerName
 }
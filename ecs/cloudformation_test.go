@@ -25,6 +25,7 @@ import (
 
 	"github.com/docker/compose-cli/api/compose"
 
+	ecsapi "github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/awslabs/goformation/v4/cloudformation"
 	"github.com/awslabs/goformation/v4/cloudformation/ec2"
@@ -58,20 +59,79 @@ services:
     image: hello_world
     logging:
       options:
-        awslogs-datetime-pattern: "FOO"
+        awslogs-datetime-format: "%Y-%m-%d %H:%M:%S"
+        mode: non-blocking
 
-x-aws-logs_retention: 10
+x-aws-logs_retention: 14
 `, useDefaultVPC)
 	def := template.Resources["FooTaskDefinition"].(*ecs.TaskDefinition)
 	logging := getMainContainer(def, t).LogConfiguration
 	if logging != nil {
-		assert.Equal(t, logging.Options["awslogs-datetime-pattern"], "FOO")
+		assert.Equal(t, logging.Options["awslogs-datetime-format"], "%Y-%m-%d %H:%M:%S")
+		assert.Equal(t, logging.Options["mode"], "non-blocking")
 	} else {
 		t.Fatal("Logging not configured")
 	}
 
 	logGroup := template.Resources["LogGroup"].(*logs.LogGroup)
-	assert.Equal(t, logGroup.RetentionInDays, 10)
+	assert.Equal(t, logGroup.RetentionInDays, 14)
+}
+
+func TestLogsRetention(t *testing.T) {
+	project := &types.Project{}
+	retention, err := logsRetention(project)
+	assert.NilError(t, err)
+	assert.Equal(t, retention, 0)
+
+	project.Extensions = map[string]interface{}{extensionRetention: "30"}
+	retention, err = logsRetention(project)
+	assert.NilError(t, err)
+	assert.Equal(t, retention, 30)
+
+	project.Extensions[extensionRetention] = 10
+	_, err = logsRetention(project)
+	assert.ErrorContains(t, err, "x-aws-logs_retention: CloudWatch doesn't support a retention of 10 days, supported values are 1, 3, 5")
+
+	project.Extensions[extensionRetention] = "a week"
+	_, err = logsRetention(project)
+	assert.Error(t, err, `x-aws-logs_retention: invalid number of days "a week"`)
+}
+
+func TestLoggingOptionsCompatibility(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+    logging:
+      options:
+        awslogs-datetime-format: "%Y-%m-%d"
+        awslogs-multiline-pattern: "^INFO"
+        tag: "{{.Name}}"
+`)
+	warnings := &compose.Warnings{}
+	err := (&ecsAPIService{}).checkCompatibility(compose.WithWarnings(context.TODO(), warnings), project)
+	assert.NilError(t, err)
+	messages := []string{}
+	for _, w := range warnings.List() {
+		messages = append(messages, w.Message)
+	}
+	assert.DeepEqual(t, messages, []string{
+		"services.logging.options.tag is not supported by the awslogs driver: unsupported attribute",
+		"services.logging.options.awslogs-multiline-pattern is ignored when awslogs-datetime-format is set: unsupported attribute",
+	})
+	_, ok := project.Services[0].Logging.Options["tag"]
+	assert.Assert(t, !ok)
+
+	project = loadConfig(t, `
+services:
+  foo:
+    image: hello_world
+    logging:
+      options:
+        awslogs-multiline-pattern: "^[INFO"
+`)
+	err = (&ecsAPIService{}).checkCompatibility(context.TODO(), project)
+	assert.ErrorContains(t, err, `awslogs-multiline-pattern "^[INFO" is not a valid regular expression`)
 }
 
 func TestEnvFile(t *testing.T) {
@@ -351,6 +411,75 @@ services:
 	assert.Check(t, loadBalancer.Type == elbv2.LoadBalancerTypeEnumNetwork)
 }
 
+func TestLoadBalancerMultiplePorts(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  test:
+    image: nginx
+    ports:
+      - 80:8080
+      - 443:8080
+`, useDefaultVPC)
+	for _, published := range []int{80, 443} {
+		l := template.Resources[fmt.Sprintf("TestTCP%dListener", published)]
+		assert.Assert(t, l != nil)
+		listener := *l.(*elasticloadbalancingv2.Listener)
+		assert.Equal(t, listener.Port, published)
+
+		tg := template.Resources[fmt.Sprintf("TestTCP%dTargetGroup", published)]
+		assert.Assert(t, tg != nil)
+		targetGroup := *tg.(*elasticloadbalancingv2.TargetGroup)
+		assert.Equal(t, targetGroup.Port, 8080)
+	}
+
+	service := *template.Resources["TestService"].(*ecs.Service)
+	assert.Equal(t, len(service.LoadBalancers), 2)
+
+	def := template.Resources["TestTaskDefinition"].(*ecs.TaskDefinition)
+	container := getMainContainer(def, t)
+	assert.DeepEqual(t, container.PortMappings, []ecs.TaskDefinition_PortMapping{
+		{ContainerPort: 8080, HostPort: 8080, Protocol: "tcp"},
+	})
+}
+
+func TestServiceSubnetsAndPublicIP(t *testing.T) {
+	template := convertYaml(t, `
+services:
+  api:
+    image: nginx
+    x-aws-subnets:
+      - subnet2
+    x-aws-assign_public_ip: false
+  front:
+    image: nginx
+`, useDefaultVPC)
+	api := *template.Resources["ApiService"].(*ecs.Service)
+	assert.DeepEqual(t, api.NetworkConfiguration.AwsvpcConfiguration.Subnets, []string{"subnet2"})
+	assert.Equal(t, api.NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp, ecsapi.AssignPublicIpDisabled)
+
+	front := *template.Resources["FrontService"].(*ecs.Service)
+	assert.DeepEqual(t, front.NetworkConfiguration.AwsvpcConfiguration.Subnets, []string{"subnet1", "subnet2"})
+	assert.Equal(t, front.NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp, ecsapi.AssignPublicIpEnabled)
+}
+
+func TestServiceSubnetsOutsideVPC(t *testing.T) {
+	project := loadConfig(t, `
+services:
+  api:
+    image: nginx
+    x-aws-subnets:
+      - subnet3
+`)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := NewMockAPI(ctrl)
+	useDefaultVPC(m.EXPECT())
+
+	backend := &ecsAPIService{aws: m}
+	_, err := backend.convert(context.TODO(), project)
+	assert.Error(t, err, "service api: subnet subnet3 does not belong to VPC vpc-123")
+}
+
 func TestUseExternalNetwork(t *testing.T) {
 	template := convertYaml(t, `
 services:
@@ -17,15 +17,22 @@
 package ecs
 
 import (
+	"context"
 	"fmt"
+	"regexp"
+	"sort"
 
 	"github.com/compose-spec/compose-go/compatibility"
 	"github.com/compose-spec/compose-go/errdefs"
 	"github.com/compose-spec/compose-go/types"
-	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/compose"
 )
 
-func (b *ecsAPIService) checkCompatibility(project *types.Project) error {
+func (b *ecsAPIService) checkCompatibility(ctx context.Context, project *types.Project) error {
+	if err := compose.CheckProjectName(project.Name, false); err != nil {
+		return err
+	}
 	var checker compatibility.Checker = &fargateCompatibilityChecker{
 		AllowList: compatibility.AllowList{
 			Supported: compatibleComposeAttributes,
@@ -37,7 +44,9 @@ func (b *ecsAPIService) checkCompatibility(project *types.Project) error {
 		if errdefs.IsIncompatibleError(err) {
 			return err
 		}
-		logrus.Warn(err.Error())
+		if err := compose.Warn(ctx, compose.Warning{Message: err.Error()}); err != nil {
+			return err
+		}
 	}
 	if !compatibility.IsCompatible(checker) {
 		return fmt.Errorf("compose file is incompatible with Amazon ECS")
@@ -115,12 +124,10 @@ func (c *fargateCompatibilityChecker) CheckImage(service *types.ServiceConfig) {
 }
 
 func (c *fargateCompatibilityChecker) CheckPortsPublished(p *types.ServicePortConfig) {
+	// the load balancer listens on the published port and forwards to the container port
 	if p.Published == 0 {
 		p.Published = p.Target
 	}
-	if p.Published != p.Target {
-		c.Incompatible("published port can't be set to a distinct value than container port")
-	}
 }
 
 func (c *fargateCompatibilityChecker) CheckVolumesSource(config *types.ServiceVolumeConfig) {
@@ -151,6 +158,43 @@ func (c *fargateCompatibilityChecker) CheckLoggingDriver(config *types.LoggingCo
 	}
 }
 
+// awslogsOptions are the options of the awslogs log driver services can set, the group, region and stream prefix
+// being set by default
+var awslogsOptions = map[string]bool{
+	"awslogs-group":             true,
+	"awslogs-region":            true,
+	"awslogs-stream-prefix":     true,
+	"awslogs-datetime-format":   true,
+	"awslogs-multiline-pattern": true,
+	"mode":                      true,
+	"max-buffer-size":           true,
+}
+
+func (c *fargateCompatibilityChecker) CheckLoggingOptions(config *types.LoggingConfig) {
+	keys := []string{}
+	for k := range config.Options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !awslogsOptions[k] {
+			c.Unsupported("services.logging.options.%s is not supported by the awslogs driver", k)
+			delete(config.Options, k)
+		}
+	}
+	if pattern, ok := config.Options["awslogs-multiline-pattern"]; ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			c.Incompatible("services.logging.options.awslogs-multiline-pattern %q is not a valid regular expression: %v", pattern, err)
+		}
+		if _, ok := config.Options["awslogs-datetime-format"]; ok {
+			c.Unsupported("services.logging.options.awslogs-multiline-pattern is ignored when awslogs-datetime-format is set")
+		}
+	}
+	if mode, ok := config.Options["mode"]; ok && mode != "blocking" && mode != "non-blocking" {
+		c.Incompatible("services.logging.options.mode %q must be blocking or non-blocking", mode)
+	}
+}
+
 func (c *fargateCompatibilityChecker) CheckUlimits(service *types.ServiceConfig) {
 	for k := range service.Ulimits {
 		if k != "nofile" {
@@ -18,15 +18,18 @@ package ecs
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	credstore "github.com/docker/compose-cli/context/credentials"
 	"github.com/docker/compose-cli/context/store"
 	"github.com/docker/compose-cli/errdefs"
 	"github.com/docker/compose-cli/prompt"
+	"github.com/docker/compose-cli/utils"
 
 	"github.com/AlecAivazis/survey/v2/terminal"
 	"github.com/aws/aws-sdk-go/aws"
@@ -44,11 +47,7 @@ func getEnvVars() ContextParams {
 		Region:  os.Getenv("AWS_REGION"),
 	}
 	if c.Region == "" {
-		defaultRegion := os.Getenv("AWS_DEFAULT_REGION")
-		if defaultRegion == "" {
-			defaultRegion = "us-east-1"
-		}
-		c.Region = defaultRegion
+		c.Region = os.Getenv("AWS_DEFAULT_REGION")
 	}
 
 	p := credentials.EnvProvider{}
@@ -91,7 +90,7 @@ func (h contextCreateAWSHelper) createContextData(_ context.Context, opts Contex
 		if err != nil {
 			return nil, "", err
 		}
-		if !contains(profilesList, opts.Profile) {
+		if !utils.StringContains(profilesList, opts.Profile) {
 			return nil, "", errors.Wrapf(errdefs.ErrNotFound, "profile %q not found", opts.Profile)
 		}
 	} else {
@@ -99,7 +98,16 @@ func (h contextCreateAWSHelper) createContextData(_ context.Context, opts Contex
 		var options []string
 		var actions []func(params *ContextParams) error
 
-		if _, err := os.Stat(getAWSConfigFile()); err == nil {
+		_, err := os.Stat(getAWSConfigFile())
+		hasConfig := err == nil
+		if !hasConfig && !getEnvVars().haveRequiredEnvVars() && runningOnEC2() {
+			// EC2 build agents rely on the instance profile, no need to prompt
+			opts.CredsFromEnv = true
+			ecsCtx, descr := h.createContext(&opts)
+			return ecsCtx, descr, nil
+		}
+
+		if hasConfig {
 			// User has .aws/config file, so we can offer to select one of his profiles
 			options = append(options, "An existing AWS profile")
 			actions = append(actions, h.selectFromLocalProfile)
@@ -150,7 +158,8 @@ func (h contextCreateAWSHelper) createContext(c *ContextParams) (interface{}, st
 			fmt.Sprintf("%s (%s)", c.Description, c.Region))
 	}
 	return store.EcsContext{
-		Profile: c.Profile,
+		Profile:           c.Profile,
+		StoredCredentials: c.storedCredentials,
 	}, description
 }
 
@@ -189,37 +198,70 @@ func (h contextCreateAWSHelper) createProfileFromCredentials(opts *ContextParams
 	if err != nil {
 		return err
 	}
+	opts.storedCredentials = true
 	return h.saveRegion(opts.Profile, opts.Region)
 }
 
-func (h contextCreateAWSHelper) saveCredentials(profile string, accessKeyID string, secretAccessKey string) error {
-	file := getAWSCredentialsFile()
-	err := os.MkdirAll(filepath.Dir(file), 0700)
-	if err != nil {
-		return err
+// storedKeys are the access keys of a profile, kept in the credentials store rather than in the plaintext AWS credentials file
+type storedKeys struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func credentialsStore() (credstore.Store, error) {
+	dir := filepath.Dir(getAWSCredentialsFile())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
 	}
+	return credstore.NewStore(dir), nil
+}
 
-	credentials, err := ini.Load(file)
-	if err != nil {
-		if !os.IsNotExist(err) {
-			return err
-		}
-		credentials = ini.Empty()
+// credentialsKey returns the key the access keys of a profile are stored with, the profile name
+// becomes a file name when the keys are written to disk
+func credentialsKey(profile string) (string, error) {
+	if strings.ContainsAny(profile, `/\`) {
+		return "", errors.Errorf("invalid profile name %q", profile)
 	}
+	return "docker-" + profile + "-keys", nil
+}
 
-	section, err := credentials.NewSection(profile)
+func (h contextCreateAWSHelper) saveCredentials(profile string, accessKeyID string, secretAccessKey string) error {
+	secrets, err := credentialsStore()
 	if err != nil {
 		return err
 	}
-	_, err = section.NewKey("aws_access_key_id", accessKeyID)
+	data, err := json.Marshal(storedKeys{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+	})
 	if err != nil {
 		return err
 	}
-	_, err = section.NewKey("aws_secret_access_key", secretAccessKey)
+	key, err := credentialsKey(profile)
 	if err != nil {
 		return err
 	}
-	return credentials.SaveTo(file)
+	return secrets.Set(key, data)
+}
+
+func loadCredentials(profile string) (*credentials.Credentials, error) {
+	secrets, err := credentialsStore()
+	if err != nil {
+		return nil, err
+	}
+	key, err := credentialsKey(profile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := secrets.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var keys storedKeys
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	return credentials.NewStaticCredentials(keys.AccessKeyID, keys.SecretAccessKey, ""), nil
 }
 
 func (h contextCreateAWSHelper) saveRegion(profile, region string) error {
@@ -271,7 +313,7 @@ func getProfiles() ([]string, error) {
 		}
 		for key := range sections {
 			name := strings.ToLower(key)
-			if !contains(profiles, name) {
+			if !utils.StringContains(profiles, name) {
 				profiles = append(profiles, name)
 			}
 		}
@@ -303,7 +345,7 @@ func getRegion(profile string) (string, error) {
 		profile = "default"
 	}
 	// only load ~/.aws/config
-	awsConfig := defaults.SharedConfigFilename()
+	awsConfig := getAWSConfigFile()
 	configIni, err := ini.Load(awsConfig)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -330,6 +372,9 @@ func getRegion(profile string) (string, error) {
 	if region == "" {
 		region = getProfileRegion("default")
 	}
+	if region == "" {
+		region = getInstanceRegion()
+	}
 	if region == "" {
 		// fallback to AWS default
 		region = "us-east-1"
@@ -390,15 +435,6 @@ func (h contextCreateAWSHelper) askCredentials() (string, string, error) {
 	return accessKeyID, secretAccessKey, nil
 }
 
-func contains(values []string, value string) bool {
-	for _, v := range values {
-		if v == value {
-			return true
-		}
-	}
-	return false
-}
-
 func loadIniFile(path string, prefix bool) (map[string]ini.Section, error) {
 	profiles := map[string]ini.Section{}
 	credIni, err := ini.Load(path)
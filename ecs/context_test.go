@@ -21,6 +21,7 @@ import (
 	"os"
 	"testing"
 
+	credstore "github.com/docker/compose-cli/context/credentials"
 	"github.com/docker/compose-cli/context/store"
 	"github.com/docker/compose-cli/prompt"
 
@@ -51,6 +52,9 @@ func TestCreateContextDataByKeys(t *testing.T) {
 	defer os.Unsetenv("AWS_CONFIG_FILE")             // nolint:errcheck
 	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE") // nolint:errcheck
 
+	os.Setenv(credstore.HelperEnvVar, credstore.FileHelper) // nolint:errcheck
+	defer os.Unsetenv(credstore.HelperEnvVar)               // nolint:errcheck
+
 	c := contextCreateAWSHelper{
 		user: nil,
 	}
@@ -63,12 +67,12 @@ func TestCreateContextDataByKeys(t *testing.T) {
 	})
 	assert.NilError(t, err)
 	assert.Equal(t, data.(store.EcsContext).Profile, "default")
+	assert.Assert(t, data.(store.EcsContext).StoredCredentials)
 
 	s := golden.Get(t, dir.Join("config"))
 	golden.Assert(t, string(s), "context/by-keys/config.golden")
 
-	s = golden.Get(t, dir.Join("credentials"))
-	golden.Assert(t, string(s), "context/by-keys/credentials.golden")
+	assertStoredCredentials(t, dir)
 }
 
 func TestCreateContextDataFromProfile(t *testing.T) {
@@ -101,6 +105,7 @@ func TestCreateContextDataFromEnvInteractive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	withInstanceMetadata(t, "")
 	ui := prompt.NewMockUI(ctrl)
 	c := contextCreateAWSHelper{
 		user: ui,
@@ -120,9 +125,13 @@ func TestCreateContextDataByKeysInteractive(t *testing.T) {
 	defer os.Unsetenv("AWS_CONFIG_FILE")             // nolint:errcheck
 	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE") // nolint:errcheck
 
+	os.Setenv(credstore.HelperEnvVar, credstore.FileHelper) // nolint:errcheck
+	defer os.Unsetenv(credstore.HelperEnvVar)               // nolint:errcheck
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	withInstanceMetadata(t, "")
 	ui := prompt.NewMockUI(ctrl)
 	c := contextCreateAWSHelper{
 		user: ui,
@@ -142,12 +151,12 @@ func TestCreateContextDataByKeysInteractive(t *testing.T) {
 
 	assert.NilError(t, err)
 	assert.Equal(t, data.(store.EcsContext).Profile, "default")
+	assert.Assert(t, data.(store.EcsContext).StoredCredentials)
 
 	s := golden.Get(t, dir.Join("config"))
 	golden.Assert(t, string(s), "context/by-keys/config.golden")
 
-	s = golden.Get(t, dir.Join("credentials"))
-	golden.Assert(t, string(s), "context/by-keys/credentials.golden")
+	assertStoredCredentials(t, dir)
 }
 
 func TestCreateContextDataByProfileInteractive(t *testing.T) {
@@ -160,6 +169,7 @@ func TestCreateContextDataByProfileInteractive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	withInstanceMetadata(t, "")
 	ui := prompt.NewMockUI(ctrl)
 	c := contextCreateAWSHelper{
 		user: ui,
@@ -171,3 +181,93 @@ func TestCreateContextDataByProfileInteractive(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Equal(t, data.(store.EcsContext).Profile, "foo")
 }
+
+type fakeInstanceMetadata struct {
+	region string
+}
+
+func (m fakeInstanceMetadata) Available() bool {
+	return m.region != ""
+}
+
+func (m fakeInstanceMetadata) Region() (string, error) {
+	return m.region, nil
+}
+
+func withInstanceMetadata(t *testing.T, region string) {
+	previous := getInstanceMetadata
+	getInstanceMetadata = func() instanceMetadata {
+		return fakeInstanceMetadata{region: region}
+	}
+	t.Cleanup(func() {
+		getInstanceMetadata = previous
+	})
+}
+
+func TestCreateContextDataOnEC2(t *testing.T) {
+	dir := fs.NewDir(t, "aws")
+	os.Setenv("AWS_CONFIG_FILE", dir.Join("config"))                  // nolint:errcheck
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", dir.Join("credentials")) // nolint:errcheck
+
+	defer os.Unsetenv("AWS_CONFIG_FILE")             // nolint:errcheck
+	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE") // nolint:errcheck
+
+	withInstanceMetadata(t, "eu-central-1")
+	c := contextCreateAWSHelper{
+		user: nil,
+	}
+	data, _, err := c.createContextData(context.TODO(), ContextParams{})
+	assert.NilError(t, err)
+	assert.Equal(t, data.(store.EcsContext).CredentialsFromEnv, true)
+
+	region, err := getRegion("")
+	assert.NilError(t, err)
+	assert.Equal(t, region, "eu-central-1")
+}
+
+func TestCreateContextDataFromCredentialProcessProfile(t *testing.T) {
+	dir := fs.NewDir(t, "aws", fs.WithFile("config", "[profile sso]\ncredential_process = /usr/local/bin/get-credentials\nregion = eu-west-1\n"))
+	os.Setenv("AWS_CONFIG_FILE", dir.Join("config"))                  // nolint:errcheck
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", dir.Join("credentials")) // nolint:errcheck
+
+	defer os.Unsetenv("AWS_CONFIG_FILE")             // nolint:errcheck
+	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE") // nolint:errcheck
+
+	c := contextCreateAWSHelper{
+		user: nil,
+	}
+	data, _, err := c.createContextData(context.TODO(), ContextParams{
+		Name:    "test",
+		Profile: "sso",
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, data.(store.EcsContext).Profile, "sso")
+
+	region, err := getRegion("sso")
+	assert.NilError(t, err)
+	assert.Equal(t, region, "eu-west-1")
+}
+
+func assertStoredCredentials(t *testing.T, dir *fs.Dir) {
+	// access keys are kept out of the plaintext AWS credentials file
+	_, err := os.Stat(dir.Join("credentials"))
+	assert.Assert(t, os.IsNotExist(err))
+
+	creds, err := loadCredentials("default")
+	assert.NilError(t, err)
+	value, err := creds.Get()
+	assert.NilError(t, err)
+	assert.Equal(t, value.AccessKeyID, "ABCD")
+	assert.Equal(t, value.SecretAccessKey, "X&123")
+}
+
+func TestCredentialsKey(t *testing.T) {
+	key, err := credentialsKey("dev")
+	assert.NilError(t, err)
+	assert.Equal(t, key, "docker-dev-keys")
+
+	for _, profile := range []string{"../dev", `..\dev`, "a/b"} {
+		_, err := credentialsKey(profile)
+		assert.ErrorContains(t, err, "invalid profile name")
+	}
+}
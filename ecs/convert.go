@@ -306,7 +306,7 @@ func getLogConfiguration(service types.ServiceConfig, project *types.Project) *e
 	}
 	if service.Logging != nil {
 		for k, v := range service.Logging.Options {
-			if strings.HasPrefix(k, "awslogs-") {
+			if awslogsOptions[k] {
 				options[k] = v
 			}
 		}
@@ -441,10 +441,18 @@ func toPortMappings(ports []types.ServicePortConfig) []ecs.TaskDefinition_PortMa
 		return nil
 	}
 	m := []ecs.TaskDefinition_PortMapping{}
+	seen := map[string]bool{}
 	for _, p := range ports {
+		// awsvpc network mode requires host port to match the container port, the load balancer
+		// maps the published port
+		key := fmt.Sprintf("%d/%s", p.Target, p.Protocol)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
 		m = append(m, ecs.TaskDefinition_PortMapping{
 			ContainerPort: int(p.Target),
-			HostPort:      int(p.Published),
+			HostPort:      int(p.Target),
 			Protocol:      p.Protocol,
 		})
 	}
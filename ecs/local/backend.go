@@ -75,3 +75,8 @@ func (e ecsLocalSimulation) ComposeService() compose.Service {
 func (e ecsLocalSimulation) ResourceService() resources.Service {
 	return nil
 }
+
+func (e ecsLocalSimulation) Ping(ctx context.Context) error {
+	_, err := e.moby.Ping(ctx)
+	return err
+}
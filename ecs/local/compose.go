@@ -41,7 +41,7 @@ import (
 	"golang.org/x/mod/semver"
 )
 
-func (e ecsLocalSimulation) Up(ctx context.Context, project *types.Project, detach bool) error {
+func (e ecsLocalSimulation) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
 	cmd := exec.Command("docker-compose", "version", "--short")
 	b := bytes.Buffer{}
 	b.WriteString("v")
@@ -161,7 +161,28 @@ services:
 	return cmd.Run()
 }
 
-func (e ecsLocalSimulation) Logs(ctx context.Context, projectName string, w io.Writer) error {
+func (e ecsLocalSimulation) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose up")
+}
+
+func (e ecsLocalSimulation) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	return nil, errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose down")
+}
+
+func (e ecsLocalSimulation) Pull(ctx context.Context, project *types.Project) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose pull")
+}
+
+func (e ecsLocalSimulation) Logs(ctx context.Context, projectName string, w io.Writer, options compose.LogOptions) error {
+	if options.Filter != "" {
+		return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose logs with grep to filter logs")
+	}
+	if options.Since != "" {
+		return errors.Wrap(errdefs.ErrNotImplemented, "use docker logs --since")
+	}
+	if options.Until != "" {
+		return errors.Wrap(errdefs.ErrNotImplemented, "use docker logs --until")
+	}
 	list, err := e.moby.ContainerList(ctx, types2.ContainerListOptions{
 		Filters: filters.NewArgs(filters.Arg("label", "com.docker.compose.project="+projectName)),
 	})
@@ -181,7 +202,12 @@ func (e ecsLocalSimulation) Logs(ctx context.Context, projectName string, w io.W
 	if err != nil {
 		return err
 	}
-	cmd := exec.Command("docker-compose", "--context", "default", "--project-name", projectName, "-f", "-", "logs", "-f")
+	args := []string{"--context", "default", "--project-name", projectName, "-f", "-", "logs", "-f"}
+	if options.Tail != "" {
+		args = append(args, "--tail", options.Tail)
+	}
+	args = append(args, options.Services...)
+	cmd := exec.Command("docker-compose", args...)
 	cmd.Stdin = strings.NewReader(string(marshal))
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -194,3 +220,61 @@ func (e ecsLocalSimulation) Ps(ctx context.Context, projectName string) ([]compo
 func (e ecsLocalSimulation) List(ctx context.Context, projectName string) ([]compose.Stack, error) {
 	return nil, errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose ls")
 }
+
+func (e ecsLocalSimulation) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose run")
+}
+
+func (e ecsLocalSimulation) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose exec")
+}
+
+func (e ecsLocalSimulation) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker attach")
+}
+
+func (e ecsLocalSimulation) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	return nil, errors.Wrap(errdefs.ErrNotImplemented, "use docker inspect")
+}
+
+func (e ecsLocalSimulation) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	return nil, errors.Wrap(errdefs.ErrNotImplemented, "use docker wait")
+}
+
+func (e ecsLocalSimulation) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker stats")
+}
+
+func (e ecsLocalSimulation) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose with bind mounts")
+}
+
+func (e ecsLocalSimulation) Stop(ctx context.Context, project *types.Project) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose stop")
+}
+
+func (e ecsLocalSimulation) Kill(ctx context.Context, projectName string) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose kill")
+}
+
+func (e ecsLocalSimulation) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	return errors.Wrap(errdefs.ErrNotImplemented, "use docker-compose up --scale")
+}
+
+func (e ecsLocalSimulation) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	return compose.ImageRepository{}, errors.Wrap(errdefs.ErrNotImplemented, "images are built by docker-compose up --build")
+}
+
+func (e ecsLocalSimulation) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	// the project is run by docker-compose, which doesn't support the develop section
+	supported := []string{}
+	for _, f := range compose.Features {
+		if f != compose.FeatureDevelop {
+			supported = append(supported, f)
+		}
+	}
+	return compose.Capabilities{
+		Backend:   "ecs-local",
+		Supported: supported,
+	}, nil
+}
@@ -19,12 +19,45 @@ package ecs
 import (
 	"context"
 	"io"
+	"time"
 
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
 	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/utils"
 )
 
-func (b *ecsAPIService) Logs(ctx context.Context, project string, w io.Writer) error {
-	consumer := formatter.NewLogConsumer(w)
-	err := b.aws.GetLogs(ctx, project, consumer.Log)
+func (b *ecsAPIService) Logs(ctx context.Context, project string, w io.Writer, options compose.LogOptions) error {
+	if options.Tail != "" && options.Tail != "all" {
+		return errors.Wrap(errdefs.ErrNotImplemented, "--tail is not supported by ECS, CloudWatch logs are streamed from the start")
+	}
+	var since, until time.Time
+	now := time.Now()
+	if options.Since != "" {
+		t, err := compose.ParseLogTime(options.Since, now)
+		if err != nil {
+			return err
+		}
+		since = t
+	}
+	if options.Until != "" {
+		t, err := compose.ParseLogTime(options.Until, now)
+		if err != nil {
+			return err
+		}
+		until = t
+	}
+	consumer, err := formatter.NewFilteredLogConsumer(w, options.Filter)
+	if err != nil {
+		return err
+	}
+	err = b.aws.GetLogs(ctx, project, since, until, func(service, container, message string) {
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, service) {
+			return
+		}
+		consumer.Log(service, container, message)
+	})
 	return err
 }
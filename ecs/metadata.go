@@ -0,0 +1,64 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// instanceMetadata is the EC2 instance metadata service, which provides instance profile
+// credentials and the region of the instance when running on EC2
+type instanceMetadata interface {
+	Available() bool
+	Region() (string, error)
+}
+
+var getInstanceMetadata = func() instanceMetadata {
+	sess, err := session.NewSession(&aws.Config{
+		// don't slow down commands when not running on EC2
+		HTTPClient: &http.Client{Timeout: time.Second},
+		MaxRetries: aws.Int(0),
+	})
+	if err != nil {
+		return nil
+	}
+	return ec2metadata.New(sess)
+}
+
+// runningOnEC2 checks whether instance profile credentials are available
+func runningOnEC2() bool {
+	metadata := getInstanceMetadata()
+	return metadata != nil && metadata.Available()
+}
+
+// getInstanceRegion returns the region of the EC2 instance, if any
+func getInstanceRegion() string {
+	metadata := getInstanceMetadata()
+	if metadata == nil || !metadata.Available() {
+		return ""
+	}
+	region, err := metadata.Region()
+	if err != nil {
+		return ""
+	}
+	return region
+}
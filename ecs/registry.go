@@ -0,0 +1,44 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// EnsureImageRepository creates, or reuses, the ECR repository named after the project and service
+func (b *ecsAPIService) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	// ECR repository names are lower case
+	name := strings.ToLower(fmt.Sprintf("%s/%s", projectName, service))
+	uri, err := b.aws.EnsureRepository(ctx, name, projectName)
+	if err != nil {
+		return compose.ImageRepository{}, err
+	}
+	username, password, err := b.aws.GetRegistryCredentials(ctx)
+	if err != nil {
+		return compose.ImageRepository{}, err
+	}
+	return compose.ImageRepository{
+		Name:     uri,
+		Username: username,
+		Password: password,
+	}, nil
+}
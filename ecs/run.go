@@ -0,0 +1,91 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package ecs
+
+import (
+	"context"
+
+	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (b *ecsAPIService) Pull(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Stop(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Kill(ctx context.Context, projectName string) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (b *ecsAPIService) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+var capabilities = compose.Capabilities{
+	Backend: "ecs",
+	Supported: []string{
+		compose.FeatureBuild,
+		compose.FeatureGPUs,
+		compose.FeatureHealthcheck,
+		compose.FeatureNetworks,
+		compose.FeatureScale,
+		compose.FeatureSecrets,
+		compose.FeatureVolumes,
+	},
+}
+
+func (b *ecsAPIService) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	return capabilities, nil
+}
@@ -19,6 +19,7 @@ package ecs
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -42,6 +43,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
 	"github.com/aws/aws-sdk-go/service/efs"
@@ -75,6 +78,7 @@ type sdk struct {
 	SSM      ssmiface.SSMAPI
 	AG       autoscalingiface.AutoScalingAPI
 	S3       s3iface.S3API
+	ECR      ecriface.ECRAPI
 	uploader *s3manager.Uploader
 }
 
@@ -97,6 +101,7 @@ func newSDK(sess *session.Session) sdk {
 		SSM:      ssm.New(sess),
 		AG:       autoscaling.New(sess),
 		S3:       s3.New(sess),
+		ECR:      ecr.New(sess),
 		uploader: s3manager.NewUploader(sess),
 	}
 }
@@ -118,6 +123,19 @@ func (s sdk) CheckRequirements(ctx context.Context, region string) error {
 	return nil
 }
 
+func (s sdk) CheckCredentials(ctx context.Context) error {
+	_, err := s.ECS.ListClustersWithContext(ctx, &ecs.ListClustersInput{
+		MaxResults: aws.Int64(1),
+	})
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case "ExpiredToken", "ExpiredTokenException", "InvalidClientTokenId", "UnrecognizedClientException", "NoCredentialProviders":
+			return errors.Wrap(errdefs.ErrLoginRequired, aerr.Message())
+		}
+	}
+	return err
+}
+
 func (s sdk) ResolveCluster(ctx context.Context, nameOrArn string) (awsResource, error) {
 	logrus.Debug("CheckRequirements if cluster was already created: ", nameOrArn)
 	clusters, err := s.ECS.DescribeClustersWithContext(ctx, &ecs.DescribeClustersInput{
@@ -369,6 +387,38 @@ func (s sdk) CreateChangeSet(ctx context.Context, name string, region string, te
 	return changeset, err
 }
 
+func (s sdk) DescribeChangeSet(ctx context.Context, changeset string) ([]compose.ResourceChange, error) {
+	var (
+		changes []compose.ResourceChange
+		token   *string
+	)
+	for {
+		desc, err := s.CF.DescribeChangeSetWithContext(ctx, &cloudformation.DescribeChangeSetInput{
+			ChangeSetName: aws.String(changeset),
+			NextToken:     token,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range desc.Changes {
+			resource := change.ResourceChange
+			if resource == nil {
+				continue
+			}
+			changes = append(changes, compose.ResourceChange{
+				Action:      aws.StringValue(resource.Action),
+				Type:        aws.StringValue(resource.ResourceType),
+				Name:        aws.StringValue(resource.LogicalResourceId),
+				Replacement: aws.StringValue(resource.Replacement) == cloudformation.ReplacementTrue,
+			})
+		}
+		if desc.NextToken == nil {
+			return changes, nil
+		}
+		token = desc.NextToken
+	}
+}
+
 func (s sdk) UpdateStack(ctx context.Context, changeset string) error {
 	desc, err := s.CF.DescribeChangeSetWithContext(ctx, &cloudformation.DescribeChangeSetInput{
 		ChangeSetName: aws.String(changeset),
@@ -377,7 +427,7 @@ func (s sdk) UpdateStack(ctx context.Context, changeset string) error {
 		return err
 	}
 
-	if strings.HasPrefix(aws.StringValue(desc.StatusReason), "The submitted information didn't contain changes.") {
+	if strings.HasPrefix(aws.StringValue(desc.StatusReason), noChanges) {
 		return nil
 	}
 
@@ -387,6 +437,9 @@ func (s sdk) UpdateStack(ctx context.Context, changeset string) error {
 	return err
 }
 
+// noChanges is the reason of change sets failing as they don't change the stack
+const noChanges = "The submitted information didn't contain changes."
+
 const (
 	stackCreate = iota
 	stackUpdate
@@ -741,9 +794,16 @@ func (s sdk) DeleteSecret(ctx context.Context, id string, recover bool) error {
 	return err
 }
 
-func (s sdk) GetLogs(ctx context.Context, name string, consumer func(service, container, message string)) error {
+func (s sdk) GetLogs(ctx context.Context, name string, since time.Time, until time.Time, consumer func(service, container, message string)) error {
 	logGroup := fmt.Sprintf("/docker-compose/%s", name)
-	var startTime int64
+	startTime := aws.Int64(0)
+	if !since.IsZero() {
+		startTime = aws.Int64(since.UnixNano() / int64(time.Millisecond))
+	}
+	var endTime *int64
+	if !until.IsZero() {
+		endTime = aws.Int64(until.UnixNano() / int64(time.Millisecond))
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -755,7 +815,8 @@ func (s sdk) GetLogs(ctx context.Context, name string, consumer func(service, co
 				events, err := s.CW.FilterLogEvents(&cloudwatchlogs.FilterLogEventsInput{
 					LogGroupName: aws.String(logGroup),
 					NextToken:    token,
-					StartTime:    aws.Int64(startTime),
+					StartTime:    startTime,
+					EndTime:      endTime,
 				})
 				if err != nil {
 					return err
@@ -769,9 +830,13 @@ func (s sdk) GetLogs(ctx context.Context, name string, consumer func(service, co
 				for _, event := range events.Events {
 					p := strings.Split(aws.StringValue(event.LogStreamName), "/")
 					consumer(p[1], p[2], aws.StringValue(event.Message))
-					startTime = *event.IngestionTime
+					startTime = event.IngestionTime
 				}
 			}
+			if !until.IsZero() && until.Before(time.Now()) {
+				// no more events will be ingested before the --until bound
+				return nil
+			}
 		}
 		time.Sleep(500 * time.Millisecond)
 	}
@@ -853,6 +918,29 @@ func (s sdk) getURLWithPortMapping(ctx context.Context, targetGroupArns []string
 		}
 		return nil
 	}
+	// listeners publish target groups on their own port, which may differ from the container port
+	listenerPorts := map[string]int64{}
+	for _, lb := range lbs.LoadBalancers {
+		listeners, err := s.ELB.DescribeListenersWithContext(ctx, &elbv2.DescribeListenersInput{
+			LoadBalancerArn: lb.LoadBalancerArn,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range listeners.Listeners {
+			for _, action := range l.DefaultActions {
+				if action.TargetGroupArn != nil {
+					listenerPorts[aws.StringValue(action.TargetGroupArn)] = aws.Int64Value(l.Port)
+				}
+				if action.ForwardConfig != nil {
+					for _, tg := range action.ForwardConfig.TargetGroups {
+						listenerPorts[aws.StringValue(tg.TargetGroupArn)] = aws.Int64Value(l.Port)
+					}
+				}
+			}
+		}
+	}
+
 	loadBalancers := []compose.PortPublisher{}
 	for _, tg := range groups.TargetGroups {
 		for _, lbarn := range tg.LoadBalancerArns {
@@ -860,10 +948,14 @@ func (s sdk) getURLWithPortMapping(ctx context.Context, targetGroupArns []string
 			if lb == nil {
 				continue
 			}
+			published, ok := listenerPorts[aws.StringValue(tg.TargetGroupArn)]
+			if !ok {
+				published = aws.Int64Value(tg.Port)
+			}
 			loadBalancers = append(loadBalancers, compose.PortPublisher{
 				URL:           aws.StringValue(lb.DNSName),
 				TargetPort:    int(aws.Int64Value(tg.Port)),
-				PublishedPort: int(aws.Int64Value(tg.Port)),
+				PublishedPort: int(published),
 				Protocol:      aws.StringValue(tg.Protocol),
 			})
 
@@ -1099,3 +1191,51 @@ func (s sdk) DeleteFileSystem(ctx context.Context, id string) error {
 	})
 	return err
 }
+
+func (s sdk) EnsureRepository(ctx context.Context, name string, project string) (string, error) {
+	repositories, err := s.ECR.DescribeRepositoriesWithContext(ctx, &ecr.DescribeRepositoriesInput{
+		RepositoryNames: aws.StringSlice([]string{name}),
+	})
+	if err == nil && len(repositories.Repositories) > 0 {
+		return aws.StringValue(repositories.Repositories[0].RepositoryUri), nil
+	}
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != ecr.ErrCodeRepositoryNotFoundException {
+			return "", err
+		}
+	}
+	logrus.Debug("Creating ECR repository ", name)
+	created, err := s.ECR.CreateRepositoryWithContext(ctx, &ecr.CreateRepositoryInput{
+		RepositoryName: aws.String(name),
+		Tags: []*ecr.Tag{
+			{
+				Key:   aws.String(compose.ProjectTag),
+				Value: aws.String(project),
+			},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(created.Repository.RepositoryUri), nil
+}
+
+func (s sdk) GetRegistryCredentials(ctx context.Context) (string, string, error) {
+	token, err := s.ECR.GetAuthorizationTokenWithContext(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return "", "", err
+	}
+	if len(token.AuthorizationData) == 0 {
+		return "", "", errors.New("no ECR authorization token returned")
+	}
+	// the token is the base64 encoding of user:password
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(token.AuthorizationData[0].AuthorizationToken))
+	if err != nil {
+		return "", "", err
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", errors.New("invalid ECR authorization token")
+	}
+	return parts[0], parts[1], nil
+}
@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
 )
 
-func (b *ecsAPIService) Up(ctx context.Context, project *types.Project, detach bool) error {
+func (b *ecsAPIService) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
 	err := b.aws.CheckRequirements(ctx, b.Region)
 	if err != nil {
 		return err
@@ -44,9 +47,12 @@ func (b *ecsAPIService) Up(ctx context.Context, project *types.Project, detach b
 	operation := stackCreate
 	if update {
 		operation = stackUpdate
-		changeset, err := b.aws.CreateChangeSet(ctx, project.Name, b.Region, template)
-		if err != nil {
-			return err
+		changeset := options.Plan
+		if changeset == "" {
+			changeset, err = b.aws.CreateChangeSet(ctx, project.Name, b.Region, template)
+			if err != nil {
+				return err
+			}
 		}
 		err = b.aws.UpdateStack(ctx, changeset)
 		if err != nil {
@@ -58,7 +64,7 @@ func (b *ecsAPIService) Up(ctx context.Context, project *types.Project, detach b
 			return err
 		}
 	}
-	if detach {
+	if options.Detach {
 		return nil
 	}
 	signalChan := make(chan os.Signal, 1)
@@ -72,3 +78,27 @@ func (b *ecsAPIService) Up(ctx context.Context, project *types.Project, detach b
 	err = b.WaitStackCompletion(ctx, project.Name, operation)
 	return err
 }
+
+// UpPlan creates the change set of an existing stack, so its changes can be reviewed before it is executed
+func (b *ecsAPIService) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	update, err := b.aws.StackExists(ctx, project.Name)
+	if err != nil || !update {
+		return compose.ChangePlan{}, err
+	}
+	template, err := b.Convert(ctx, project, "yaml")
+	if err != nil {
+		return compose.ChangePlan{}, err
+	}
+	changeset, err := b.aws.CreateChangeSet(ctx, project.Name, b.Region, template)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), noChanges) {
+			return compose.ChangePlan{ID: changeset}, nil
+		}
+		return compose.ChangePlan{}, err
+	}
+	changes, err := b.aws.DescribeChangeSet(ctx, changeset)
+	if err != nil {
+		return compose.ChangePlan{}, err
+	}
+	return compose.ChangePlan{ID: changeset, Changes: changes}, nil
+}
@@ -30,4 +30,6 @@ const (
 	extensionRole            = "x-aws-role"
 	extensionManagedPolicies = "x-aws-policies"
 	extensionAutoScaling     = "x-aws-autoscaling"
+	extensionSubnets         = "x-aws-subnets"
+	extensionAssignPublicIP  = "x-aws-assign_public_ip"
 )
@@ -24,6 +24,12 @@ const (
 	//ExitCodeLoginRequired exit code when command cannot execute because it requires cloud login
 	// This will be used by VSCode to detect when creating context if the user needs to login first
 	ExitCodeLoginRequired = 5
+	// ExitCodeNotFound exit code when the command targets a resource that does not exist
+	ExitCodeNotFound = 6
+	// ExitCodeConflict exit code when the command conflicts with an existing resource
+	ExitCodeConflict = 7
+	// ExitCodeQuotaExceeded exit code when the backend refused the command because of a quota
+	ExitCodeQuotaExceeded = 8
 )
 
 var (
@@ -31,6 +37,12 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrAlreadyExists is returned when an object already exists
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict is returned when an operation conflicts with the current
+	// state of an object, e.g. a resource still in use
+	ErrConflict = errors.New("conflict")
+	// ErrQuotaExceeded is returned when the backend refuses an operation
+	// because a quota or limit has been reached
+	ErrQuotaExceeded = errors.New("quota exceeded")
 	// ErrForbidden is returned when an operation is not permitted
 	ErrForbidden = errors.New("forbidden")
 	// ErrUnknown is returned when the error type is unmapped
@@ -51,6 +63,17 @@ var (
 	ErrWrongContextType = errors.New("wrong context type")
 )
 
+// StatusError is returned when a command run in a container, with exec or run, exits with a
+// non zero code. The CLI exits with the same code.
+type StatusError struct {
+	Status     string
+	StatusCode int
+}
+
+func (e StatusError) Error() string {
+	return e.Status
+}
+
 // IsNotFoundError returns true if the unwrapped error is ErrNotFound
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
@@ -61,6 +84,16 @@ func IsAlreadyExistsError(err error) bool {
 	return errors.Is(err, ErrAlreadyExists)
 }
 
+// IsConflictError returns true if the unwrapped error is ErrConflict or ErrAlreadyExists
+func IsConflictError(err error) bool {
+	return errors.Is(err, ErrConflict) || errors.Is(err, ErrAlreadyExists)
+}
+
+// IsQuotaExceededError returns true if the unwrapped error is ErrQuotaExceeded
+func IsQuotaExceededError(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded)
+}
+
 // IsForbiddenError returns true if the unwrapped error is ErrForbidden
 func IsForbiddenError(err error) bool {
 	return errors.Is(err, ErrForbidden)
@@ -85,3 +118,52 @@ func IsErrParsingFailed(err error) bool {
 func IsErrCanceled(err error) bool {
 	return errors.Is(err, ErrCanceled)
 }
+
+// Code returns the machine-readable code of the error, so wrappers can branch
+// on failures without parsing messages
+func Code(err error) string {
+	switch {
+	case IsNotFoundError(err):
+		return "NotFound"
+	case IsConflictError(err):
+		return "Conflict"
+	case errors.Is(err, ErrLoginRequired):
+		return "LoginRequired"
+	case errors.Is(err, ErrLoginFailed):
+		return "LoginFailed"
+	case IsQuotaExceededError(err):
+		return "QuotaExceeded"
+	case IsForbiddenError(err):
+		return "Forbidden"
+	case IsErrNotImplemented(err):
+		return "NotImplemented"
+	case IsErrParsingFailed(err):
+		return "ParsingFailed"
+	case IsErrCanceled(err):
+		return "Canceled"
+	default:
+		return "Unknown"
+	}
+}
+
+// ExitCode returns the exit code the CLI uses for the error
+func ExitCode(err error) int {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode
+	}
+	switch {
+	case errors.Is(err, ErrLoginRequired):
+		return ExitCodeLoginRequired
+	case IsNotFoundError(err):
+		return ExitCodeNotFound
+	case IsConflictError(err):
+		return ExitCodeConflict
+	case IsQuotaExceededError(err):
+		return ExitCodeQuotaExceeded
+	case IsErrCanceled(err):
+		return 130
+	default:
+		return 1
+	}
+}
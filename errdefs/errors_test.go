@@ -50,3 +50,30 @@ func TestIsUnknown(t *testing.T) {
 
 	assert.Assert(t, !IsUnknownError(errors.New("another error")))
 }
+
+func TestIsConflict(t *testing.T) {
+	assert.Assert(t, IsConflictError(errors.Wrap(ErrConflict, `volume "data" is in use`)))
+	assert.Assert(t, IsConflictError(errors.Wrap(ErrAlreadyExists, `object "name"`)))
+
+	assert.Assert(t, !IsConflictError(errors.New("another error")))
+}
+
+func TestCodeAndExitCode(t *testing.T) {
+	testCases := []struct {
+		err      error
+		code     string
+		exitCode int
+	}{
+		{errors.Wrap(ErrNotFound, `object "name"`), "NotFound", ExitCodeNotFound},
+		{errors.Wrap(ErrAlreadyExists, `object "name"`), "Conflict", ExitCodeConflict},
+		{errors.Wrap(ErrLoginRequired, "azure"), "LoginRequired", ExitCodeLoginRequired},
+		{errors.Wrap(ErrQuotaExceeded, "cores"), "QuotaExceeded", ExitCodeQuotaExceeded},
+		{errors.Wrap(ErrNotImplemented, "exec"), "NotImplemented", 1},
+		{errors.New("another error"), "Unknown", 1},
+		{errors.Wrap(StatusError{Status: "exited with code 3", StatusCode: 3}, "exec"), "Unknown", 3},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, Code(tc.err), tc.code)
+		assert.Equal(t, ExitCode(tc.err), tc.exitCode)
+	}
+}
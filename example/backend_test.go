@@ -0,0 +1,108 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package example
+
+import (
+	"context"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestComposeLifecycle(t *testing.T) {
+	ctx := context.Background()
+	backend := newAPIService()
+	service := backend.ComposeService()
+
+	replicas := uint64(2)
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "web", Image: "nginx", Deploy: &types.DeployConfig{Replicas: &replicas}},
+			{Name: "db", Image: "postgres"},
+		},
+	}
+	assert.NilError(t, service.Up(ctx, project, compose.UpOptions{Detach: true}))
+
+	status, err := service.Ps(ctx, "demo")
+	assert.NilError(t, err)
+	assert.Equal(t, len(status), 2)
+	assert.Equal(t, status[0].Name, "web")
+	assert.Equal(t, status[0].Replicas, 2)
+	assert.Equal(t, status[1].Name, "db")
+	assert.Equal(t, status[1].Containers[0].ID, "demo_db_1")
+
+	stacks, err := service.List(ctx, "")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, stacks, []compose.Stack{{ID: "demo", Name: "demo", Status: compose.RUNNING}})
+
+	// containers run by compose are listed along the seeded ones
+	list, err := backend.ContainerService().List(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 5)
+
+	exits, err := service.Wait(ctx, "demo", []string{"db"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, exits, []compose.ContainerExit{{Name: "demo_db_1", Service: "db"}})
+
+	assert.NilError(t, service.Down(ctx, "demo"))
+	status, err = service.Ps(ctx, "demo")
+	assert.NilError(t, err)
+	assert.Equal(t, len(status), 0)
+	assert.Assert(t, errdefs.IsNotFoundError(service.Down(ctx, "demo")))
+}
+
+func TestContainersDeterministicState(t *testing.T) {
+	ctx := context.Background()
+	containers := newAPIService().ContainerService()
+
+	list, err := containers.List(ctx, true)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 3)
+
+	assert.Assert(t, errdefs.IsNotFoundError(containers.Stop(ctx, "unknown", nil)))
+	assert.NilError(t, containers.Stop(ctx, "1234", nil))
+	list, err = containers.List(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 1)
+
+	// every backend starts from the same seeded state
+	list, err = newAPIService().ContainerService().List(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 2)
+}
+
+func TestVolumes(t *testing.T) {
+	ctx := context.Background()
+	volumes := newAPIService().VolumeService()
+
+	_, err := volumes.Create(ctx, "data", nil)
+	assert.NilError(t, err)
+	_, err = volumes.Create(ctx, "data", nil)
+	assert.Assert(t, errdefs.IsAlreadyExistsError(err))
+
+	list, err := volumes.List(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, len(list), 1)
+
+	assert.NilError(t, volumes.Delete(ctx, "data", nil))
+	assert.Assert(t, errdefs.IsNotFoundError(volumes.Delete(ctx, "data", nil)))
+}
@@ -0,0 +1,359 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+	"github.com/sanathkr/go-yaml"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/utils"
+)
+
+type composeService struct {
+	state *state
+}
+
+func (cs *composeService) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	for _, service := range project.Services {
+		replicas := 1
+		if service.Deploy != nil && service.Deploy.Replicas != nil {
+			replicas = int(*service.Deploy.Replicas)
+		}
+		for number := 1; number <= replicas; number++ {
+			name := fmt.Sprintf("%s_%s_%d", project.Name, service.Name, number)
+			if c, err := cs.state.container(name); err == nil {
+				c.Image = service.Image
+				c.running = true
+				continue
+			}
+			cs.state.containers = append(cs.state.containers, &exampleContainer{
+				Container: containers.Container{
+					ID:      name,
+					Image:   service.Image,
+					Command: strings.Join(service.Command, " "),
+				},
+				running: true,
+				project: project.Name,
+				service: service.Name,
+				number:  number,
+			})
+		}
+		name := service.Name
+		cs.state.remove(func(c *exampleContainer) bool {
+			return c.project == project.Name && c.service == name && c.number > replicas
+		})
+	}
+	return nil
+}
+
+func (cs *composeService) Pull(ctx context.Context, project *types.Project) error {
+	for _, service := range project.Services {
+		if service.Image == "" {
+			return errors.Errorf("service %q has no image to pull", service.Name)
+		}
+	}
+	return nil
+}
+
+func (cs *composeService) Down(ctx context.Context, project string) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	if len(cs.state.projectContainers(project)) == 0 {
+		return errors.Wrapf(errdefs.ErrNotFound, "project %q", project)
+	}
+	cs.state.remove(func(c *exampleContainer) bool {
+		return c.project == project
+	})
+	return nil
+}
+
+func (cs *composeService) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	resources := []compose.DownResource{}
+	for _, c := range cs.state.projectContainers(projectName) {
+		resources = append(resources, compose.DownResource{
+			Type:   compose.ContainerResource,
+			Name:   c.ID,
+			Remove: true,
+		})
+	}
+	return resources, nil
+}
+
+func (cs *composeService) Ps(ctx context.Context, project string) ([]compose.ServiceStatus, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	var (
+		names    []string
+		services = map[string]*compose.ServiceStatus{}
+	)
+	for _, c := range cs.state.projectContainers(project) {
+		status, ok := services[c.service]
+		if !ok {
+			status = &compose.ServiceStatus{ID: c.service, Name: c.service}
+			services[c.service] = status
+			names = append(names, c.service)
+		}
+		state := "exited"
+		if c.running {
+			state = "running"
+			status.Replicas++
+		}
+		status.Desired++
+		status.Containers = append(status.Containers, compose.ContainerSummary{
+			ID:    c.ID,
+			Name:  c.ID,
+			State: state,
+		})
+	}
+	res := []compose.ServiceStatus{}
+	for _, name := range names {
+		res = append(res, *services[name])
+	}
+	return res, nil
+}
+
+func (cs *composeService) List(ctx context.Context, project string) ([]compose.Stack, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	var (
+		names   []string
+		running = map[string]bool{}
+	)
+	for _, c := range cs.state.containers {
+		if c.project == "" || (project != "" && c.project != project) {
+			continue
+		}
+		if _, ok := running[c.project]; !ok {
+			names = append(names, c.project)
+		}
+		running[c.project] = running[c.project] || c.running
+	}
+	stacks := []compose.Stack{}
+	for _, name := range names {
+		status := compose.RUNNING
+		if !running[name] {
+			status = "Exited"
+		}
+		stacks = append(stacks, compose.Stack{ID: name, Name: name, Status: status})
+	}
+	return stacks, nil
+}
+
+func (cs *composeService) Logs(ctx context.Context, project string, w io.Writer, options compose.LogOptions) error {
+	var filter *regexp.Regexp
+	if options.Filter != "" {
+		re, err := regexp.Compile(options.Filter)
+		if err != nil {
+			return fmt.Errorf("invalid log filter %q: %w", options.Filter, err)
+		}
+		filter = re
+	}
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	for _, c := range cs.state.projectContainers(project) {
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, c.service) {
+			continue
+		}
+		line := fmt.Sprintf("Following logs for container %q", c.ID)
+		if filter != nil && !filter.MatchString(line) {
+			continue
+		}
+		fmt.Fprintf(w, "%s | %s\n", c.service, line)
+	}
+	return nil
+}
+
+func (cs *composeService) Convert(ctx context.Context, project *types.Project, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(project, "", "  ")
+	case "yaml":
+		return yaml.Marshal(project)
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func (cs *composeService) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	var service *types.ServiceConfig
+	for i, s := range project.Services {
+		if s.Name == opts.Service {
+			service = &project.Services[i]
+		}
+	}
+	if service == nil {
+		return errors.Wrapf(errdefs.ErrNotFound, "service %q", opts.Service)
+	}
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	number := 1
+	for _, c := range cs.state.projectContainers(project.Name) {
+		if c.service == service.Name && c.number >= number {
+			number = c.number + 1
+		}
+	}
+	command := service.Command
+	if len(opts.Command) > 0 {
+		command = opts.Command
+	}
+	cs.state.containers = append(cs.state.containers, &exampleContainer{
+		Container: containers.Container{
+			ID:      fmt.Sprintf("%s_%s_run_%d", project.Name, service.Name, number),
+			Image:   service.Image,
+			Command: strings.Join(command, " "),
+		},
+		// one-off containers of the example backend exit as soon as they are started
+		running: false,
+		project: project.Name,
+		service: service.Name,
+		number:  number,
+	})
+	return nil
+}
+
+func (cs *composeService) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	c, err := cs.replica(projectName, opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+	if opts.Stdout != nil {
+		fmt.Fprintf(opts.Stdout, "Executing command %q on container %q\n", strings.Join(opts.Command, " "), c.ID)
+	}
+	return nil
+}
+
+func (cs *composeService) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	c, err := cs.replica(projectName, opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+	if opts.Stdout != nil {
+		fmt.Fprintf(opts.Stdout, "Attaching to container %q\n", c.ID)
+	}
+	return nil
+}
+
+func (cs *composeService) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	res := []compose.ContainerInspect{}
+	for _, c := range cs.state.projectContainers(projectName) {
+		if c.service != service {
+			continue
+		}
+		state := "exited"
+		if c.running {
+			state = "running"
+		}
+		res = append(res, compose.ContainerInspect{ID: c.ID, Name: c.ID, State: state})
+	}
+	if len(res) == 0 {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "service %q", service)
+	}
+	return res, nil
+}
+
+func (cs *composeService) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	exits := []compose.ContainerExit{}
+	for _, c := range cs.state.projectContainers(projectName) {
+		if len(services) > 0 && !utils.StringContains(services, c.service) {
+			continue
+		}
+		// containers of the example backend exit successfully as soon as they are waited for
+		c.running = false
+		exits = append(exits, compose.ContainerExit{Name: c.ID, Service: c.service})
+	}
+	return exits, nil
+}
+
+func (cs *composeService) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	status, err := cs.Ps(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	stats := []compose.ServiceStats{}
+	for _, s := range status {
+		stats = append(stats, compose.ServiceStats{Service: s.Name, Replicas: s.Replicas})
+	}
+	if options.Consumer != nil {
+		options.Consumer(stats)
+	}
+	return nil
+}
+
+// replica returns the running container of a service replica, indexed from 1
+func (cs *composeService) replica(project string, service string, index int) (*exampleContainer, error) {
+	if index == 0 {
+		index = 1
+	}
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	for _, c := range cs.state.projectContainers(project) {
+		if c.service == service && c.number == index && c.running {
+			return c, nil
+		}
+	}
+	return nil, errors.Wrapf(errdefs.ErrNotFound, "service %q replica %d is not running", service, index)
+}
+
+func (cs *composeService) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) Stop(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) Kill(ctx context.Context, projectName string) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	return compose.ImageRepository{}, errdefs.ErrNotImplemented
+}
+
+func (cs *composeService) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	return compose.Capabilities{
+		Backend:   "example",
+		Supported: []string{},
+	}, nil
+}
@@ -0,0 +1,193 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package example
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type containerService struct {
+	state *state
+}
+
+func (cs *containerService) List(ctx context.Context, all bool) ([]containers.Container, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	result := []containers.Container{}
+	for _, c := range cs.state.containers {
+		if c.running || all {
+			result = append(result, c.Container)
+		}
+	}
+	return result, nil
+}
+
+func (cs *containerService) Inspect(ctx context.Context, id string) (containers.Container, error) {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	c, err := cs.state.container(id)
+	if err != nil {
+		return containers.Container{}, err
+	}
+	return c.Container, nil
+}
+
+func (cs *containerService) Run(ctx context.Context, r containers.ContainerConfig) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	if _, err := cs.state.container(r.ID); err == nil {
+		return errors.Wrapf(errdefs.ErrAlreadyExists, "container %q", r.ID)
+	}
+	cs.state.containers = append(cs.state.containers, &exampleContainer{
+		Container: containers.Container{
+			ID:      r.ID,
+			Image:   r.Image,
+			Command: strings.Join(r.Command, " "),
+			Ports:   r.Ports,
+		},
+		running: true,
+	})
+	fmt.Printf("Running container %q with name %q\n", r.Image, r.ID)
+	return nil
+}
+
+func (cs *containerService) Start(ctx context.Context, containerID string) error {
+	return cs.setRunning(containerID, true)
+}
+
+func (cs *containerService) Stop(ctx context.Context, containerName string, timeout *uint32) error {
+	return cs.setRunning(containerName, false)
+}
+
+func (cs *containerService) Kill(ctx context.Context, containerName string, signal string) error {
+	return cs.setRunning(containerName, false)
+}
+
+func (cs *containerService) setRunning(id string, running bool) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	c, err := cs.state.container(id)
+	if err != nil {
+		return err
+	}
+	c.running = running
+	return nil
+}
+
+func (cs *containerService) Exec(ctx context.Context, name string, request containers.ExecRequest) error {
+	if err := cs.checkRunning(name); err != nil {
+		return err
+	}
+	fmt.Printf("Executing command %q on container %q", request.Command, name)
+	return nil
+}
+
+func (cs *containerService) Logs(ctx context.Context, containerName string, request containers.LogsRequest) error {
+	if _, err := cs.Inspect(ctx, containerName); err != nil {
+		return err
+	}
+	fmt.Fprintf(request.Writer, "Following logs for container %q", containerName)
+	return nil
+}
+
+func (cs *containerService) Delete(ctx context.Context, id string, request containers.DeleteRequest) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	c, err := cs.state.container(id)
+	if err != nil {
+		return err
+	}
+	if c.running && !request.Force {
+		return errors.Wrapf(errdefs.ErrForbidden, "container %q is running", id)
+	}
+	cs.state.remove(func(c *exampleContainer) bool {
+		return c.ID == id
+	})
+	fmt.Printf("Deleting container %q with force = %t\n", id, request.Force)
+	return nil
+}
+
+func (cs *containerService) Checkpoint(ctx context.Context, id string, request containers.CheckpointRequest) error {
+	if err := cs.checkRunning(id); err != nil {
+		return err
+	}
+	fmt.Printf("Checkpointing container %q as %q\n", id, request.Name)
+	return nil
+}
+
+func (cs *containerService) ListCheckpoints(ctx context.Context, id string, dir string) ([]containers.Checkpoint, error) {
+	return []containers.Checkpoint{{Name: "checkpoint1"}}, nil
+}
+
+func (cs *containerService) Restore(ctx context.Context, id string, request containers.RestoreRequest) error {
+	fmt.Printf("Restoring container %q from %q\n", id, request.Checkpoint)
+	return cs.setRunning(id, true)
+}
+
+func (cs *containerService) Commit(ctx context.Context, id string, request containers.CommitRequest) (string, error) {
+	if _, err := cs.Inspect(ctx, id); err != nil {
+		return "", err
+	}
+	fmt.Printf("Committing container %q as %q\n", id, request.Reference)
+	return "sha256:0123456789abcdef", nil
+}
+
+func (cs *containerService) Wait(ctx context.Context, id string) (int64, error) {
+	// containers of the example backend exit successfully as soon as they are waited for
+	return 0, cs.setRunning(id, false)
+}
+
+func (cs *containerService) Diff(ctx context.Context, id string) ([]containers.FilesystemChange, error) {
+	if _, err := cs.Inspect(ctx, id); err != nil {
+		return nil, err
+	}
+	// every container of the example backend has the same changes
+	return []containers.FilesystemChange{
+		{Kind: containers.ChangeModified, Path: "/etc"},
+		{Kind: containers.ChangeAdded, Path: "/etc/example.conf"},
+		{Kind: containers.ChangeDeleted, Path: "/tmp/example.lock"},
+	}, nil
+}
+
+func (cs *containerService) Export(ctx context.Context, id string, w io.Writer) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs *containerService) Import(ctx context.Context, request containers.ImportRequest) (string, error) {
+	return "", errdefs.ErrNotImplemented
+}
+
+func (cs *containerService) checkRunning(id string) error {
+	cs.state.Lock()
+	defer cs.state.Unlock()
+	c, err := cs.state.container(id)
+	if err != nil {
+		return err
+	}
+	if !c.running {
+		return errors.Errorf("container %q is not running", id)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package example
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/api/volumes"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// exampleContainer is a container of the in-memory backend, either run directly or as a compose service replica
+type exampleContainer struct {
+	containers.Container
+	running bool
+	project string
+	service string
+	number  int
+}
+
+// state holds the containers and volumes of the in-memory backend, ordered so listings are deterministic
+type state struct {
+	sync.Mutex
+	containers []*exampleContainer
+	volumes    []volumes.Volume
+}
+
+func newState() *state {
+	return &state{
+		containers: []*exampleContainer{
+			{
+				Container: containers.Container{
+					ID:       "id",
+					Image:    "nginx",
+					Platform: "Linux",
+					HostConfig: &containers.HostConfig{
+						RestartPolicy: "none",
+					},
+				},
+				running: true,
+			},
+			{
+				Container: containers.Container{
+					ID:    "1234",
+					Image: "alpine",
+				},
+				running: true,
+			},
+			{
+				Container: containers.Container{
+					ID:    "stopped",
+					Image: "nginx",
+				},
+			},
+		},
+	}
+}
+
+// container returns the container with the given ID, the state must be locked
+func (s *state) container(id string) (*exampleContainer, error) {
+	for _, c := range s.containers {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, errors.Wrapf(errdefs.ErrNotFound, "container %q", id)
+}
+
+// remove deletes the containers matching the predicate, the state must be locked
+func (s *state) remove(predicate func(*exampleContainer) bool) {
+	kept := []*exampleContainer{}
+	for _, c := range s.containers {
+		if !predicate(c) {
+			kept = append(kept, c)
+		}
+	}
+	s.containers = kept
+}
+
+// projectContainers lists the containers of a compose project, the state must be locked
+func (s *state) projectContainers(project string) []*exampleContainer {
+	var res []*exampleContainer
+	for _, c := range s.containers {
+		if c.project == project {
+			res = append(res, c)
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package example
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/volumes"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+type volumeService struct {
+	state *state
+}
+
+func (vs *volumeService) List(ctx context.Context) ([]volumes.Volume, error) {
+	vs.state.Lock()
+	defer vs.state.Unlock()
+	return append([]volumes.Volume{}, vs.state.volumes...), nil
+}
+
+func (vs *volumeService) Create(ctx context.Context, name string, options interface{}) (volumes.Volume, error) {
+	vs.state.Lock()
+	defer vs.state.Unlock()
+	for _, v := range vs.state.volumes {
+		if v.ID == name {
+			return volumes.Volume{}, errors.Wrapf(errdefs.ErrAlreadyExists, "volume %q", name)
+		}
+	}
+	volume := volumes.Volume{ID: name, Description: "example volume"}
+	vs.state.volumes = append(vs.state.volumes, volume)
+	return volume, nil
+}
+
+func (vs *volumeService) Delete(ctx context.Context, volumeID string, options interface{}) error {
+	vs.state.Lock()
+	defer vs.state.Unlock()
+	for i, v := range vs.state.volumes {
+		if v.ID == volumeID {
+			vs.state.volumes = append(vs.state.volumes[:i], vs.state.volumes[i+1:]...)
+			return nil
+		}
+	}
+	return errors.Wrapf(errdefs.ErrNotFound, "volume %q", volumeID)
+}
+
+func (vs *volumeService) Inspect(ctx context.Context, volumeID string) (volumes.Volume, error) {
+	vs.state.Lock()
+	defer vs.state.Unlock()
+	for _, v := range vs.state.volumes {
+		if v.ID == volumeID {
+			return v, nil
+		}
+	}
+	return volumes.Volume{}, errors.Wrapf(errdefs.ErrNotFound, "volume %q", volumeID)
+}
@@ -18,6 +18,7 @@ package formatter
 
 import (
 	"fmt"
+	"os"
 	"strconv"
 )
 
@@ -51,6 +52,21 @@ func makeColorFunc(code string) colorFunc {
 
 var loop = make(chan colorFunc)
 
+// NoColor returns whether colored output is disabled by setting the NO_COLOR environment variable, see https://no-color.org
+func NoColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// nextColor returns the next color to render a service with, no color when disabled
+func nextColor() colorFunc {
+	if NoColor() {
+		return func(s string) string {
+			return s
+		}
+	}
+	return <-loop
+}
+
 func init() {
 	colors := map[string]colorFunc{}
 	for i, name := range names {
@@ -23,4 +23,8 @@ const (
 	TemplateLegacyJSON = "{{json.}}"
 	// PRETTY is the constant for default formats on list commands
 	PRETTY = "pretty"
+	// TABLE is an alias of PRETTY, also used as a Go template prefix to align the output in columns
+	TABLE = "table"
+	// FormatUsage describes the --format flag of list commands
+	FormatUsage = "Format the output. Values: [table | json | TEMPLATE]. (Default: table)"
 )
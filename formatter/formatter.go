@@ -30,7 +30,7 @@ import (
 // Print prints formatted lists in different formats
 func Print(toJSON interface{}, format string, outWriter io.Writer, writerFn func(w io.Writer), headers ...string) error {
 	switch strings.ToLower(format) {
-	case PRETTY, "":
+	case PRETTY, TABLE, "":
 		return PrintPrettySection(outWriter, writerFn, headers...)
 	case TemplateLegacyJSON:
 		switch reflect.TypeOf(toJSON).Kind() {
@@ -67,6 +67,9 @@ func Print(toJSON interface{}, format string, outWriter io.Writer, writerFn func
 			_, _ = fmt.Fprintln(outWriter, outJSON)
 		}
 	default:
+		if IsTemplate(format) {
+			return PrintTemplate(toJSON, format, outWriter)
+		}
 		return errors.Wrapf(errdefs.ErrParsingFailed, "format value %q could not be parsed", format)
 	}
 	return nil
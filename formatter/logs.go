@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"regexp"
 	"strconv"
 	"strings"
 )
@@ -33,17 +34,34 @@ func NewLogConsumer(w io.Writer) LogConsumer {
 	}
 }
 
+// NewFilteredLogConsumer creates a new LogConsumer only displaying lines matching the filter regular expression
+func NewFilteredLogConsumer(w io.Writer, filter string) (LogConsumer, error) {
+	consumer := NewLogConsumer(w)
+	if filter == "" {
+		return consumer, nil
+	}
+	re, err := regexp.Compile(filter)
+	if err != nil {
+		return consumer, fmt.Errorf("invalid log filter %q: %w", filter, err)
+	}
+	consumer.filter = re
+	return consumer, nil
+}
+
 // Log formats a log message as received from service/container
 func (l *LogConsumer) Log(service, container, message string) {
 	cf, ok := l.colors[service]
 	if !ok {
-		cf = <-loop
+		cf = nextColor()
 		l.colors[service] = cf
 		l.computeWidth()
 	}
 	prefix := fmt.Sprintf("%-"+strconv.Itoa(l.width)+"s |", service)
 
 	for _, line := range strings.Split(message, "\n") {
+		if l.filter != nil && !l.filter.MatchString(line) {
+			continue
+		}
 		buf := bytes.NewBufferString(fmt.Sprintf("%s %s\n", cf(prefix), line))
 		l.writer.Write(buf.Bytes()) // nolint:errcheck
 	}
@@ -73,6 +91,7 @@ type LogConsumer struct {
 	colors map[string]colorFunc
 	width  int
 	writer io.Writer
+	filter *regexp.Regexp
 }
 
 type splitBuffer struct {
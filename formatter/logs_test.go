@@ -0,0 +1,58 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFilteredLogConsumer(t *testing.T) {
+	b := bytes.Buffer{}
+	consumer, err := NewFilteredLogConsumer(&b, "ERROR|panic")
+	assert.NilError(t, err)
+
+	consumer.Log("web", "web_1", "INFO starting\nERROR cannot bind port")
+	consumer.Log("db", "db_1", "panic: out of memory")
+	consumer.Log("db", "db_1", "ready")
+
+	out := b.String()
+	assert.Equal(t, strings.Count(out, "\n"), 2)
+	assert.Assert(t, strings.Contains(out, "ERROR cannot bind port"))
+	assert.Assert(t, strings.Contains(out, "panic: out of memory"))
+	assert.Assert(t, !strings.Contains(out, "starting"))
+	assert.Assert(t, !strings.Contains(out, "ready"))
+}
+
+func TestFilteredLogConsumerInvalidFilter(t *testing.T) {
+	_, err := NewFilteredLogConsumer(&bytes.Buffer{}, "ERROR(")
+	assert.ErrorContains(t, err, `invalid log filter "ERROR("`)
+}
+
+func TestLogConsumerNoColor(t *testing.T) {
+	os.Setenv("NO_COLOR", "1")    // nolint:errcheck
+	defer os.Unsetenv("NO_COLOR") // nolint:errcheck
+
+	b := bytes.Buffer{}
+	consumer := NewLogConsumer(&b)
+	consumer.Log("web", "web_1", "starting")
+	assert.Equal(t, b.String(), "web    | starting\n")
+}
@@ -17,16 +17,65 @@
 package formatter
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/moby/term"
 )
 
-// PrintPrettySection prints a tabbed section on the writer parameter
+// PrintPrettySection prints a tabbed section on the writer parameter, lines are truncated
+// to the width of the terminal when the writer is one
 func PrintPrettySection(out io.Writer, printer func(writer io.Writer), headers ...string) error {
-	w := tabwriter.NewWriter(out, 20, 1, 3, ' ', 0)
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 20, 1, 3, ' ', 0)
 	_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
 	printer(w)
-	return w.Flush()
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	width := TerminalWidth(out)
+	if width <= 0 {
+		_, err := out.Write(buf.Bytes())
+		return err
+	}
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := fmt.Fprintln(out, Truncate(strings.TrimSuffix(line, "\n"), width)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TerminalWidth returns the current width of the terminal the writer is, 0 when it is not a terminal
+func TerminalWidth(out io.Writer) int {
+	fd, isTerminal := term.GetFdInfo(out)
+	if !isTerminal {
+		return 0
+	}
+	size, err := term.GetWinsize(fd)
+	if err != nil {
+		return 0
+	}
+	return int(size.Width)
+}
+
+// Truncate shortens the text to fit in width, the cut being marked with an ellipsis
+func Truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
 }
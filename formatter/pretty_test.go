@@ -0,0 +1,43 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, Truncate("compose", 10), "compose")
+	assert.Equal(t, Truncate("compose", 7), "compose")
+	assert.Equal(t, Truncate("compose-cli", 8), "compo...")
+	assert.Equal(t, Truncate("compose", 2), "co")
+	assert.Equal(t, Truncate("compose", 0), "")
+}
+
+func TestPrintPrettySectionNotTerminal(t *testing.T) {
+	var b bytes.Buffer
+	err := PrintPrettySection(&b, func(w io.Writer) {
+		_, _ = fmt.Fprintln(w, "web\tnginx\t0.0.0.0:80->80/tcp")
+	}, "SERVICE", "IMAGE", "PORTS")
+	assert.NilError(t, err)
+	assert.Equal(t, b.String(), "SERVICE             IMAGE               PORTS\nweb                 nginx               0.0.0.0:80->80/tcp\n")
+}
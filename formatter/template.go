@@ -0,0 +1,120 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"unicode"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		s, err := ToJSON(v, "", "")
+		return strings.TrimSpace(s), err
+	},
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// IsTemplate returns true if the format is a Go template
+func IsTemplate(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+// PrintTemplate prints every element of a list, or a single object, with a Go template. When
+// prefixed with `table`, the output is aligned in columns under a header naming the fields.
+func PrintTemplate(data interface{}, format string, out io.Writer) error {
+	table := strings.HasPrefix(format, TABLE+" ")
+	if table {
+		format = strings.TrimPrefix(format, TABLE+" ")
+	}
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format) + "\n"
+	tmpl, err := template.New("format").Funcs(templateFuncs).Parse(format)
+	if err != nil {
+		return errors.Wrapf(errdefs.ErrParsingFailed, "template %q could not be parsed: %v", format, err)
+	}
+
+	w := out
+	if table {
+		tw := tabwriter.NewWriter(out, 20, 1, 3, ' ', 0)
+		defer tw.Flush() //nolint:errcheck
+		w = tw
+		var header bytes.Buffer
+		if err := tmpl.Execute(&header, templateHeaders(data)); err == nil {
+			_, _ = header.WriteTo(w)
+		}
+	}
+
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return tmpl.Execute(w, data)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := tmpl.Execute(w, v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateHeaders maps the fields of the listed type to their header, so that the template renders the header row
+func templateHeaders(data interface{}) map[string]string {
+	headers := map[string]string{}
+	t := reflect.TypeOf(data)
+	if t == nil {
+		return headers
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return headers
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); f.PkgPath == "" {
+			headers[f.Name] = headerName(f.Name)
+		}
+	}
+	return headers
+}
+
+// headerName turns a CamelCase field name into an upper case header, i.e. `DockerEndpoint` into `DOCKER ENDPOINT`
+func headerName(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
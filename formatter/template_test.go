@@ -0,0 +1,78 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package formatter
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+type templateTestStruct struct {
+	Name           string
+	DockerEndpoint string
+	ContainerID    string
+	Ports          []string
+}
+
+func TestPrintTemplate(t *testing.T) {
+	list := []templateTestStruct{
+		{Name: "first", DockerEndpoint: "unix:///var/run/docker.sock", ContainerID: "123", Ports: []string{"80/tcp", "443/tcp"}},
+		{Name: "second", ContainerID: "456"},
+	}
+
+	b := &bytes.Buffer{}
+	assert.NilError(t, Print(list, "{{.Name}} {{join .Ports \",\"}}", b, nil))
+	assert.Equal(t, b.String(), "first 80/tcp,443/tcp\nsecond \n")
+
+	b.Reset()
+	assert.NilError(t, Print(list, "{{ json .Name }}", b, nil))
+	assert.Equal(t, b.String(), "\"first\"\n\"second\"\n")
+
+	b.Reset()
+	assert.NilError(t, Print(list, "table {{.Name}}\\t{{.DockerEndpoint}}\\t{{.ContainerID}}", b, nil))
+	assert.Equal(t, b.String(), `NAME                DOCKER ENDPOINT               CONTAINER ID
+first               unix:///var/run/docker.sock   123
+second                                            456
+`)
+
+	b.Reset()
+	assert.NilError(t, Print(list[0], "{{upper .Name}}", b, nil))
+	assert.Equal(t, b.String(), "FIRST\n")
+
+	err := Print(list, "{{.Name", b, nil)
+	assert.ErrorContains(t, err, "could not be parsed")
+
+	err = Print(list, "yaml", b, nil)
+	assert.ErrorContains(t, err, `format value "yaml" could not be parsed`)
+}
+
+func TestPrintTable(t *testing.T) {
+	b := &bytes.Buffer{}
+	assert.NilError(t, Print([]templateTestStruct{}, TABLE, b, func(w io.Writer) {}, "NAME", "STATUS"))
+	assert.Equal(t, b.String(), "NAME                STATUS\n")
+}
+
+func TestHeaderName(t *testing.T) {
+	assert.Equal(t, headerName("Name"), "NAME")
+	assert.Equal(t, headerName("DockerEndpoint"), "DOCKER ENDPOINT")
+	assert.Equal(t, headerName("ContainerID"), "CONTAINER ID")
+	assert.Equal(t, headerName("ID"), "ID")
+	assert.Equal(t, headerName("CPUPercentage"), "CPU PERCENTAGE")
+}
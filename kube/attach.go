@@ -0,0 +1,57 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *kubeAPIService) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	if opts.DetachKeys != "" {
+		return errors.Wrap(errdefs.ErrNotImplemented, "kubectl attach doesn't support detach keys")
+	}
+	pods, err := s.kubectl.projectPods(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	p, err := getReplica(pods, opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+	cmd := s.kubectl.command(ctx, attachArgs(p.Metadata.Name, opts)...)
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	return cmd.Run()
+}
+
+// attachArgs builds the kubectl arguments attaching to the main container of a pod
+func attachArgs(podName string, opts compose.AttachOptions) []string {
+	args := []string{"attach"}
+	if opts.Stdin != nil {
+		args = append(args, "--stdin")
+		if opts.Tty {
+			args = append(args, "--tty")
+		}
+	}
+	return append(args, "pod/"+podName)
+}
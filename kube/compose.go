@@ -0,0 +1,101 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+
+	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *kubeAPIService) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Pull(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Down(ctx context.Context, projectName string) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Ps(ctx context.Context, projectName string) ([]compose.ServiceStatus, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) List(ctx context.Context, projectName string) ([]compose.Stack, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Convert(ctx context.Context, project *types.Project, format string) ([]byte, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	return nil, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Stop(ctx context.Context, project *types.Project) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Kill(ctx context.Context, projectName string) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	return compose.ImageRepository{}, errdefs.ErrNotImplemented
+}
+
+func (s *kubeAPIService) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	return compose.Capabilities{
+		Backend:   "kube",
+		Supported: []string{},
+	}, nil
+}
@@ -0,0 +1,76 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/context/cloud"
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// ContextParams options for creating a Kubernetes context
+type ContextParams struct {
+	Description string
+	KubeConfig  string
+	Namespace   string
+}
+
+var _ cloud.Service = kubeCloudService{}
+
+type kubeCloudService struct{}
+
+func (cs kubeCloudService) Login(ctx context.Context, params interface{}) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs kubeCloudService) Logout(ctx context.Context) error {
+	return errdefs.ErrNotImplemented
+}
+
+func (cs kubeCloudService) CreateContextData(ctx context.Context, params interface{}) (interface{}, string, error) {
+	opts, ok := params.(ContextParams)
+	if !ok {
+		return nil, "", errors.New("could not read Kubernetes ContextParams struct from generic parameter")
+	}
+	kubeContext := store.KubeContext{
+		KubeConfig: opts.KubeConfig,
+		Namespace:  opts.Namespace,
+	}
+	if kubeContext.KubeConfig != "" {
+		// subsequent commands can be run from any directory
+		path, err := filepath.Abs(kubeContext.KubeConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		kubeContext.KubeConfig = path
+	}
+	k := newKubectl(kubeContext)
+	if err := k.checkNamespace(ctx); err != nil {
+		return nil, "", errors.Wrapf(err, "could not access namespace %q on Kubernetes cluster", k.namespace)
+	}
+	description := opts.Description
+	if description == "" {
+		description = fmt.Sprintf("Kubernetes namespace %s", k.namespace)
+	}
+	return kubeContext, description, nil
+}
@@ -0,0 +1,76 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *kubeAPIService) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	if opts.Detach {
+		return errors.Wrap(errdefs.ErrNotImplemented, "kubectl exec can't run detached commands")
+	}
+	pods, err := s.kubectl.projectPods(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	p, err := getReplica(pods, opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+	cmd := s.kubectl.command(ctx, execArgs(p.Metadata.Name, opts)...)
+	cmd.Stdin = opts.Stdin
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	return cmd.Run()
+}
+
+// execArgs builds the kubectl arguments running a command through the pod exec subresource
+func execArgs(podName string, opts compose.ExecOptions) []string {
+	args := []string{"exec", "--stdin"}
+	if opts.Tty {
+		args = append(args, "--tty")
+	}
+	args = append(args, "pod/"+podName, "--")
+	if len(opts.Environment) > 0 {
+		// the exec subresource doesn't set variables, they are set by running the command through env
+		args = append(append(args, "env"), opts.Environment...)
+	}
+	return append(args, opts.Command...)
+}
+
+// getReplica selects the pod of a service by its index, pods being numbered from 1 in name order
+func getReplica(pods []pod, service string, index int) (pod, error) {
+	var replicas []pod
+	for _, p := range pods {
+		if p.Metadata.Labels[serviceLabel] == service {
+			replicas = append(replicas, p)
+		}
+	}
+	if len(replicas) == 0 {
+		return pod{}, errors.Wrapf(errdefs.ErrNotFound, "service %q is not running", service)
+	}
+	if index < 1 || index > len(replicas) {
+		return pod{}, errors.Wrapf(errdefs.ErrNotFound, "service %q is not running pod #%d", service, index)
+	}
+	return replicas[index-1], nil
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func testPod(name string, service string) pod {
+	p := pod{}
+	p.Metadata.Name = name
+	p.Metadata.Labels = map[string]string{serviceLabel: service}
+	return p
+}
+
+func TestGetReplica(t *testing.T) {
+	pods := []pod{
+		testPod("db-0", "db"),
+		testPod("web-5d8f-a", "web"),
+		testPod("web-5d8f-b", "web"),
+	}
+
+	p, err := getReplica(pods, "web", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, p.Metadata.Name, "web-5d8f-b")
+
+	_, err = getReplica(pods, "web", 3)
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+	assert.ErrorContains(t, err, "service \"web\" is not running pod #3")
+
+	_, err = getReplica(pods, "cache", 1)
+	assert.ErrorContains(t, err, "service \"cache\" is not running")
+}
+
+func TestExecArgs(t *testing.T) {
+	args := execArgs("web-5d8f-a", compose.ExecOptions{Command: []string{"sh", "-c", "ls"}})
+	assert.DeepEqual(t, args, []string{"exec", "--stdin", "pod/web-5d8f-a", "--", "sh", "-c", "ls"})
+
+	args = execArgs("web-5d8f-a", compose.ExecOptions{Command: []string{"sh"}, Tty: true})
+	assert.DeepEqual(t, args, []string{"exec", "--stdin", "--tty", "pod/web-5d8f-a", "--", "sh"})
+
+	args = execArgs("web-5d8f-a", compose.ExecOptions{Command: []string{"migrate"}, Environment: []string{"DEBUG=1"}})
+	assert.DeepEqual(t, args, []string{"exec", "--stdin", "pod/web-5d8f-a", "--", "env", "DEBUG=1", "migrate"})
+}
+
+func TestAttachArgs(t *testing.T) {
+	args := attachArgs("web-5d8f-a", compose.AttachOptions{})
+	assert.DeepEqual(t, args, []string{"attach", "pod/web-5d8f-a"})
+
+	args = attachArgs("web-5d8f-a", compose.AttachOptions{Stdin: strings.NewReader(""), Tty: true})
+	assert.DeepEqual(t, args, []string{"attach", "--stdin", "--tty", "pod/web-5d8f-a"})
+}
@@ -0,0 +1,244 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/sanathkr/go-yaml"
+)
+
+// manifest holds the subset of Kubernetes objects fields used to build compose services
+type manifest struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name   string            `yaml:"name"`
+		Labels map[string]string `yaml:"labels"`
+	} `yaml:"metadata"`
+	Spec struct {
+		// Deployment
+		Replicas *uint64 `yaml:"replicas"`
+		Template struct {
+			Metadata struct {
+				Labels map[string]string `yaml:"labels"`
+			} `yaml:"metadata"`
+			Spec struct {
+				Containers []container `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+		// Service
+		Type     string            `yaml:"type"`
+		Selector map[string]string `yaml:"selector"`
+		Ports    []servicePort     `yaml:"ports"`
+	} `yaml:"spec"`
+}
+
+type container struct {
+	Name       string   `yaml:"name"`
+	Image      string   `yaml:"image"`
+	Command    []string `yaml:"command"`
+	Args       []string `yaml:"args"`
+	WorkingDir string   `yaml:"workingDir"`
+	Env        []struct {
+		Name  string `yaml:"name"`
+		Value string `yaml:"value"`
+	} `yaml:"env"`
+	Ports []struct {
+		ContainerPort uint32 `yaml:"containerPort"`
+		Protocol      string `yaml:"protocol"`
+	} `yaml:"ports"`
+}
+
+type servicePort struct {
+	Port       uint32      `yaml:"port"`
+	TargetPort interface{} `yaml:"targetPort"`
+	NodePort   uint32      `yaml:"nodePort"`
+	Protocol   string      `yaml:"protocol"`
+}
+
+// LoadManifests reads the Kubernetes manifests from the given files, or the yaml files of the given directories
+func LoadManifests(paths ...string) ([]byte, error) {
+	var data [][]byte
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			b, err := ioutil.ReadFile(f)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, b)
+		}
+	}
+	return bytes.Join(data, []byte("\n---\n")), nil
+}
+
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ToCompose translates the Deployments and Services of the Kubernetes manifests into compose services.
+// Other kinds of objects are ignored.
+func ToCompose(data []byte) (types.Services, error) {
+	var deployments, services []manifest
+	for _, doc := range splitDocuments(data) {
+		var m manifest
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return nil, err
+		}
+		switch m.Kind {
+		case "Deployment":
+			deployments = append(deployments, m)
+		case "Service":
+			services = append(services, m)
+		}
+	}
+
+	var result types.Services
+	for _, d := range deployments {
+		containers := d.Spec.Template.Spec.Containers
+		for _, c := range containers {
+			name := d.Metadata.Name
+			if len(containers) > 1 {
+				name = fmt.Sprintf("%s-%s", d.Metadata.Name, c.Name)
+			}
+			service := toService(name, c)
+			if d.Spec.Replicas != nil && *d.Spec.Replicas != 1 {
+				service.Deploy = &types.DeployConfig{Replicas: d.Spec.Replicas}
+			}
+			for _, s := range services {
+				if matches(s.Spec.Selector, d.Spec.Template.Metadata.Labels) {
+					service.Ports = append(service.Ports, publishedPorts(s, c)...)
+				}
+			}
+			result = append(result, service)
+		}
+	}
+	return result, nil
+}
+
+func splitDocuments(data []byte) [][]byte {
+	var docs [][]byte
+	for _, doc := range bytes.Split(data, []byte("\n---")) {
+		if len(bytes.TrimSpace(doc)) > 0 {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+func toService(name string, c container) types.ServiceConfig {
+	service := types.ServiceConfig{
+		Name:       name,
+		Image:      c.Image,
+		WorkingDir: c.WorkingDir,
+	}
+	if len(c.Command) > 0 {
+		service.Entrypoint = c.Command
+	}
+	if len(c.Args) > 0 {
+		service.Command = c.Args
+	}
+	if len(c.Env) > 0 {
+		service.Environment = types.MappingWithEquals{}
+		for _, e := range c.Env {
+			value := e.Value
+			service.Environment[e.Name] = &value
+		}
+	}
+	return service
+}
+
+// publishedPorts returns the ports of the container exposed by a NodePort or LoadBalancer service
+func publishedPorts(s manifest, c container) []types.ServicePortConfig {
+	if s.Spec.Type != "NodePort" && s.Spec.Type != "LoadBalancer" {
+		return nil
+	}
+	var ports []types.ServicePortConfig
+	for _, p := range s.Spec.Ports {
+		target := p.Port
+		switch t := p.TargetPort.(type) {
+		case int:
+			target = uint32(t)
+		case string:
+			// named port, not resolved
+			continue
+		}
+		if !exposes(c, target) {
+			continue
+		}
+		published := p.Port
+		if p.NodePort != 0 {
+			published = p.NodePort
+		}
+		ports = append(ports, types.ServicePortConfig{
+			Target:    target,
+			Published: published,
+			Protocol:  strings.ToLower(p.Protocol),
+		})
+	}
+	return ports
+}
+
+func exposes(c container, port uint32) bool {
+	if len(c.Ports) == 0 {
+		return true
+	}
+	for _, p := range c.Ports {
+		if p.ContainerPort == port {
+			return true
+		}
+	}
+	return false
+}
+
+func matches(selector map[string]string, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
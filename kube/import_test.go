@@ -0,0 +1,112 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+const manifests = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: web
+          image: nginx
+          args: ["nginx", "-g", "daemon off;"]
+          env:
+            - name: MODE
+              value: dev
+          ports:
+            - containerPort: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: NodePort
+  selector:
+    app: web
+  ports:
+    - port: 80
+      targetPort: 80
+      nodePort: 30080
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`
+
+func TestToCompose(t *testing.T) {
+	services, err := ToCompose([]byte(manifests))
+	assert.NilError(t, err)
+	assert.Equal(t, len(services), 1)
+
+	web := services[0]
+	mode := "dev"
+	replicas := uint64(2)
+	assert.DeepEqual(t, web, types.ServiceConfig{
+		Name:        "web",
+		Image:       "nginx",
+		Command:     types.ShellCommand{"nginx", "-g", "daemon off;"},
+		Environment: types.MappingWithEquals{"MODE": &mode},
+		Deploy:      &types.DeployConfig{Replicas: &replicas},
+		Ports: []types.ServicePortConfig{
+			{Target: 80, Published: 30080},
+		},
+	})
+}
+
+func TestClusterIPServiceIsNotPublished(t *testing.T) {
+	services, err := ToCompose([]byte(`kind: Deployment
+metadata:
+  name: db
+spec:
+  template:
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+        - name: db
+          image: postgres
+---
+kind: Service
+metadata:
+  name: db
+spec:
+  selector:
+    app: db
+  ports:
+    - port: 5432
+`))
+	assert.NilError(t, err)
+	assert.Equal(t, len(services), 1)
+	assert.Equal(t, len(services[0].Ports), 0)
+}
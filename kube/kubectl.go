@@ -0,0 +1,100 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+const (
+	defaultNamespace = "default"
+
+	projectLabel = "com.docker.compose.project"
+	serviceLabel = "com.docker.compose.service"
+)
+
+// pod holds the subset of Kubernetes pods fields used by compose commands
+type pod struct {
+	Metadata struct {
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
+	} `json:"metadata"`
+}
+
+// kubectl runs commands against the cluster and namespace targeted by a Kubernetes context
+type kubectl struct {
+	kubeConfig string
+	namespace  string
+}
+
+func newKubectl(kubeContext store.KubeContext) kubectl {
+	namespace := kubeContext.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return kubectl{
+		kubeConfig: kubeContext.KubeConfig,
+		namespace:  namespace,
+	}
+}
+
+func (k kubectl) command(ctx context.Context, args ...string) *exec.Cmd {
+	globalArgs := []string{"--namespace", k.namespace}
+	if k.kubeConfig != "" {
+		globalArgs = append(globalArgs, "--kubeconfig", k.kubeConfig)
+	}
+	return exec.CommandContext(ctx, "kubectl", append(globalArgs, args...)...)
+}
+
+func (k kubectl) output(ctx context.Context, args ...string) ([]byte, error) {
+	out, err := k.command(ctx, args...).Output()
+	if errors.Is(err, exec.ErrNotFound) {
+		return nil, errors.Wrap(err, "kubectl is required to use Kubernetes contexts")
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return nil, errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return out, err
+}
+
+// checkNamespace validates the cluster is reachable and the target namespace exists
+func (k kubectl) checkNamespace(ctx context.Context) error {
+	_, err := k.output(ctx, "get", "namespace", k.namespace, "--output", "name")
+	return err
+}
+
+// projectPods lists the pods of a compose project, sorted by name
+func (k kubectl) projectPods(ctx context.Context, projectName string) ([]pod, error) {
+	out, err := k.output(ctx, "get", "pods", "--selector", projectLabel+"="+projectName, "--sort-by", ".metadata.name", "--output", "json")
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []pod `json:"items"`
+	}
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
@@ -0,0 +1,36 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+func TestKubectlCommand(t *testing.T) {
+	k := newKubectl(store.KubeContext{})
+	cmd := k.command(context.Background(), "get", "pods")
+	assert.DeepEqual(t, cmd.Args, []string{"kubectl", "--namespace", "default", "get", "pods"})
+
+	k = newKubectl(store.KubeContext{KubeConfig: "/home/user/.kube/staging", Namespace: "shop"})
+	cmd = k.command(context.Background(), "get", "pods")
+	assert.DeepEqual(t, cmd.Args, []string{"kubectl", "--namespace", "shop", "--kubeconfig", "/home/user/.kube/staging", "get", "pods"})
+}
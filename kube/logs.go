@@ -0,0 +1,80 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+	"github.com/docker/compose-cli/formatter"
+	"github.com/docker/compose-cli/utils"
+)
+
+func (s *kubeAPIService) Logs(ctx context.Context, projectName string, w io.Writer, options compose.LogOptions) error {
+	pods, err := s.kubectl.projectPods(ctx, projectName)
+	if err != nil {
+		return err
+	}
+	consumer, err := formatter.NewFilteredLogConsumer(w, options.Filter)
+	if err != nil {
+		return err
+	}
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, p := range pods {
+		service := p.Metadata.Labels[serviceLabel]
+		if len(options.Services) > 0 && !utils.StringContains(options.Services, service) {
+			continue
+		}
+		args, err := logsArgs(p.Metadata.Name, options)
+		if err != nil {
+			return err
+		}
+		cmd := s.kubectl.command(ctx, args...)
+		cmd.Stdout = consumer.GetWriter(service, p.Metadata.Name)
+		eg.Go(cmd.Run)
+	}
+	return eg.Wait()
+}
+
+// logsArgs builds the kubectl arguments streaming the logs of all the containers of a pod
+func logsArgs(podName string, options compose.LogOptions) ([]string, error) {
+	if options.Until != "" {
+		return nil, errors.Wrap(errdefs.ErrNotImplemented, "--until is not supported by kubectl logs")
+	}
+	args := []string{"logs", "pod/" + podName, "--all-containers", "--follow"}
+	if options.Tail != "" && options.Tail != "all" {
+		args = append(args, "--tail", options.Tail)
+	}
+	if options.Since != "" {
+		// kubectl only accepts durations as --since, timestamps are set by --since-time in RFC3339 format
+		if _, err := time.ParseDuration(options.Since); err == nil {
+			return append(args, "--since", options.Since), nil
+		}
+		since, err := compose.ParseLogTime(options.Since, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--since-time", since.Format(time.RFC3339))
+	}
+	return args, nil
+}
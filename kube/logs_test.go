@@ -0,0 +1,51 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestLogsArgs(t *testing.T) {
+	args, err := logsArgs("web-1", compose.LogOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args, []string{"logs", "pod/web-1", "--all-containers", "--follow"})
+
+	args, err = logsArgs("web-1", compose.LogOptions{Tail: "10", Since: "42m"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args, []string{"logs", "pod/web-1", "--all-containers", "--follow", "--tail", "10", "--since", "42m"})
+
+	args, err = logsArgs("web-1", compose.LogOptions{Tail: "all", Since: "2013-01-02T13:23:37Z"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, args, []string{"logs", "pod/web-1", "--all-containers", "--follow", "--since-time", "2013-01-02T13:23:37Z"})
+
+	args, err = logsArgs("web-1", compose.LogOptions{Since: "2013-01-02T13:23:37"})
+	assert.NilError(t, err)
+	since := time.Date(2013, 1, 2, 13, 23, 37, 0, time.Local).Format(time.RFC3339)
+	assert.DeepEqual(t, args, []string{"logs", "pod/web-1", "--all-containers", "--follow", "--since-time", since})
+
+	_, err = logsArgs("web-1", compose.LogOptions{Since: "yesterday"})
+	assert.ErrorContains(t, err, "invalid timestamp \"yesterday\"")
+
+	_, err = logsArgs("web-1", compose.LogOptions{Until: "10m"})
+	assert.ErrorContains(t, err, "--until is not supported")
+}
@@ -0,0 +1,67 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func (s *local) Attach(ctx context.Context, projectName string, opts compose.AttachOptions) error {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(opts.Service),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	container, err := getReplica(withoutOneOffContainers(list), opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+	inspect, err := s.containerService.apiClient.ContainerInspect(ctx, container.ID)
+	if err != nil {
+		return err
+	}
+
+	// stdin is only forwarded to containers keeping it open, as `docker attach` does
+	stdin := opts.Stdin
+	if !inspect.Config.OpenStdin {
+		stdin = nil
+	}
+	resp, err := s.containerService.apiClient.ContainerAttach(ctx, container.ID, moby.ContainerAttachOptions{
+		Stream:     true,
+		Stdin:      stdin != nil,
+		Stdout:     true,
+		Stderr:     true,
+		DetachKeys: opts.DetachKeys,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	return pipeStreams(resp, stdin, opts.Stdout, opts.Stderr, inspect.Config.Tty)
+}
@@ -0,0 +1,69 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/distribution/reference"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+)
+
+const dockerHubConfigKey = "https://index.docker.io/v1/"
+
+// registryAuth resolves the encoded credentials of the registry hosting an image from the docker CLI
+// configuration. Credential helpers are run on every call, so they get a chance to renew expired tokens.
+func registryAuth(image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", err
+	}
+	key := reference.Domain(named)
+	if key == "docker.io" {
+		key = dockerHubConfigKey
+	}
+	authConfig, err := config.LoadDefaultConfigFile(ioutil.Discard).GetAuthConfig(key)
+	if err != nil {
+		return "", err
+	}
+	buf, err := json.Marshal(moby.AuthConfig(authConfig))
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(buf), nil
+}
+
+// isUnauthorized checks whether an error reports missing or expired registry credentials
+func isUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errdefs.IsUnauthorized(err) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "unauthorized") ||
+		strings.Contains(message, "authentication required") ||
+		strings.Contains(message, "token has expired")
+}
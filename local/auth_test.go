@@ -0,0 +1,33 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsUnauthorized(t *testing.T) {
+	assert.Assert(t, !isUnauthorized(nil))
+	assert.Assert(t, isUnauthorized(errors.New("unauthorized: authentication required")))
+	assert.Assert(t, isUnauthorized(errors.New("denied: Your authorization token has expired. Reauthenticate and try again.")))
+	assert.Assert(t, !isUnauthorized(errors.New("manifest for nginx:nope not found")))
+}
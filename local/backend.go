@@ -30,6 +30,7 @@ import (
 	"github.com/docker/compose-cli/api/volumes"
 	"github.com/docker/compose-cli/backend"
 	"github.com/docker/compose-cli/context/cloud"
+	"github.com/docker/compose-cli/context/store"
 )
 
 type local struct {
@@ -39,18 +40,29 @@ type local struct {
 
 func init() {
 	backend.Register("local", "local", service, cloud.NotImplementedCloudService)
+	compose.RegisterBackendConfig(compose.BackendConfig{Capabilities: capabilities()})
 }
 
 func service(ctx context.Context) (backend.Service, error) {
-	apiClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	apiClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation(), withRetryPolicy(store.ContextRetryPolicy(ctx)))
 	if err != nil {
 		return nil, err
 	}
 
+	return newLocal(apiClient), nil
+}
+
+// NewComposeService returns the compose API implementation running projects on the Docker engine of apiClient,
+// so Go programs can embed compose orchestration
+func NewComposeService(apiClient *client.Client) compose.Service {
+	return newLocal(apiClient)
+}
+
+func newLocal(apiClient *client.Client) *local {
 	return &local{
 		containerService: &containerService{apiClient},
 		volumeService:    &volumeService{apiClient},
-	}, nil
+	}
 }
 
 func (s *local) ContainerService() containers.Service {
@@ -72,3 +84,8 @@ func (s *local) VolumeService() volumes.Service {
 func (s *local) ResourceService() resources.Service {
 	return nil
 }
+
+func (s *local) Ping(ctx context.Context) error {
+	_, err := s.containerService.apiClient.Ping(ctx)
+	return err
+}
@@ -0,0 +1,50 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *local) Capabilities(ctx context.Context) (compose.Capabilities, error) {
+	return capabilities(), nil
+}
+
+func capabilities() compose.Capabilities {
+	// images are pulled, building them is not supported yet
+	supported := []string{}
+	for _, f := range compose.Features {
+		if f != compose.FeatureBuild {
+			supported = append(supported, f)
+		}
+	}
+	return compose.Capabilities{
+		Backend:   "local",
+		Supported: supported,
+	}
+}
+
+func (s *local) EnsureImageRepository(ctx context.Context, projectName string, service string) (compose.ImageRepository, error) {
+	return compose.ImageRepository{}, errors.Wrap(errdefs.ErrNotImplemented, "images of local projects aren't pushed to a registry")
+}
@@ -36,12 +36,12 @@ import (
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/strslice"
+	volumetypes "github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/errdefs"
-	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/nat"
 	"github.com/pkg/errors"
 	"github.com/sanathkr/go-yaml"
-	"golang.org/x/sync/errgroup"
+	"github.com/sirupsen/logrus"
 
 	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/api/containers"
@@ -49,164 +49,174 @@ import (
 	"github.com/docker/compose-cli/progress"
 )
 
-func (s *local) Up(ctx context.Context, project *types.Project, detach bool) error {
-	for k, network := range project.Networks {
-		if !network.External.External && network.Name != "" {
-			network.Name = fmt.Sprintf("%s_%s", project.Name, k)
-			project.Networks[k] = network
-		}
-		err := s.ensureNetwork(ctx, network)
-		if err != nil {
-			return err
-		}
+func (s *local) Up(ctx context.Context, project *types.Project, options compose.UpOptions) error {
+	err := checkContainerNames(project)
+	if err != nil {
+		return err
 	}
-
-	for k, volume := range project.Volumes {
-		if !volume.External.External && volume.Name != "" {
-			volume.Name = fmt.Sprintf("%s_%s", project.Name, k)
-			project.Volumes[k] = volume
-		}
-		err := s.ensureVolume(ctx, volume)
-		if err != nil {
-			return err
-		}
+	err = prepareProjectResources(ctx, project)
+	if err != nil {
+		return err
 	}
 
 	for _, service := range project.Services {
-		err := s.applyPullPolicy(ctx, service)
+		err := s.applyPullPolicy(ctx, service, options.Pull)
 		if err != nil {
 			return err
 		}
 	}
 
-	err := inDependencyOrder(ctx, project, func(c context.Context, service types.ServiceConfig) error {
-		return s.ensureService(c, project, service)
+	observed, err := s.listServiceContainers(ctx, project)
+	if err != nil {
+		return err
+	}
+	diff, err := diffProject(project, observed)
+	if err != nil {
+		return err
+	}
+	for _, service := range diff.Removed {
+		logrus.Warnf("found containers for service %q which is not declared by project %q anymore", service, project.Name)
+	}
+
+	var (
+		lock      sync.Mutex
+		attempted = map[string]bool{}
+		// recreated are the services whose containers have been recreated, their dependents are recreated too
+		recreated = map[string]bool{}
+	)
+	onStatus := progress.WalkStatusFunc(progress.ContextWriter(ctx), progressTreeParents(project))
+	notify := func(service string, status compose.WalkStatus, err error) {
+		onStatus(service, status, err)
+		if options.OnStatus != nil {
+			options.OnStatus(service, status, err)
+		}
+	}
+	converge := func(c context.Context, service types.ServiceConfig) error {
+		lock.Lock()
+		retry := attempted[service.Name]
+		attempted[service.Name] = true
+		cascade := false
+		for _, dep := range compose.ServiceDependencies(service) {
+			cascade = cascade || recreated[dep]
+		}
+		lock.Unlock()
+		if cascade {
+			service = withLifecycle(service, forceRecreate)
+		}
+		actual := observed[service.Name]
+		if !retry && !cascade && diff.isUnchanged(service.Name) {
+			// nothing to converge, dependencies are not waited for either
+			reportRunning(c, service, actual)
+			return nil
+		}
+		if retry {
+			// a failed attempt may have left containers behind
+			refreshed, err := s.listServiceContainers(c, project)
+			if err != nil {
+				return err
+			}
+			actual = refreshed[service.Name]
+		}
+		// a failed attempt may have recreated containers the retry won't see diverging anymore
+		updated, err := s.ensureService(c, project, service, actual, options)
+		if updated {
+			lock.Lock()
+			recreated[service.Name] = true
+			lock.Unlock()
+		}
+		return err
+	}
+	err = compose.Walk(ctx, project, func(c context.Context, service types.ServiceConfig) error {
+		return withRetry(c, service, converge, func(err error) {
+			notify(service.Name, compose.WalkRetrying, err)
+		})
+	}, compose.WalkOptions{
+		EnsureResource: func(c context.Context, r compose.Resource) error {
+			return s.ensureResource(c, project, r)
+		},
+		OnStatus: notify,
 	})
 	return err
 }
 
-func getContainerName(c moby.Container) string {
-	// Names return container canonical name /foo  + link aliases /linked_by/foo
-	for _, name := range c.Names {
-		if strings.LastIndex(name, "/") == 0 {
-			return name[1:]
+// ensureProjectResources creates the networks and volumes declared by the project
+func (s *local) ensureProjectResources(ctx context.Context, project *types.Project) error {
+	if err := prepareProjectResources(ctx, project); err != nil {
+		return err
+	}
+	for k := range project.Networks {
+		if err := s.ensureResource(ctx, project, compose.Resource{Kind: compose.ResourceNetwork, Name: k}); err != nil {
+			return err
 		}
 	}
-	return c.Names[0][1:]
-}
-
-func (s *local) applyPullPolicy(ctx context.Context, service types.ServiceConfig) error {
-	w := progress.ContextWriter(ctx)
-	// TODO build vs pull should be controlled by pull policy
-	// if service.Build {}
-	if service.Image != "" {
-		_, _, err := s.containerService.apiClient.ImageInspectWithRaw(ctx, service.Image)
-		if err != nil {
-			if errdefs.IsNotFound(err) {
-				stream, err := s.containerService.apiClient.ImagePull(ctx, service.Image, moby.ImagePullOptions{})
-				if err != nil {
-					return err
-				}
-				dec := json.NewDecoder(stream)
-				for {
-					var jm jsonmessage.JSONMessage
-					if err := dec.Decode(&jm); err != nil {
-						if err == io.EOF {
-							break
-						}
-						return err
-					}
-					toProgressEvent(jm, w)
-				}
-			}
+	for k := range project.Volumes {
+		if err := s.ensureResource(ctx, project, compose.Resource{Kind: compose.ResourceVolume, Name: k}); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func toProgressEvent(jm jsonmessage.JSONMessage, w progress.Writer) {
-	if jm.Progress != nil {
-		if jm.Progress.Total != 0 {
-			status := progress.Working
-			if jm.Status == "Pull complete" {
-				status = progress.Done
-			}
-			w.Event(progress.Event{
-				ID:         jm.ID,
-				Text:       jm.Status,
-				Status:     status,
-				StatusText: jm.Progress.String(),
-			})
-		} else {
-			if jm.Error != nil {
-				w.Event(progress.Event{
-					ID:         jm.ID,
-					Text:       jm.Status,
-					Status:     progress.Error,
-					StatusText: jm.Error.Message,
-				})
-			} else if jm.Status == "Pull complete" || jm.Status == "Already exists" {
-				w.Event(progress.Event{
-					ID:     jm.ID,
-					Text:   jm.Status,
-					Status: progress.Done,
-				})
-			} else {
-				w.Event(progress.Event{
-					ID:     jm.ID,
-					Text:   jm.Status,
-					Status: progress.Working,
-				})
-			}
-		}
+// ensureResource creates a network or volume of the project, once named by prepareProjectResources
+func (s *local) ensureResource(ctx context.Context, project *types.Project, r compose.Resource) error {
+	switch r.Kind {
+	case compose.ResourceNetwork:
+		return s.ensureNetwork(ctx, project.Networks[r.Name])
+	case compose.ResourceVolume:
+		return s.ensureVolume(ctx, project.Volumes[r.Name])
+	default:
+		return errors.Errorf("unsupported resource %s", r)
 	}
 }
 
-func (s *local) Down(ctx context.Context, projectName string) error {
-	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
-		Filters: filters.NewArgs(
-			projectFilter(projectName),
-		),
-	})
-	if err != nil {
+// prepareProjectResources scopes the names of the networks and volumes declared by the project to the project
+// and labels them, before they are created
+func prepareProjectResources(ctx context.Context, project *types.Project) error {
+	if err := checkConfigsContent(project); err != nil {
+		return err
+	}
+	if err := resolveSecrets(ctx, project); err != nil {
 		return err
 	}
 
-	eg, _ := errgroup.WithContext(ctx)
-	w := progress.ContextWriter(ctx)
-	for _, c := range list {
-		container := c
-		eg.Go(func() error {
-			w.Event(progress.Event{
-				ID:     getContainerName(container),
-				Text:   "Stopping",
-				Status: progress.Working,
-			})
-			err := s.containerService.Stop(ctx, container.ID, nil)
-			if err != nil {
-				return err
+	for k, network := range project.Networks {
+		if !network.External.External && network.Name != "" {
+			network.Name = fmt.Sprintf("%s_%s", project.Name, k)
+			if network.Labels == nil {
+				network.Labels = map[string]string{}
 			}
-			w.Event(progress.Event{
-				ID:     getContainerName(container),
-				Text:   "Removing",
-				Status: progress.Working,
-			})
-			err = s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
-			if err != nil {
-				return err
+			network.Labels[projectLabel] = project.Name
+			project.Networks[k] = network
+		}
+	}
+
+	for k, volume := range project.Volumes {
+		if !volume.External.External {
+			// volumes declared with an explicit `name` keep it, others are scoped to the project
+			if volume.Name == "" || volume.Name == k {
+				volume.Name = fmt.Sprintf("%s_%s", project.Name, k)
 			}
-			w.Event(progress.Event{
-				ID:     getContainerName(container),
-				Text:   "Removed",
-				Status: progress.Done,
-			})
-			return nil
-		})
+			if volume.Labels == nil {
+				volume.Labels = map[string]string{}
+			}
+			volume.Labels[projectLabel] = project.Name
+			project.Volumes[k] = volume
+		}
+	}
+	return nil
+}
+
+func getContainerName(c moby.Container) string {
+	// Names return container canonical name /foo  + link aliases /linked_by/foo
+	for _, name := range c.Names {
+		if strings.LastIndex(name, "/") == 0 {
+			return name[1:]
+		}
 	}
-	return eg.Wait()
+	return c.Names[0][1:]
 }
 
-func (s *local) Logs(ctx context.Context, projectName string, w io.Writer) error {
+func (s *local) Logs(ctx context.Context, projectName string, w io.Writer, options compose.LogOptions) error {
 	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
 		Filters: filters.NewArgs(
 			projectFilter(projectName),
@@ -215,14 +225,23 @@ func (s *local) Logs(ctx context.Context, projectName string, w io.Writer) error
 	if err != nil {
 		return err
 	}
+	consumer, err := formatter.NewFilteredLogConsumer(w, options.Filter)
+	if err != nil {
+		return err
+	}
 	var wg sync.WaitGroup
-	consumer := formatter.NewLogConsumer(w)
 	for _, c := range list {
 		service := c.Labels[serviceLabel]
+		if len(options.Services) > 0 && !contains(options.Services, service) {
+			continue
+		}
 		containerID := c.ID
 		go func() {
 			_ = s.containerService.Logs(ctx, containerID, containers.LogsRequest{
 				Follow: true,
+				Tail:   options.Tail,
+				Since:  options.Since,
+				Until:  options.Until,
 				Writer: consumer.GetWriter(service, containerID),
 			})
 			wg.Done()
@@ -253,22 +272,69 @@ func containersToServiceStatus(containers []moby.Container) ([]compose.ServiceSt
 	var services []compose.ServiceStatus
 	for _, service := range keys {
 		containers := containersByLabel[service]
-		runnningContainers := []moby.Container{}
+		desired := 0
+		running := 0
+		summaries := []compose.ContainerSummary{}
+		unhealthy := 0
 		for _, container := range containers {
-			if container.State == "running" {
-				runnningContainers = append(runnningContainers, container)
+			health := getHealth(container)
+			// one-off containers are listed, but they are not replicas of the service
+			if container.Labels[oneoffLabel] != "True" {
+				desired++
+				if container.State == "running" {
+					running++
+				}
+				if health == "unhealthy" {
+					unhealthy++
+				}
 			}
+			summaries = append(summaries, compose.ContainerSummary{
+				ID:     container.ID,
+				Name:   getContainerName(container),
+				State:  container.State,
+				Health: health,
+				Ports:  toPortStrings(container.Ports),
+				Labels: container.Labels,
+			})
 		}
 		services = append(services, compose.ServiceStatus{
-			ID:       service,
-			Name:     service,
-			Desired:  len(containers),
-			Replicas: len(runnningContainers),
+			ID:         service,
+			Name:       service,
+			Desired:    desired,
+			Replicas:   running,
+			Unhealthy:  unhealthy,
+			Containers: summaries,
 		})
 	}
 	return services, nil
 }
 
+// getHealth extracts the healthcheck status from the container human readable status
+func getHealth(c moby.Container) string {
+	switch {
+	case strings.Contains(c.Status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(c.Status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(c.Status, "(health: starting)"):
+		return "starting"
+	default:
+		return ""
+	}
+}
+
+func toPortStrings(ports []moby.Port) []string {
+	result := []string{}
+	for _, p := range ports {
+		if p.PublicPort == 0 {
+			result = append(result, fmt.Sprintf("%d/%s", p.PrivatePort, p.Type))
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s:%d->%d/%s", p.IP, p.PublicPort, p.PrivatePort, p.Type))
+	}
+	return result
+}
+
 func groupContainerByLabel(containers []moby.Container, labelName string) (map[string][]moby.Container, []string, error) {
 	containersByLabel := map[string][]moby.Container{}
 	keys := []string{}
@@ -359,7 +425,7 @@ func (s *local) Convert(ctx context.Context, project *types.Project, format stri
 }
 
 func getContainerCreateOptions(p *types.Project, s types.ServiceConfig, number int, inherit *moby.Container) (*container.Config, *container.HostConfig, *network.NetworkingConfig, error) {
-	hash, err := jsonHash(s)
+	hash, err := serviceHash(s)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -410,7 +476,7 @@ func getContainerCreateOptions(p *types.Project, s types.ServiceConfig, number i
 		MacAddress:      s.MacAddress,
 		Labels:          labels,
 		StopSignal:      s.StopSignal,
-		Env:             toMobyEnv(s.Environment),
+		Env:             append(getLinkEnvironment(p, s), toMobyEnv(s.Environment)...),
 		Healthcheck:     toMobyHealthCheck(s.HealthCheck),
 		// Volumes:         // FIXME unclear to me the overlap with HostConfig.Mounts
 		StopTimeout: toSeconds(s.StopGracePeriod),
@@ -426,16 +492,50 @@ func getContainerCreateOptions(p *types.Project, s types.ServiceConfig, number i
 		CapDrop:        strslice.StrSlice(s.CapDrop),
 		NetworkMode:    networkMode,
 		Init:           s.Init,
+		Privileged:     s.Privileged,
+		SecurityOpt:    s.SecurityOpt,
 		ReadonlyRootfs: s.ReadOnly,
 		// ShmSize: , TODO
 		Sysctls:      s.Sysctls,
 		PortBindings: bindings,
-	}
-
-	networkConfig := buildDefaultNetworkConfig(s, networkMode)
+		PidMode:      container.PidMode(s.Pid),
+		IpcMode:      getIpcMode(p, s),
+		DNS:          s.DNS,
+		DNSSearch:    s.DNSSearch,
+		DNSOptions:   s.DNSOpts,
+		ExtraHosts:   s.ExtraHosts,
+		VolumesFrom:  s.VolumesFrom,
+		Resources: container.Resources{
+			DeviceRequests: getDeviceRequests(s),
+		},
+	}
+
+	networkConfig := buildDefaultNetworkConfig(p, s, networkMode)
 	return &containerConfig, &hostConfig, networkConfig, nil
 }
 
+// getDeviceRequests translates the devices reserved by a service, typically GPUs, to engine device requests
+func getDeviceRequests(s types.ServiceConfig) []container.DeviceRequest {
+	if s.Deploy == nil || s.Deploy.Resources.Reservations == nil {
+		return nil
+	}
+	var requests []container.DeviceRequest
+	for _, device := range s.Deploy.Resources.Reservations.Devices {
+		count := device.Count
+		if count == 0 && len(device.IDs) == 0 {
+			// all the available devices are requested when neither a count nor IDs are set
+			count = -1
+		}
+		requests = append(requests, container.DeviceRequest{
+			Driver:       device.Diver,
+			Count:        count,
+			DeviceIDs:    device.IDs,
+			Capabilities: [][]string{device.Capabilities},
+		})
+	}
+	return requests
+}
+
 func buildContainerPorts(s types.ServiceConfig) nat.PortSet {
 	ports := nat.PortSet{}
 	for _, p := range s.Ports {
@@ -446,16 +546,18 @@ func buildContainerPorts(s types.ServiceConfig) nat.PortSet {
 }
 
 func buildContainerBindingOptions(s types.ServiceConfig) nat.PortMap {
+	hostIPs, _ := s.Extensions[compose.PortHostIPsExtension].(map[string]interface{})
 	bindings := nat.PortMap{}
 	for _, port := range s.Ports {
 		p := nat.Port(fmt.Sprintf("%d/%s", port.Target, port.Protocol))
-		bind := []nat.PortBinding{}
 		binding := nat.PortBinding{}
 		if port.Published > 0 {
 			binding.HostPort = fmt.Sprint(port.Published)
+			if hostIP, ok := hostIPs[fmt.Sprintf("%d:%s", port.Published, p)]; ok {
+				binding.HostIP = fmt.Sprint(hostIP)
+			}
 		}
-		bind = append(bind, binding)
-		bindings[p] = bind
+		bindings[p] = append(bindings[p], binding)
 	}
 	return bindings
 }
@@ -491,6 +593,10 @@ func buildContainerMountOptions(p *types.Project, s types.ServiceConfig, inherit
 			// FIXME handle ~/
 			source = filepath.Join(p.WorkingDir, source)
 		}
+		if volume, ok := p.Volumes[source]; ok && v.Type == "volume" && volume.Name != "" {
+			// named volumes are mounted by their actual name, which differs for external and project scoped volumes
+			source = volume.Name
+		}
 
 		mounts = append(mounts, mount.Mount{
 			Type:          mount.Type(v.Type),
@@ -503,6 +609,12 @@ func buildContainerMountOptions(p *types.Project, s types.ServiceConfig, inherit
 			TmpfsOptions:  buildTmpfsOptions(v.Tmpfs),
 		})
 	}
+
+	for _, m := range append(buildConfigMounts(p, s), buildSecretMounts(p, s)...) {
+		if !contains(inherited, m.Target) {
+			mounts = append(mounts, m)
+		}
+	}
 	return mounts
 }
 
@@ -537,23 +649,51 @@ func buildTmpfsOptions(tmpfs *types.ServiceVolumeTmpfs) *mount.TmpfsOptions {
 	}
 }
 
-func buildDefaultNetworkConfig(s types.ServiceConfig, networkMode container.NetworkMode) *network.NetworkingConfig {
+func buildDefaultNetworkConfig(p *types.Project, s types.ServiceConfig, networkMode container.NetworkMode) *network.NetworkingConfig {
 	config := map[string]*network.EndpointSettings{}
 	net := string(networkMode)
-	config[net] = &network.EndpointSettings{
-		Aliases: getAliases(s, s.Networks[net]),
-	}
+	key := getServiceNetworkKey(p, s, net)
+	config[net] = getEndpointSettings(p, s, key, s.Networks[key])
 
 	return &network.NetworkingConfig{
 		EndpointsConfig: config,
 	}
 }
 
-func getAliases(s types.ServiceConfig, c *types.ServiceNetworkConfig) []string {
+// getServiceNetworkKey returns the key of the service network which is created with the given name
+func getServiceNetworkKey(p *types.Project, s types.ServiceConfig, name string) string {
+	for key := range getNetworksForService(s) {
+		if n, ok := p.Networks[key]; ok && n.Name == name {
+			return key
+		}
+	}
+	return name
+}
+
+func getEndpointSettings(p *types.Project, s types.ServiceConfig, networkKey string, c *types.ServiceNetworkConfig) *network.EndpointSettings {
+	settings := &network.EndpointSettings{
+		Aliases: getAliases(p, s, networkKey, c),
+	}
+	if c != nil && (c.Ipv4Address != "" || c.Ipv6Address != "") {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{
+			IPv4Address: c.Ipv4Address,
+			IPv6Address: c.Ipv6Address,
+		}
+	}
+	return settings
+}
+
+// getAliases returns the aliases of the service on the network declared by the project with the given key
+func getAliases(p *types.Project, s types.ServiceConfig, networkKey string, c *types.ServiceNetworkConfig) []string {
 	aliases := []string{s.Name}
 	if c != nil {
 		aliases = append(aliases, c.Aliases...)
 	}
+	for _, alias := range getLinkAliases(p, s, networkKey) {
+		if !contains(aliases, alias) {
+			aliases = append(aliases, alias)
+		}
+	}
 	return aliases
 }
 
@@ -568,14 +708,7 @@ func getNetworkMode(p *types.Project, service types.ServiceConfig) container.Net
 		return container.NetworkMode("none")
 	}
 
-	/// FIXME incomplete implementation
-	if strings.HasPrefix(mode, "service:") {
-		panic("Not yet implemented")
-	}
-	if strings.HasPrefix(mode, "container:") {
-		panic("Not yet implemented")
-	}
-
+	// `service:` is resolved to the container of the service when the container is created
 	return container.NetworkMode(mode)
 }
 
@@ -597,6 +730,7 @@ func (s *local) ensureNetwork(ctx context.Context, n types.NetworkConfig) error
 				Options:    n.DriverOpts,
 				Internal:   n.Internal,
 				Attachable: n.Attachable,
+				EnableIPv6: n.Extensions[compose.EnableIPv6Extension] == true,
 			}
 
 			if n.Ipam.Driver != "" || len(n.Ipam.Config) > 0 {
@@ -609,7 +743,10 @@ func (s *local) ensureNetwork(ctx context.Context, n types.NetworkConfig) error
 
 			for _, ipamConfig := range n.Ipam.Config {
 				config := network.IPAMConfig{
-					Subnet: ipamConfig.Subnet,
+					Subnet:     ipamConfig.Subnet,
+					Gateway:    ipamConfig.Gateway,
+					IPRange:    ipamConfig.IPRange,
+					AuxAddress: ipamConfig.AuxiliaryAddresses,
 				}
 				createOpts.IPAM.Config = append(createOpts.IPAM.Config, config)
 			}
@@ -620,6 +757,12 @@ func (s *local) ensureNetwork(ctx context.Context, n types.NetworkConfig) error
 				StatusText: "Create",
 			})
 			if _, err := s.containerService.apiClient.NetworkCreate(ctx, n.Name, createOpts); err != nil {
+				w.Event(progress.Event{
+					ID:         fmt.Sprintf("Network %q", n.Name),
+					Text:       "Error",
+					Status:     progress.Error,
+					StatusText: err.Error(),
+				})
 				return errors.Wrapf(err, "failed to create network %s", n.Name)
 			}
 			w.Event(progress.Event{
@@ -637,26 +780,41 @@ func (s *local) ensureNetwork(ctx context.Context, n types.NetworkConfig) error
 func (s *local) ensureVolume(ctx context.Context, volume types.VolumeConfig) error {
 	// TODO could identify volume by label vs name
 	_, err := s.volumeService.Inspect(ctx, volume.Name)
-	if err != nil {
-		if errdefs.IsNotFound(err) {
-			w := progress.ContextWriter(ctx)
-			w.Event(progress.Event{
-				ID:         fmt.Sprintf("Volume %q", volume.Name),
-				Status:     progress.Working,
-				StatusText: "Create",
-			})
-			// TODO we miss support for driver_opts and labels
-			_, err := s.volumeService.Create(ctx, volume.Name, nil)
-			w.Event(progress.Event{
-				ID:         fmt.Sprintf("Volume %q", volume.Name),
-				Status:     progress.Done,
-				StatusText: "Created",
-			})
-			if err != nil {
-				return err
-			}
-		}
+	if err == nil {
+		return nil
+	}
+	if !errdefs.IsNotFound(err) {
 		return err
 	}
+	if volume.External.External {
+		return errors.Errorf("external volume %q not found", volume.Name)
+	}
+	w := progress.ContextWriter(ctx)
+	w.Event(progress.Event{
+		ID:         fmt.Sprintf("Volume %q", volume.Name),
+		Status:     progress.Working,
+		StatusText: "Create",
+	})
+	// driver and driver_opts are passed through so volume plugins (NFS, sshfs...) get their configuration
+	_, err = s.containerService.apiClient.VolumeCreate(ctx, volumetypes.VolumeCreateBody{
+		Name:       volume.Name,
+		Driver:     volume.Driver,
+		DriverOpts: volume.DriverOpts,
+		Labels:     volume.Labels,
+	})
+	if err != nil {
+		w.Event(progress.Event{
+			ID:         fmt.Sprintf("Volume %q", volume.Name),
+			Text:       "Error",
+			Status:     progress.Error,
+			StatusText: err.Error(),
+		})
+		return errors.Wrapf(err, "failed to create volume %s", volume.Name)
+	}
+	w.Event(progress.Event{
+		ID:         fmt.Sprintf("Volume %q", volume.Name),
+		Status:     progress.Done,
+		StatusText: "Created",
+	})
 	return nil
 }
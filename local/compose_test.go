@@ -19,9 +19,18 @@
 package local
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
+	composetypes "github.com/compose-spec/compose-go/types"
 	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
 	"gotest.tools/v3/assert"
 
 	"github.com/docker/compose-cli/api/compose"
@@ -65,45 +74,305 @@ func TestContainersToServiceStatus(t *testing.T) {
 	containers := []types.Container{
 		{
 			ID:     "c1",
+			Names:  []string{"/p_service1_1"},
 			State:  "running",
+			Status: "Up 2 minutes (healthy)",
 			Labels: map[string]string{serviceLabel: "service1"},
 		},
 		{
 			ID:     "c2",
+			Names:  []string{"/p_service1_2"},
 			State:  "exited",
 			Labels: map[string]string{serviceLabel: "service1"},
 		},
 		{
 			ID:     "c3",
+			Names:  []string{"/p_service1_3"},
 			State:  "running",
+			Status: "Up 2 minutes (unhealthy)",
 			Labels: map[string]string{serviceLabel: "service1"},
 		},
+		{
+			ID:     "r1",
+			Names:  []string{"/p_service1_run_1"},
+			State:  "running",
+			Status: "Up 1 minute (unhealthy)",
+			Labels: map[string]string{serviceLabel: "service1", oneoffLabel: "True"},
+		},
 		{
 			ID:     "c4",
+			Names:  []string{"/p_service2_1"},
 			State:  "running",
 			Labels: map[string]string{serviceLabel: "service2"},
+			Ports: []types.Port{
+				{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+			},
 		},
 	}
 	services, err := containersToServiceStatus(containers)
 	assert.NilError(t, err)
 	assert.DeepEqual(t, services, []compose.ServiceStatus{
 		{
-			ID:       "service1",
-			Name:     "service1",
-			Replicas: 2,
-			Desired:  3,
+			ID:        "service1",
+			Name:      "service1",
+			Replicas:  2,
+			Desired:   3,
+			Unhealthy: 1,
+			Containers: []compose.ContainerSummary{
+				{ID: "c1", Name: "p_service1_1", State: "running", Health: "healthy", Ports: []string{}, Labels: map[string]string{serviceLabel: "service1"}},
+				{ID: "c2", Name: "p_service1_2", State: "exited", Ports: []string{}, Labels: map[string]string{serviceLabel: "service1"}},
+				{ID: "c3", Name: "p_service1_3", State: "running", Health: "unhealthy", Ports: []string{}, Labels: map[string]string{serviceLabel: "service1"}},
+				{ID: "r1", Name: "p_service1_run_1", State: "running", Health: "unhealthy", Ports: []string{}, Labels: map[string]string{serviceLabel: "service1", oneoffLabel: "True"}},
+			},
 		},
 		{
 			ID:       "service2",
 			Name:     "service2",
 			Replicas: 1,
 			Desired:  1,
+			Containers: []compose.ContainerSummary{
+				{ID: "c4", Name: "p_service2_1", State: "running", Ports: []string{"0.0.0.0:8080->80/tcp"}, Labels: map[string]string{serviceLabel: "service2"}},
+			},
 		},
 	})
 }
 
+func TestNewComposeService(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1.40/containers/json")
+		writeJSON(t, w, http.StatusOK, []types.Container{
+			{ID: "c1", Names: []string{"/demo_web_1"}, State: "running", Labels: map[string]string{serviceLabel: "web"}},
+		})
+	})
+	service := NewComposeService(engine)
+
+	services, err := service.Ps(context.Background(), "demo")
+	assert.NilError(t, err)
+	assert.Equal(t, len(services), 1)
+	assert.Equal(t, services[0].Name, "web")
+	assert.Equal(t, services[0].Replicas, 1)
+}
+
+func TestUpRecreatesDependents(t *testing.T) {
+	project := &composetypes.Project{
+		Name: "demo",
+		Services: composetypes.Services{
+			{Name: "db"},
+			{Name: "web", DependsOn: composetypes.DependsOnConfig{"db": {}}},
+			{Name: "cache"},
+		},
+	}
+	running := func(service composetypes.ServiceConfig, hash string) types.Container {
+		name := "demo_" + service.Name + "_1"
+		return types.Container{
+			ID:    name + "_id",
+			Names: []string{"/" + name},
+			State: "running",
+			Labels: map[string]string{
+				projectLabel:         "demo",
+				serviceLabel:         service.Name,
+				configHashLabel:      hash,
+				containerNumberLabel: "1",
+			},
+		}
+	}
+	webHash, err := serviceHash(project.Services[1])
+	assert.NilError(t, err)
+	cacheHash, err := serviceHash(project.Services[2])
+	assert.NilError(t, err)
+	observed := []types.Container{
+		running(project.Services[0], "outdated"),
+		running(project.Services[1], webHash),
+		running(project.Services[2], cacheHash),
+	}
+
+	var (
+		lock      sync.Mutex
+		recreated []string
+		hashes    = map[string]string{}
+	)
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v1.40")
+		switch {
+		case path == "/containers/json":
+			writeJSON(t, w, http.StatusOK, observed)
+		case path == "/containers/create":
+			var config container.Config
+			assert.NilError(t, json.NewDecoder(r.Body).Decode(&config))
+			lock.Lock()
+			hashes[config.Labels[serviceLabel]] = config.Labels[configHashLabel]
+			lock.Unlock()
+			writeJSON(t, w, http.StatusCreated, map[string]string{"Id": r.URL.Query().Get("name")})
+		case strings.HasSuffix(path, "/rename"):
+			lock.Lock()
+			recreated = append(recreated, strings.Split(path, "/")[2])
+			lock.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(path, "/stop"), strings.HasSuffix(path, "/start"), r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, path)
+		}
+	})
+	s := newLocal(engine)
+
+	err = s.Up(context.Background(), project, compose.UpOptions{})
+	assert.NilError(t, err)
+	// db changed, web is recreated as it depends on it, cache is left alone
+	assert.DeepEqual(t, recreated, []string{"demo_db_1_id", "demo_web_1_id"})
+	// the recreation of a dependent doesn't change its configuration
+	assert.Equal(t, hashes["web"], webHash)
+	_, ok := project.Services[1].Extensions[extLifecycle]
+	assert.Assert(t, !ok)
+}
+
 func TestStacksMixedStatus(t *testing.T) {
 	assert.Equal(t, combinedStatus([]string{"running"}), "running(1)")
 	assert.Equal(t, combinedStatus([]string{"running", "running", "running"}), "running(3)")
 	assert.Equal(t, combinedStatus([]string{"running", "exited", "running"}), "exited(1), running(2)")
 }
+
+func TestBuildDefaultNetworkConfig(t *testing.T) {
+	project := &composetypes.Project{
+		Networks: composetypes.Networks{
+			"back": composetypes.NetworkConfig{Name: "myproject_back"},
+		},
+	}
+	service := composetypes.ServiceConfig{
+		Name: "db",
+		Networks: map[string]*composetypes.ServiceNetworkConfig{
+			"back": {Aliases: []string{"database"}, Ipv4Address: "172.28.0.10"},
+		},
+	}
+
+	config := buildDefaultNetworkConfig(project, service, container.NetworkMode("myproject_back"))
+	assert.DeepEqual(t, config.EndpointsConfig["myproject_back"], &network.EndpointSettings{
+		Aliases:    []string{"db", "database"},
+		IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.28.0.10"},
+	})
+}
+
+func TestBuildContainerBindingOptionsWithHostIPs(t *testing.T) {
+	service := composetypes.ServiceConfig{
+		Ports: []composetypes.ServicePortConfig{
+			{Target: 80, Published: 8080, Protocol: "tcp"},
+			{Target: 80, Published: 8081, Protocol: "tcp"},
+			{Target: 53, Published: 53, Protocol: "udp"},
+		},
+		Extensions: map[string]interface{}{
+			compose.PortHostIPsExtension: map[string]interface{}{"8080:80/tcp": "::1"},
+		},
+	}
+
+	bindings := buildContainerBindingOptions(service)
+	assert.DeepEqual(t, bindings, nat.PortMap{
+		"80/tcp": {{HostIP: "::1", HostPort: "8080"}, {HostPort: "8081"}},
+		"53/udp": {{HostPort: "53"}},
+	})
+}
+
+func TestBuildContainerMountOptionsWithNamedVolumes(t *testing.T) {
+	project := &composetypes.Project{
+		Volumes: composetypes.Volumes{
+			"data":   {Name: "myproject_data"},
+			"shared": {Name: "nfs-shared", External: composetypes.External{External: true}},
+		},
+	}
+	service := composetypes.ServiceConfig{
+		Volumes: []composetypes.ServiceVolumeConfig{
+			{Type: "volume", Source: "data", Target: "/data"},
+			{Type: "volume", Source: "shared", Target: "/shared"},
+			{Type: "volume", Source: "undeclared", Target: "/tmp/cache"},
+		},
+	}
+
+	mounts := buildContainerMountOptions(project, service, nil)
+	assert.Equal(t, len(mounts), 3)
+	assert.Equal(t, mounts[0].Source, "myproject_data")
+	assert.Equal(t, mounts[1].Source, "nfs-shared")
+	assert.Equal(t, mounts[2].Source, "undeclared")
+}
+
+func TestContainerCreateOptionsResolution(t *testing.T) {
+	project := &composetypes.Project{Name: "myproject"}
+	service := composetypes.ServiceConfig{
+		Name:        "web",
+		Image:       "nginx",
+		NetworkMode: "bridge",
+		DNS:         composetypes.StringList{"8.8.8.8"},
+		DNSSearch:   composetypes.StringList{"example.com"},
+		DNSOpts:     []string{"ndots:2"},
+		ExtraHosts:  composetypes.HostsList{"somehost:162.242.195.82"},
+	}
+
+	_, hostConfig, _, err := getContainerCreateOptions(project, service, 1, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, hostConfig.DNS, []string{"8.8.8.8"})
+	assert.DeepEqual(t, hostConfig.DNSSearch, []string{"example.com"})
+	assert.DeepEqual(t, hostConfig.DNSOptions, []string{"ndots:2"})
+	assert.DeepEqual(t, hostConfig.ExtraHosts, []string{"somehost:162.242.195.82"})
+}
+
+func TestContainerCreateOptionsNamespaces(t *testing.T) {
+	project := &composetypes.Project{
+		Name: "myproject",
+		Services: []composetypes.ServiceConfig{
+			{Name: "app", Image: "app"},
+			{Name: "debug", Image: "busybox", Pid: "service:app", Ipc: "service:app"},
+		},
+	}
+
+	_, hostConfig, _, err := getContainerCreateOptions(project, project.Services[0], 1, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, hostConfig.IpcMode, container.IpcMode("shareable"))
+
+	_, hostConfig, _, err = getContainerCreateOptions(project, project.Services[1], 1, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, hostConfig.PidMode, container.PidMode("service:app"))
+	assert.Equal(t, hostConfig.IpcMode, container.IpcMode("service:app"))
+}
+
+func TestContainerCreateOptionsSecurity(t *testing.T) {
+	project := &composetypes.Project{Name: "myproject"}
+	service := composetypes.ServiceConfig{
+		Name:        "web",
+		Image:       "nginx",
+		NetworkMode: "bridge",
+		Privileged:  true,
+		SecurityOpt: []string{"no-new-privileges:true"},
+		ReadOnly:    true,
+	}
+
+	_, hostConfig, _, err := getContainerCreateOptions(project, service, 1, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, hostConfig.Privileged)
+	assert.Assert(t, hostConfig.ReadonlyRootfs)
+	assert.DeepEqual(t, hostConfig.SecurityOpt, []string{"no-new-privileges:true"})
+}
+
+func TestContainerCreateOptionsDeviceRequests(t *testing.T) {
+	project := &composetypes.Project{Name: "myproject"}
+	service := composetypes.ServiceConfig{
+		Name:  "training",
+		Image: "tensorflow/tensorflow:latest-gpu",
+		Deploy: &composetypes.DeployConfig{
+			Resources: composetypes.Resources{
+				Reservations: &composetypes.Resource{
+					Devices: []composetypes.DeviceRequest{
+						{Capabilities: []string{"gpu"}, Diver: "nvidia", Count: 2},
+						{Capabilities: []string{"gpu", "utility"}, IDs: []string{"GPU-1"}},
+						{Capabilities: []string{"gpu"}},
+					},
+				},
+			},
+		},
+	}
+
+	_, hostConfig, _, err := getContainerCreateOptions(project, service, 1, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, hostConfig.DeviceRequests, []container.DeviceRequest{
+		{Driver: "nvidia", Count: 2, Capabilities: [][]string{{"gpu"}}},
+		{DeviceIDs: []string{"GPU-1"}, Capabilities: [][]string{{"gpu", "utility"}}},
+		{Count: -1, Capabilities: [][]string{{"gpu"}}},
+	})
+}
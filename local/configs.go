@@ -0,0 +1,92 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// checkConfigsContent checks the inline content of the project configs, it is copied into the
+// service containers and never written on the host
+func checkConfigsContent(project *types.Project) error {
+	for name, config := range project.Configs {
+		value, ok := config.Extensions[compose.ConfigContentExtension]
+		if !ok {
+			continue
+		}
+		if _, ok := value.(string); !ok {
+			return errors.Errorf("invalid content for config %q: expected a string, got %T", name, value)
+		}
+	}
+	return nil
+}
+
+// configTarget returns the path of a config in the service containers, at the root unless a target is set
+func configTarget(ref types.ServiceConfigObjConfig) string {
+	if ref.Target == "" {
+		return "/" + ref.Source
+	}
+	return ref.Target
+}
+
+// buildInjectedConfigs returns the configs of a service with an inline content, with the ownership
+// and mode set by the service
+func buildInjectedConfigs(p *types.Project, s types.ServiceConfig) ([]injectedFile, error) {
+	var files []injectedFile
+	for _, ref := range s.Configs {
+		content, ok := p.Configs[ref.Source].Extensions[compose.ConfigContentExtension].(string)
+		if !ok {
+			continue
+		}
+		file, err := newInjectedFile(types.FileReferenceConfig(ref), configTarget(ref), []byte(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "service %q", s.Name)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// buildConfigMounts bind mounts the file based configs of a service
+func buildConfigMounts(p *types.Project, s types.ServiceConfig) []mount.Mount {
+	var mounts []mount.Mount
+	for _, ref := range s.Configs {
+		config, ok := p.Configs[ref.Source]
+		if !ok || config.External.External || config.File == "" {
+			continue
+		}
+		source := config.File
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(p.WorkingDir, source)
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   configTarget(ref),
+			ReadOnly: true,
+		})
+	}
+	return mounts
+}
@@ -0,0 +1,69 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/api/types/mount"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestInlineConfigMount(t *testing.T) {
+	mode := uint32(0440)
+	project := &types.Project{
+		Name:       "configs_test",
+		WorkingDir: "/project",
+		Configs: map[string]types.ConfigObjConfig{
+			"nginx": {Extensions: map[string]interface{}{compose.ConfigContentExtension: "listen 80;"}},
+			"app":   {File: "app.conf"},
+		},
+	}
+	service := types.ServiceConfig{
+		Name: "web",
+		Configs: []types.ServiceConfigObjConfig{
+			{Source: "nginx", Target: "/etc/nginx/conf.d/default.conf", GID: "101", Mode: &mode},
+			{Source: "app"},
+		},
+	}
+
+	assert.NilError(t, checkConfigsContent(project))
+	assert.DeepEqual(t, buildConfigMounts(project, service), []mount.Mount{
+		{Type: mount.TypeBind, Source: "/project/app.conf", Target: "/app", ReadOnly: true},
+	})
+	files, err := buildInjectedFiles(project, service)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, files, []injectedFile{
+		{Target: "/etc/nginx/conf.d/default.conf", Content: []byte("listen 80;"), GID: 101, Mode: 0440},
+	})
+}
+
+func TestInlineConfigInvalidContent(t *testing.T) {
+	project := &types.Project{
+		Name: "configs_test",
+		Configs: map[string]types.ConfigObjConfig{
+			"settings": {Extensions: map[string]interface{}{compose.ConfigContentExtension: map[string]interface{}{"debug": true}}},
+		},
+	}
+	err := checkConfigsContent(project)
+	assert.Error(t, err, `invalid content for config "settings": expected a string, got map[string]interface {}`)
+}
@@ -21,6 +21,7 @@ package local
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"io"
 	"strings"
 	"time"
@@ -30,6 +31,7 @@ import (
 	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/docker/docker/pkg/stringid"
 	"github.com/pkg/errors"
@@ -191,6 +193,152 @@ func (cs *containerService) Kill(ctx context.Context, containerID string, signal
 	return cs.apiClient.ContainerKill(ctx, containerID, signal)
 }
 
+// Checkpoint relies on CRIU, the docker daemon must run with experimental features enabled
+func (cs *containerService) Checkpoint(ctx context.Context, containerID string, request containers.CheckpointRequest) error {
+	return cs.apiClient.CheckpointCreate(ctx, containerID, types.CheckpointCreateOptions{
+		CheckpointID:  request.Name,
+		CheckpointDir: request.Dir,
+		Exit:          !request.LeaveRunning,
+	})
+}
+
+func (cs *containerService) ListCheckpoints(ctx context.Context, containerID string, dir string) ([]containers.Checkpoint, error) {
+	list, err := cs.apiClient.CheckpointList(ctx, containerID, types.CheckpointListOptions{
+		CheckpointDir: dir,
+	})
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "container %q", containerID)
+		}
+		return nil, err
+	}
+	result := []containers.Checkpoint{}
+	for _, c := range list {
+		result = append(result, containers.Checkpoint{Name: c.Name})
+	}
+	return result, nil
+}
+
+func (cs *containerService) Restore(ctx context.Context, containerID string, request containers.RestoreRequest) error {
+	return cs.apiClient.ContainerStart(ctx, containerID, types.ContainerStartOptions{
+		CheckpointID:  request.Checkpoint,
+		CheckpointDir: request.Dir,
+	})
+}
+
+func (cs *containerService) Commit(ctx context.Context, containerID string, request containers.CommitRequest) (string, error) {
+	response, err := cs.apiClient.ContainerCommit(ctx, containerID, types.ContainerCommitOptions{
+		Reference: request.Reference,
+		Comment:   request.Message,
+		Author:    request.Author,
+		Changes:   request.Changes,
+		Pause:     request.Pause,
+	})
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return "", errors.Wrapf(errdefs.ErrNotFound, "container %q", containerID)
+		}
+		return "", err
+	}
+	return response.ID, nil
+}
+
+func (cs *containerService) Wait(ctx context.Context, containerID string) (int64, error) {
+	statusC, errC := cs.apiClient.ContainerWait(ctx, containerID, container.WaitConditionNotRunning)
+	select {
+	case status := <-statusC:
+		if status.Error != nil {
+			return status.StatusCode, errors.New(status.Error.Message)
+		}
+		return status.StatusCode, nil
+	case err := <-errC:
+		if client.IsErrNotFound(err) {
+			return 0, errors.Wrapf(errdefs.ErrNotFound, "container %q", containerID)
+		}
+		return 0, err
+	}
+}
+
+func (cs *containerService) Diff(ctx context.Context, containerID string) ([]containers.FilesystemChange, error) {
+	changes, err := cs.apiClient.ContainerDiff(ctx, containerID)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, errors.Wrapf(errdefs.ErrNotFound, "container %q", containerID)
+		}
+		return nil, err
+	}
+	result := []containers.FilesystemChange{}
+	for _, c := range changes {
+		result = append(result, containers.FilesystemChange{
+			Kind: toChangeKind(c.Kind),
+			Path: c.Path,
+		})
+	}
+	return result, nil
+}
+
+func (cs *containerService) Export(ctx context.Context, containerID string, w io.Writer) error {
+	archive, err := cs.apiClient.ContainerExport(ctx, containerID)
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return errors.Wrapf(errdefs.ErrNotFound, "container %q", containerID)
+		}
+		return err
+	}
+	defer archive.Close() // nolint:errcheck
+	_, err = io.Copy(w, archive)
+	return err
+}
+
+func (cs *containerService) Import(ctx context.Context, request containers.ImportRequest) (string, error) {
+	source := types.ImageImportSource{
+		Source:     request.Source,
+		SourceName: "-",
+	}
+	if request.SourceURL != "" {
+		source = types.ImageImportSource{SourceName: request.SourceURL}
+	}
+	stream, err := cs.apiClient.ImageImport(ctx, source, request.Reference, types.ImageImportOptions{
+		Message: request.Message,
+		Changes: request.Changes,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close() // nolint:errcheck
+
+	id := ""
+	dec := json.NewDecoder(stream)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return "", err
+		}
+		if jm.Error != nil {
+			return "", errors.New(jm.Error.Message)
+		}
+		if jm.Status != "" {
+			id = jm.Status
+		}
+	}
+	return id, nil
+}
+
+// toChangeKind converts the kinds of changes reported by the engine, 0 for modified, 1 for added and 2 for deleted
+func toChangeKind(kind uint8) string {
+	switch kind {
+	case 1:
+		return containers.ChangeAdded
+	case 2:
+		return containers.ChangeDeleted
+	default:
+		return containers.ChangeModified
+	}
+}
+
 func (cs *containerService) Exec(ctx context.Context, name string, request containers.ExecRequest) error {
 	cec, err := cs.apiClient.ContainerExecCreate(ctx, name, types.ExecConfig{
 		Cmd:          []string{request.Command},
@@ -239,10 +387,14 @@ func (cs *containerService) Logs(ctx context.Context, containerName string, requ
 		return err
 	}
 
+	// the engine only sends the requested tail, logs are then streamed as they are read
 	r, err := cs.apiClient.ContainerLogs(ctx, containerName, types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Follow:     request.Follow,
+		Tail:       request.Tail,
+		Since:      request.Since,
+		Until:      request.Until,
 	})
 
 	if err != nil {
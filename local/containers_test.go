@@ -0,0 +1,187 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// newFakeEngine returns a client of an engine API served by handler
+func newFakeEngine(t *testing.T, handler http.HandlerFunc) *client.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c, err := client.NewClientWithOpts(client.WithHost("tcp://"+server.Listener.Addr().String()), client.WithVersion("1.40"))
+	assert.NilError(t, err)
+	return c
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	assert.NilError(t, json.NewEncoder(w).Encode(v))
+}
+
+func TestCommit(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1.40/commit")
+		query := r.URL.Query()
+		if query.Get("container") == "unknown" {
+			writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "No such container: unknown"})
+			return
+		}
+		assert.Equal(t, query.Get("container"), "web")
+		assert.Equal(t, query.Get("repo"), "myimage")
+		assert.Equal(t, query.Get("tag"), "v1")
+		assert.Equal(t, query.Get("author"), "John Doe <john@example.com>")
+		assert.Equal(t, query.Get("comment"), "add config")
+		assert.DeepEqual(t, query["changes"], []string{"ENV DEBUG=1", "EXPOSE 8080"})
+		assert.Equal(t, query.Get("pause"), "0")
+		writeJSON(t, w, http.StatusCreated, map[string]string{"Id": "sha256:abcdef"})
+	})
+	cs := &containerService{engine}
+
+	id, err := cs.Commit(context.Background(), "web", containers.CommitRequest{
+		Reference: "myimage:v1",
+		Author:    "John Doe <john@example.com>",
+		Message:   "add config",
+		Changes:   []string{"ENV DEBUG=1", "EXPOSE 8080"},
+		Pause:     false,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, id, "sha256:abcdef")
+
+	_, err = cs.Commit(context.Background(), "unknown", containers.CommitRequest{})
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestWait(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Query().Get("condition"), "not-running")
+		switch r.URL.Path {
+		case "/v1.40/containers/web/wait":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"StatusCode": 3})
+		case "/v1.40/containers/broken/wait":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"StatusCode": 1, "Error": map[string]string{"Message": "cannot wait"}})
+		default:
+			writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "No such container"})
+		}
+	})
+	cs := &containerService{engine}
+
+	code, err := cs.Wait(context.Background(), "web")
+	assert.NilError(t, err)
+	assert.Equal(t, code, int64(3))
+
+	code, err = cs.Wait(context.Background(), "broken")
+	assert.Error(t, err, "cannot wait")
+	assert.Equal(t, code, int64(1))
+
+	_, err = cs.Wait(context.Background(), "unknown")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestDiff(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.40/containers/web/changes" {
+			writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "No such container"})
+			return
+		}
+		writeJSON(t, w, http.StatusOK, []map[string]interface{}{
+			{"Kind": 0, "Path": "/etc"},
+			{"Kind": 1, "Path": "/etc/nginx.conf"},
+			{"Kind": 2, "Path": "/tmp/nginx.pid"},
+		})
+	})
+	cs := &containerService{engine}
+
+	changes, err := cs.Diff(context.Background(), "web")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changes, []containers.FilesystemChange{
+		{Kind: "C", Path: "/etc"},
+		{Kind: "A", Path: "/etc/nginx.conf"},
+		{Kind: "D", Path: "/tmp/nginx.pid"},
+	})
+
+	_, err = cs.Diff(context.Background(), "unknown")
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestExport(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1.40/containers/web/export" {
+			writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "No such container"})
+			return
+		}
+		_, _ = w.Write([]byte("tar archive"))
+	})
+	cs := &containerService{engine}
+
+	var b bytes.Buffer
+	assert.NilError(t, cs.Export(context.Background(), "web", &b))
+	assert.Equal(t, b.String(), "tar archive")
+
+	err := cs.Export(context.Background(), "unknown", &b)
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
+
+func TestImport(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1.40/images/create")
+		query := r.URL.Query()
+		switch query.Get("fromSrc") {
+		case "-":
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NilError(t, err)
+			assert.Equal(t, string(body), "tar archive")
+			assert.Equal(t, query.Get("repo"), "myimage:v1")
+			assert.Equal(t, query.Get("message"), "imported")
+			assert.DeepEqual(t, query["changes"], []string{"CMD [\"sh\"]"})
+			_, _ = w.Write([]byte(`{"status":"sha256:abcdef"}` + "\n"))
+		case "https://example.com/rootfs.tar":
+			_, _ = w.Write([]byte(`{"errorDetail":{"message":"download failed"},"error":"download failed"}` + "\n"))
+		default:
+			t.Errorf("unexpected source %q", query.Get("fromSrc"))
+		}
+	})
+	cs := &containerService{engine}
+
+	id, err := cs.Import(context.Background(), containers.ImportRequest{
+		Source:    bytes.NewBufferString("tar archive"),
+		Reference: "myimage:v1",
+		Message:   "imported",
+		Changes:   []string{`CMD ["sh"]`},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, id, "sha256:abcdef")
+
+	_, err = cs.Import(context.Background(), containers.ImportRequest{SourceURL: "https://example.com/rootfs.tar"})
+	assert.Error(t, err, "download failed")
+}
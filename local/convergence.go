@@ -21,15 +21,20 @@ package local
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/compose-spec/compose-go/types"
 	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/docker/compose-cli/api/compose"
 	"github.com/docker/compose-cli/api/containers"
 	"github.com/docker/compose-cli/progress"
 )
@@ -37,36 +42,118 @@ import (
 const (
 	extLifecycle  = "x-lifecycle"
 	forceRecreate = "force_recreate"
-)
 
-func (s *local) ensureService(ctx context.Context, project *types.Project, service types.ServiceConfig) error {
-	err := s.waitDependencies(ctx, project, service)
-	if err != nil {
-		return err
-	}
+	// defaultHealthInterval is how often the health of dependencies is checked when no engine event is received
+	defaultHealthInterval = 5 * time.Second
+	// optionalDependencyTimeout bounds the wait for the optional dependencies to be healthy
+	optionalDependencyTimeout = time.Minute
+)
 
-	actual, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+// listServiceContainers lists the containers of a project once and groups them by service, so
+// converging a project doesn't require a list per service
+func (s *local) listServiceContainers(ctx context.Context, project *types.Project) (map[string][]moby.Container, error) {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
 		Filters: filters.NewArgs(
 			filters.Arg("label", fmt.Sprintf("%s=%s", projectLabel, project.Name)),
-			filters.Arg("label", fmt.Sprintf("%s=%s", serviceLabel, service.Name)),
 		),
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
+	observed := map[string][]moby.Container{}
+	for _, c := range withoutOneOffContainers(list) {
+		service := c.Labels[serviceLabel]
+		observed[service] = append(observed[service], c)
+	}
+	return observed, nil
+}
 
-	scale := getScale(service)
+// inspectContainers inspects containers concurrently, results are in the order of the list
+func (s *local) inspectContainers(ctx context.Context, list []moby.Container) ([]moby.ContainerJSON, error) {
+	result := make([]moby.ContainerJSON, len(list))
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, c := range list {
+		i, c := i, c
+		eg.Go(func() error {
+			container, err := s.containerService.apiClient.ContainerInspect(ctx, c.ID)
+			result[i] = container
+			return err
+		})
+	}
+	return result, eg.Wait()
+}
+
+// ensureService converges the containers of a service, it returns true when existing containers have been
+// recreated, even if it then failed, their dependents then need to be recreated too
+func (s *local) ensureService(ctx context.Context, project *types.Project, service types.ServiceConfig, actual []moby.Container, options compose.UpOptions) (bool, error) {
+	err := s.waitDependencies(ctx, project, service, options)
+	if err != nil {
+		return false, err
+	}
 
+	w := progress.ContextWriter(ctx)
 	eg, _ := errgroup.WithContext(ctx)
+	actual, err = s.scaleService(ctx, eg, project, service, actual, getScale(service))
+	if err != nil {
+		return false, err
+	}
+
+	expected, err := serviceHash(service)
+	if err != nil {
+		return false, err
+	}
+
+	recreated := false
+	for _, container := range actual {
+		container := container
+		diverged := container.Labels[configHashLabel] != expected
+		if diverged || service.Extensions[extLifecycle] == forceRecreate {
+			recreated = true
+			eg.Go(func() error {
+				return s.recreateContainer(ctx, project, service, container)
+			})
+			continue
+		}
+
+		if container.State == "running" {
+			// already running, skip
+			w.Event(containerEvent(getContainerName(container), service.Name, progress.Done, "Running"))
+			continue
+		}
+
+		eg.Go(func() error {
+			return s.restartContainer(ctx, service, container)
+		})
+	}
+	err = eg.Wait()
+	if err != nil {
+		// some containers may have been recreated before the failure
+		return recreated, err
+	}
+	w.Event(progress.Event{
+		ID:         fmt.Sprintf("Service %q", service.Name),
+		Status:     progress.Done,
+		StatusText: "Ready",
+	})
+	return recreated, nil
+}
+
+// scaleService schedules on eg the creation of the missing containers of a service and the removal of
+// the extra ones, the highest numbered first. It returns the containers of the service which are kept.
+func (s *local) scaleService(ctx context.Context, eg *errgroup.Group, project *types.Project, service types.ServiceConfig, actual []moby.Container, scale int) ([]moby.Container, error) {
+	w := progress.ContextWriter(ctx)
 	if len(actual) < scale {
 		next, err := nextContainerNumber(actual)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		missing := scale - len(actual)
 		for i := 0; i < missing; i++ {
 			number := next + i
-			name := fmt.Sprintf("%s_%s_%d", project.Name, service.Name, number)
+			name, err := containerName(project, service, number)
+			if err != nil {
+				return nil, err
+			}
 			eg.Go(func() error {
 				return s.createContainer(ctx, project, service, name, number)
 			})
@@ -74,63 +161,117 @@ func (s *local) ensureService(ctx context.Context, project *types.Project, servi
 	}
 
 	if len(actual) > scale {
+		sort.Slice(actual, func(i, j int) bool {
+			n, _ := strconv.Atoi(actual[i].Labels[containerNumberLabel])
+			m, _ := strconv.Atoi(actual[j].Labels[containerNumberLabel])
+			return n < m
+		})
 		for i := scale; i < len(actual); i++ {
 			container := actual[i]
 			eg.Go(func() error {
+				name := getContainerName(container)
+				w.Event(containerEvent(name, service.Name, progress.Working, "Stopping"))
 				err := s.containerService.Stop(ctx, container.ID, nil)
 				if err != nil {
 					return err
 				}
-				return s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
+				w.Event(containerEvent(name, service.Name, progress.Working, "Removing"))
+				err = s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
+				if err != nil {
+					return err
+				}
+				w.Event(containerEvent(name, service.Name, progress.Done, "Removed"))
+				return nil
 			})
 		}
 		actual = actual[:scale]
 	}
+	return actual, nil
+}
 
-	expected, err := jsonHash(service)
-	if err != nil {
-		return err
+// reportRunning reports the containers of a service which is already up to date
+func reportRunning(ctx context.Context, service types.ServiceConfig, containers []moby.Container) {
+	w := progress.ContextWriter(ctx)
+	for _, c := range containers {
+		w.Event(containerEvent(getContainerName(c), service.Name, progress.Done, "Running"))
 	}
+	w.Event(progress.Event{
+		ID:         fmt.Sprintf("Service %q", service.Name),
+		Status:     progress.Done,
+		StatusText: "Ready",
+	})
+}
 
-	for _, container := range actual {
-		container := container
-		diverged := container.Labels[configHashLabel] != expected
-		if diverged || service.Extensions[extLifecycle] == forceRecreate {
-			eg.Go(func() error {
-				return s.recreateContainer(ctx, project, service, container)
-			})
-			continue
-		}
-
-		if container.State == "running" {
-			// already running, skip
-			continue
-		}
-
-		eg.Go(func() error {
-			return s.restartContainer(ctx, service, container)
-		})
+// containerEvent returns a progress event for a service container, rendered under the service event
+func containerEvent(name string, service string, status progress.EventStatus, text string) progress.Event {
+	return progress.Event{
+		ID:         fmt.Sprintf("Container %q", name),
+		ParentID:   fmt.Sprintf("Service %q", service),
+		Status:     status,
+		StatusText: text,
 	}
-	return eg.Wait()
 }
 
-func (s *local) waitDependencies(ctx context.Context, project *types.Project, service types.ServiceConfig) error {
+// waitDependencies waits for the dependencies of service declaring the service_healthy condition. Their health is
+// checked whenever the engine reports an event for their containers, and at the health interval otherwise.
+func (s *local) waitDependencies(ctx context.Context, project *types.Project, service types.ServiceConfig, options compose.UpOptions) error {
+	interval := defaultHealthInterval
+	if options.HealthInterval > 0 {
+		interval = options.HealthInterval
+	}
+
 	eg, _ := errgroup.WithContext(ctx)
 	for dep, config := range service.DependsOn {
+		dep := dep
 		switch config.Condition {
 		case "service_healthy":
+			optional := compose.IsOptionalDependency(service, dep)
+			timeout := options.WaitTimeout
+			if optional && (timeout == 0 || timeout > optionalDependencyTimeout) {
+				timeout = optionalDependencyTimeout
+			}
 			eg.Go(func() error {
-				ticker := time.NewTicker(500 * time.Millisecond)
+				waitCtx := ctx
+				if timeout > 0 {
+					var cancel context.CancelFunc
+					waitCtx, cancel = context.WithTimeout(ctx, timeout)
+					defer cancel()
+				}
+				// health is checked again whenever the engine reports an event for the dependency
+				// containers, the ticker only catches up with events which may have been missed
+				eventsCtx, cancel := context.WithCancel(waitCtx)
+				defer cancel()
+				messages, errs := s.serviceEvents(eventsCtx, project.Name, dep)
+				ticker := time.NewTicker(interval)
 				defer ticker.Stop()
 				for {
-					<-ticker.C
 					healthy, err := s.isServiceHealthy(ctx, project, dep)
+					if err == nil && !healthy {
+						select {
+						case <-waitCtx.Done():
+							err = waitTimeoutError(dep, timeout)
+						case <-messages:
+						case e := <-errs:
+							logrus.Debugf("stopped receiving events for service %q: %v", dep, e)
+							messages, errs = nil, nil
+						case <-ticker.C:
+						}
+						if err == nil {
+							continue
+						}
+					}
 					if err != nil {
+						if ctx.Err() != nil {
+							// cancelled rather than timed out, even optional dependencies stop the convergence
+							return ctx.Err()
+						}
+						if optional {
+							logrus.Warnf("optional dependency %q of service %q is not healthy: %v", dep, service.Name, err)
+							return nil
+						}
 						return err
 					}
-					if healthy {
-						return nil
-					}
+					return nil
 				}
 			})
 		}
@@ -138,6 +279,38 @@ func (s *local) waitDependencies(ctx context.Context, project *types.Project, se
 	return eg.Wait()
 }
 
+// waitTimeoutError reports a dependency which didn't become healthy in time, the duration is
+// only mentioned when a timeout was set
+func waitTimeoutError(dependency string, timeout time.Duration) error {
+	if timeout == 0 {
+		return errors.Errorf("timeout waiting for dependency %q to be healthy", dependency)
+	}
+	return errors.Errorf("timeout waiting for dependency %q to be healthy after %s", dependency, timeout)
+}
+
+// serviceEvents subscribes to the engine events of the containers of a service
+func (s *local) serviceEvents(ctx context.Context, projectName string, service string) (<-chan events.Message, <-chan error) {
+	return s.containerService.apiClient.Events(ctx, moby.EventsOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", events.ContainerEventType),
+			filters.Arg("label", fmt.Sprintf("%s=%s", projectLabel, projectName)),
+			filters.Arg("label", fmt.Sprintf("%s=%s", serviceLabel, service)),
+		),
+	})
+}
+
+// withoutOneOffContainers excludes containers created by `compose run` from the service replicas
+func withoutOneOffContainers(containers []moby.Container) []moby.Container {
+	var result []moby.Container
+	for _, c := range containers {
+		if c.Labels[oneoffLabel] == "True" {
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
 func nextContainerNumber(containers []moby.Container) (int, error) {
 	max := 0
 	for _, c := range containers {
@@ -165,35 +338,18 @@ func getScale(config types.ServiceConfig) int {
 
 func (s *local) createContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, name string, number int) error {
 	w := progress.ContextWriter(ctx)
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Working,
-		StatusText: "Create",
-	})
-	err := s.runContainer(ctx, project, service, name, number, nil)
-	if err != nil {
-		return err
-	}
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Done,
-		StatusText: "Created",
-	})
-	return nil
+	w.Event(containerEvent(name, service.Name, progress.Working, "Creating"))
+	return s.runContainer(ctx, project, service, name, number, nil)
 }
 
 func (s *local) recreateContainer(ctx context.Context, project *types.Project, service types.ServiceConfig, container moby.Container) error {
 	w := progress.ContextWriter(ctx)
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Working,
-		StatusText: "Recreate",
-	})
+	name := getContainerName(container)
+	w.Event(containerEvent(name, service.Name, progress.Working, "Recreating"))
 	err := s.containerService.Stop(ctx, container.ID, nil)
 	if err != nil {
 		return err
 	}
-	name := getContainerName(container)
 	tmpName := fmt.Sprintf("%s_%s", container.ID[:12], name)
 	err = s.containerService.apiClient.ContainerRename(ctx, container.ID, tmpName)
 	if err != nil {
@@ -207,48 +363,29 @@ func (s *local) recreateContainer(ctx context.Context, project *types.Project, s
 	if err != nil {
 		return err
 	}
-	err = s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
-	if err != nil {
-		return err
-	}
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Done,
-		StatusText: "Recreated",
-	})
-	setDependentLifecycle(project, service.Name, forceRecreate)
-	return nil
+	return s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
 }
 
-// setDependentLifecycle define the Lifecycle strategy for all services to depend on specified service
-func setDependentLifecycle(project *types.Project, service string, strategy string) {
-	for i, s := range project.Services {
-		if contains(s.GetDependencies(), service) {
-			if s.Extensions == nil {
-				s.Extensions = map[string]interface{}{}
-			}
-			s.Extensions[extLifecycle] = strategy
-			project.Services[i] = s
-		}
+// withLifecycle returns a copy of service with the Lifecycle strategy, the extensions of the project are left untouched
+func withLifecycle(service types.ServiceConfig, strategy string) types.ServiceConfig {
+	extensions := map[string]interface{}{}
+	for k, v := range service.Extensions {
+		extensions[k] = v
 	}
+	extensions[extLifecycle] = strategy
+	service.Extensions = extensions
+	return service
 }
 
 func (s *local) restartContainer(ctx context.Context, service types.ServiceConfig, container moby.Container) error {
 	w := progress.ContextWriter(ctx)
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Working,
-		StatusText: "Restart",
-	})
+	name := getContainerName(container)
+	w.Event(containerEvent(name, service.Name, progress.Working, "Starting"))
 	err := s.containerService.Start(ctx, container.ID)
 	if err != nil {
 		return err
 	}
-	w.Event(progress.Event{
-		ID:         fmt.Sprintf("Service %q", service.Name),
-		Status:     progress.Done,
-		StatusText: "Restarted",
-	})
+	w.Event(containerEvent(name, service.Name, progress.Done, "Started"))
 	return nil
 }
 
@@ -257,28 +394,49 @@ func (s *local) runContainer(ctx context.Context, project *types.Project, servic
 	if err != nil {
 		return err
 	}
+	err = s.resolveServiceNamespaces(ctx, project, hostConfig, networkingConfig)
+	if err != nil {
+		return err
+	}
+	err = s.resolveVolumesFrom(ctx, project, hostConfig)
+	if err != nil {
+		return err
+	}
+	injected, err := buildInjectedFiles(project, service)
+	if err != nil {
+		return err
+	}
+	w := progress.ContextWriter(ctx)
 	id, err := s.containerService.create(ctx, containerConfig, hostConfig, networkingConfig, name)
 	if err != nil {
 		return err
 	}
-	for net := range service.Networks {
-		name := fmt.Sprintf("%s_%s", project.Name, net)
-		err = s.connectContainerToNetwork(ctx, id, service.Name, name)
+	w.Event(containerEvent(name, service.Name, progress.Working, "Created"))
+	err = s.injectFiles(ctx, id, injected)
+	if err != nil {
+		return err
+	}
+	for net, config := range service.Networks {
+		networkName := project.Networks[net].Name
+		if networkName == string(hostConfig.NetworkMode) {
+			continue
+		}
+		err = s.connectContainerToNetwork(ctx, id, networkName, getEndpointSettings(project, service, net, config))
 		if err != nil {
 			return err
 		}
 	}
+	w.Event(containerEvent(name, service.Name, progress.Working, "Starting"))
 	err = s.containerService.apiClient.ContainerStart(ctx, id, moby.ContainerStartOptions{})
 	if err != nil {
 		return err
 	}
+	w.Event(containerEvent(name, service.Name, progress.Done, "Started"))
 	return nil
 }
 
-func (s *local) connectContainerToNetwork(ctx context.Context, id string, service string, n string) error {
-	err := s.containerService.apiClient.NetworkConnect(ctx, n, id, &network.EndpointSettings{
-		Aliases: []string{service},
-	})
+func (s *local) connectContainerToNetwork(ctx context.Context, id string, n string, settings *network.EndpointSettings) error {
+	err := s.containerService.apiClient.NetworkConnect(ctx, n, id, settings)
 	if err != nil {
 		return err
 	}
@@ -296,21 +454,27 @@ func (s *local) isServiceHealthy(ctx context.Context, project *types.Project, se
 		return false, err
 	}
 
-	for _, c := range containers {
-		container, err := s.containerService.apiClient.ContainerInspect(ctx, c.ID)
-		if err != nil {
-			return false, err
-		}
+	inspected, err := s.inspectContainers(ctx, containers)
+	if err != nil {
+		return false, err
+	}
+
+	w := progress.ContextWriter(ctx)
+	healthy := true
+	for i, c := range containers {
+		container := inspected[i]
 		if container.State == nil || container.State.Health == nil {
 			return false, fmt.Errorf("container for service %q has no healthcheck configured", service)
 		}
+		name := getContainerName(c)
 		switch container.State.Health.Status {
-		case "starting":
-			return false, nil
-		case "unhealthy":
-			return false, nil
+		case "starting", "unhealthy":
+			w.Event(containerEvent(name, service, progress.Working, "Waiting"))
+			healthy = false
+		default:
+			w.Event(containerEvent(name, service, progress.Done, "Healthy"))
 		}
 	}
-	return true, nil
+	return healthy, nil
 
 }
@@ -0,0 +1,319 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/client"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/progress"
+)
+
+// recordingWriter records the progress events it gets, as "<id> <status text>"
+type recordingWriter struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (w *recordingWriter) Start(context.Context) error {
+	return nil
+}
+
+func (w *recordingWriter) Stop() {}
+
+func (w *recordingWriter) Event(e progress.Event) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.events = append(w.events, fmt.Sprintf("%s %s", e.ID, e.StatusText))
+}
+
+func TestWaitTimeoutError(t *testing.T) {
+	assert.Error(t, waitTimeoutError("db", 0), `timeout waiting for dependency "db" to be healthy`)
+	assert.Error(t, waitTimeoutError("db", 30*time.Second), `timeout waiting for dependency "db" to be healthy after 30s`)
+}
+
+func TestContainerTransitions(t *testing.T) {
+	inspected := func(id string, health string) moby.ContainerJSON {
+		return moby.ContainerJSON{
+			ContainerJSONBase: &moby.ContainerJSONBase{
+				ID:    id,
+				State: &moby.ContainerState{Health: &moby.Health{Status: health}},
+			},
+		}
+	}
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.40/containers/create":
+			writeJSON(t, w, http.StatusCreated, map[string]string{"Id": "web1"})
+		case "/v1.40/containers/web1/start":
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1.40/containers/json":
+			writeJSON(t, w, http.StatusOK, []moby.Container{
+				{ID: "c1", Names: []string{"/demo_db_1"}},
+				{ID: "c2", Names: []string{"/demo_db_2"}},
+			})
+		case "/v1.40/containers/c1/json":
+			writeJSON(t, w, http.StatusOK, inspected("c1", "starting"))
+		case "/v1.40/containers/c2/json":
+			writeJSON(t, w, http.StatusOK, inspected("c2", "healthy"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	s := newLocal(engine)
+	recorder := &recordingWriter{}
+	ctx := progress.WithContextWriter(context.Background(), recorder)
+	project := &types.Project{
+		Name:     "demo",
+		Services: types.Services{{Name: "web", Image: "nginx"}, {Name: "db", Image: "postgres"}},
+	}
+
+	err := s.createContainer(ctx, project, project.Services[0], "demo_web_1", 1)
+	assert.NilError(t, err)
+	healthy, err := s.isServiceHealthy(ctx, project, "db")
+	assert.NilError(t, err)
+	assert.Assert(t, !healthy)
+
+	assert.DeepEqual(t, recorder.events, []string{
+		`Container "demo_web_1" Creating`,
+		`Container "demo_web_1" Created`,
+		`Container "demo_web_1" Starting`,
+		`Container "demo_web_1" Started`,
+		`Container "demo_db_1" Waiting`,
+		`Container "demo_db_2" Healthy`,
+	})
+}
+
+func TestListServiceContainers(t *testing.T) {
+	var lists int32
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.URL.Path, "/v1.40/containers/json")
+		atomic.AddInt32(&lists, 1)
+		assert.Assert(t, strings.Contains(r.URL.Query().Get("filters"), `"com.docker.compose.project=demo"`))
+		writeJSON(t, w, http.StatusOK, []moby.Container{
+			{ID: "web1", Labels: map[string]string{serviceLabel: "web"}},
+			{ID: "db1", Labels: map[string]string{serviceLabel: "db"}},
+			{ID: "web2", Labels: map[string]string{serviceLabel: "web"}},
+			{ID: "web3", Labels: map[string]string{serviceLabel: "web", oneoffLabel: "True"}},
+			{ID: "web4", Labels: map[string]string{serviceLabel: "web"}},
+		})
+	})
+	s := newLocal(engine)
+
+	observed, err := s.listServiceContainers(context.Background(), &types.Project{Name: "demo"})
+	assert.NilError(t, err)
+	assert.Equal(t, atomic.LoadInt32(&lists), int32(1))
+	ids := map[string][]string{}
+	for service, list := range observed {
+		for _, c := range list {
+			ids[service] = append(ids[service], c.ID)
+		}
+	}
+	assert.DeepEqual(t, ids, map[string][]string{
+		"web": {"web1", "web2", "web4"},
+		"db":  {"db1"},
+	})
+}
+
+func TestInspectContainers(t *testing.T) {
+	var inspecting int32
+	inFlight := make(chan struct{})
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1.40/containers/"), "/json")
+		if id == "unknown" {
+			writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "No such container: unknown"})
+			return
+		}
+		// the replicas are inspected concurrently, so none is answered before the 3 requests are in flight
+		if atomic.AddInt32(&inspecting, 1) == 3 {
+			close(inFlight)
+		}
+		select {
+		case <-inFlight:
+		case <-time.After(5 * time.Second):
+			t.Errorf("container %s inspected sequentially", id)
+		}
+		writeJSON(t, w, http.StatusOK, moby.ContainerJSON{
+			ContainerJSONBase: &moby.ContainerJSONBase{ID: id, Name: "/demo_" + id},
+		})
+	})
+	s := newLocal(engine)
+
+	inspected, err := s.inspectContainers(context.Background(), []moby.Container{{ID: "web_1"}, {ID: "web_2"}, {ID: "web_3"}})
+	assert.NilError(t, err)
+	var names []string
+	for _, c := range inspected {
+		names = append(names, c.Name)
+	}
+	// results are in the order of the list, whatever the order the engine answered
+	assert.DeepEqual(t, names, []string{"/demo_web_1", "/demo_web_2", "/demo_web_3"})
+
+	_, err = s.inspectContainers(context.Background(), []moby.Container{{ID: "web_1"}, {ID: "unknown"}})
+	assert.Assert(t, client.IsErrNotFound(err))
+	assert.ErrorContains(t, err, "No such container: unknown")
+}
+
+// healthEngine fakes the engine of the db service of the demo project, whose container reports the health
+// returned for each inspection. An engine event is sent for the container after its first inspection.
+type healthEngine struct {
+	inspections  int32
+	subscribed   chan struct{}
+	unsubscribed chan struct{}
+}
+
+func newHealthEngine(t *testing.T, health func(inspection int32) string) (*healthEngine, *client.Client) {
+	e := &healthEngine{
+		subscribed:   make(chan struct{}),
+		unsubscribed: make(chan struct{}),
+	}
+	event := make(chan struct{}, 1)
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.40/containers/json":
+			writeJSON(t, w, http.StatusOK, []moby.Container{{ID: "db1", Names: []string{"/demo_db_1"}}})
+		case "/v1.40/containers/db1/json":
+			n := atomic.AddInt32(&e.inspections, 1)
+			if n == 1 {
+				event <- struct{}{}
+			}
+			writeJSON(t, w, http.StatusOK, moby.ContainerJSON{
+				ContainerJSONBase: &moby.ContainerJSONBase{
+					ID:    "db1",
+					State: &moby.ContainerState{Health: &moby.Health{Status: health(n)}},
+				},
+			})
+		case "/v1.40/events":
+			filter := r.URL.Query().Get("filters")
+			assert.Assert(t, strings.Contains(filter, `"com.docker.compose.project=demo"`), filter)
+			assert.Assert(t, strings.Contains(filter, `"com.docker.compose.service=db"`), filter)
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			close(e.subscribed)
+			for {
+				select {
+				case <-event:
+					assert.NilError(t, json.NewEncoder(w).Encode(events.Message{
+						Type:   events.ContainerEventType,
+						Action: "health_status: healthy",
+						Actor:  events.Actor{ID: "db1"},
+					}))
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					close(e.unsubscribed)
+					return
+				}
+			}
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	return e, engine
+}
+
+// assertUnsubscribed checks the events stream has been closed
+func (e *healthEngine) assertUnsubscribed(t *testing.T) {
+	select {
+	case <-e.unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Error("events stream still open")
+	}
+}
+
+func TestWaitDependenciesOnEvents(t *testing.T) {
+	e, engine := newHealthEngine(t, func(inspection int32) string {
+		if inspection == 1 {
+			return "starting"
+		}
+		return "healthy"
+	})
+	s := newLocal(engine)
+	project := &types.Project{Name: "demo"}
+	web := types.ServiceConfig{
+		Name:      "web",
+		DependsOn: types.DependsOnConfig{"db": {Condition: "service_healthy"}},
+	}
+
+	// the health interval is never reached, the event triggers the second check
+	err := s.waitDependencies(context.Background(), project, web, compose.UpOptions{HealthInterval: time.Hour, WaitTimeout: time.Minute})
+	assert.NilError(t, err)
+	assert.Equal(t, atomic.LoadInt32(&e.inspections), int32(2))
+	e.assertUnsubscribed(t)
+}
+
+func TestWaitUnhealthyDependency(t *testing.T) {
+	unhealthy := func(int32) string {
+		return "unhealthy"
+	}
+	project := &types.Project{Name: "demo"}
+	web := types.ServiceConfig{
+		Name:      "web",
+		DependsOn: types.DependsOnConfig{"db": {Condition: "service_healthy"}},
+	}
+	options := compose.UpOptions{HealthInterval: time.Hour, WaitTimeout: 200 * time.Millisecond}
+
+	e, engine := newHealthEngine(t, unhealthy)
+	err := newLocal(engine).waitDependencies(context.Background(), project, web, options)
+	assert.Error(t, err, `timeout waiting for dependency "db" to be healthy after 200ms`)
+	assert.Equal(t, atomic.LoadInt32(&e.inspections), int32(2))
+	e.assertUnsubscribed(t)
+
+	web.Extensions = map[string]interface{}{compose.OptionalDependenciesExtension: []interface{}{"db"}}
+	e, engine = newHealthEngine(t, unhealthy)
+	err = newLocal(engine).waitDependencies(context.Background(), project, web, options)
+	assert.NilError(t, err)
+	e.assertUnsubscribed(t)
+}
+
+func TestWaitDependenciesCancelled(t *testing.T) {
+	e, engine := newHealthEngine(t, func(int32) string {
+		return "starting"
+	})
+	s := newLocal(engine)
+	project := &types.Project{Name: "demo"}
+	web := types.ServiceConfig{
+		Name:      "web",
+		DependsOn: types.DependsOnConfig{"db": {Condition: "service_healthy"}},
+		// cancellation stops the convergence, even for optional dependencies
+		Extensions: map[string]interface{}{compose.OptionalDependenciesExtension: []interface{}{"db"}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-e.subscribed
+		cancel()
+	}()
+	err := s.waitDependencies(ctx, project, web, compose.UpOptions{HealthInterval: time.Hour})
+	assert.Assert(t, errors.Is(err, context.Canceled), err)
+	e.assertUnsubscribed(t)
+}
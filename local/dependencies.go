@@ -26,6 +26,8 @@ import (
 
 	"github.com/compose-spec/compose-go/types"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
 )
 
 type ServiceStatus int
@@ -35,45 +37,64 @@ const (
 	ServiceStarted
 )
 
+// inDependencyOrder runs fn for the services in dependency order, retrying it according to the retry policy of the services
 func inDependencyOrder(ctx context.Context, project *types.Project, fn func(context.Context, types.ServiceConfig) error) error {
+	return compose.Walk(ctx, project, func(ctx context.Context, service types.ServiceConfig) error {
+		return withRetry(ctx, service, fn, func(error) {})
+	}, compose.WalkOptions{})
+}
+
+// inReverseDependencyOrder runs fn for the services once all the services depending on them
+// have been processed, all the services are processed even when fn fails for some of them
+func inReverseDependencyOrder(ctx context.Context, project *types.Project, fn func(context.Context, types.ServiceConfig) error) error {
 	g := NewGraph(project.Services)
 	if b, err := g.HasCycles(); b {
 		return err
 	}
 
-	leaves := g.Leaves()
-
+	var (
+		lock      sync.Mutex
+		scheduled = map[string]bool{}
+	)
 	eg, _ := errgroup.WithContext(ctx)
-	eg.Go(func() error {
-		return run(ctx, g, eg, leaves, fn)
-	})
-
+	var schedule func(nodes []*Vertex)
+	schedule = func(nodes []*Vertex) {
+		lock.Lock()
+		defer lock.Unlock()
+		for _, node := range nodes {
+			n := node
+			if scheduled[n.Key] || len(g.FilterParents(n.Key, ServiceStarted)) != 0 {
+				continue
+			}
+			scheduled[n.Key] = true
+			eg.Go(func() error {
+				err := fn(ctx, n.Service)
+				g.UpdateStatus(n.Key, ServiceStopped)
+				schedule(n.GetChildren())
+				return err
+			})
+		}
+	}
+	// all the services are considered started until processed
+	for key := range g.Vertices {
+		g.UpdateStatus(key, ServiceStarted)
+	}
+	schedule(g.Roots())
 	return eg.Wait()
 }
 
-// Note: this could be `graph.walk` or whatever
-func run(ctx context.Context, graph *Graph, eg *errgroup.Group, nodes []*Vertex, fn func(context.Context, types.ServiceConfig) error) error {
-	for _, node := range nodes {
-		n := node
-		// Don't start this service yet if all of its children have
-		// not been started yet.
-		if len(graph.FilterChildren(n.Service.Name, ServiceStopped)) != 0 {
-			continue
-		}
-
-		eg.Go(func() error {
-			err := fn(ctx, n.Service)
-			if err != nil {
-				return err
+// progressTreeParents picks for each dependency the service it is rendered
+// under in the progress tree, the first dependent service by name
+func progressTreeParents(project *types.Project) map[string]string {
+	parents := map[string]string{}
+	for _, s := range project.Services {
+		for _, dep := range compose.ServiceDependencies(s) {
+			if p, ok := parents[dep]; !ok || s.Name < p {
+				parents[dep] = s.Name
 			}
-
-			graph.UpdateStatus(n.Service.Name, ServiceStarted)
-
-			return run(ctx, graph, eg, n.GetParents(), fn)
-		})
+		}
 	}
-
-	return nil
+	return parents
 }
 
 type Graph struct {
@@ -97,6 +118,14 @@ func (v *Vertex) GetParents() []*Vertex {
 	return res
 }
 
+func (v *Vertex) GetChildren() []*Vertex {
+	var res []*Vertex
+	for _, c := range v.Children {
+		res = append(res, c)
+	}
+	return res
+}
+
 func NewGraph(services types.Services) *Graph {
 	graph := &Graph{
 		lock:     sync.RWMutex{},
@@ -108,7 +137,7 @@ func NewGraph(services types.Services) *Graph {
 	}
 
 	for _, s := range services {
-		for _, name := range s.GetDependencies() {
+		for _, name := range compose.ServiceDependencies(s) {
 			graph.AddEdge(s.Name, name)
 		}
 	}
@@ -174,6 +203,21 @@ func (g *Graph) Leaves() []*Vertex {
 	return res
 }
 
+// Roots returns the vertices no other vertex depends on
+func (g *Graph) Roots() []*Vertex {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var res []*Vertex
+	for _, v := range g.Vertices {
+		if len(v.Parents) == 0 {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
 func (g *Graph) UpdateStatus(key string, status ServiceStatus) {
 	g.lock.Lock()
 	defer g.lock.Unlock()
@@ -196,6 +240,22 @@ func (g *Graph) FilterChildren(key string, status ServiceStatus) []*Vertex {
 	return res
 }
 
+func (g *Graph) FilterParents(key string, status ServiceStatus) []*Vertex {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var res []*Vertex
+	vertex := g.Vertices[key]
+
+	for _, parent := range vertex.Parents {
+		if parent.Status == status {
+			res = append(res, parent)
+		}
+	}
+
+	return res
+}
+
 func (g *Graph) HasCycles() (bool, error) {
 	discovered := []string{}
 	finished := []string{}
@@ -25,6 +25,8 @@ import (
 	"gotest.tools/v3/assert"
 
 	"github.com/compose-spec/compose-go/types"
+
+	"github.com/docker/compose-cli/api/compose"
 )
 
 func TestInDependencyOrder(t *testing.T) {
@@ -57,3 +59,123 @@ func TestInDependencyOrder(t *testing.T) {
 	assert.Equal(t, <-order, "test2")
 	assert.Equal(t, <-order, "test1")
 }
+
+func TestInReverseDependencyOrder(t *testing.T) {
+	order := make(chan string)
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "test1",
+				DependsOn: map[string]types.ServiceDependency{
+					"test2": {},
+				},
+			},
+			{
+				Name: "test2",
+				DependsOn: map[string]types.ServiceDependency{
+					"test3": {},
+				},
+			},
+			{
+				Name: "test3",
+			},
+		},
+	}
+	//nolint:errcheck, unparam
+	go inReverseDependencyOrder(context.TODO(), &project, func(ctx context.Context, config types.ServiceConfig) error {
+		order <- config.Name
+		return nil
+	})
+	assert.Equal(t, <-order, "test1")
+	assert.Equal(t, <-order, "test2")
+	assert.Equal(t, <-order, "test3")
+}
+
+func TestProgressTreeParents(t *testing.T) {
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name: "web",
+				DependsOn: map[string]types.ServiceDependency{
+					"api": {},
+					"db":  {},
+				},
+			},
+			{
+				Name: "api",
+				DependsOn: map[string]types.ServiceDependency{
+					"db": {},
+				},
+			},
+			{
+				Name: "db",
+			},
+		},
+	}
+	assert.DeepEqual(t, progressTreeParents(&project), map[string]string{
+		"api": "web",
+		"db":  "api",
+	})
+}
+
+func TestInDependencyOrderWithNetworkMode(t *testing.T) {
+	order := make(chan string)
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:        "sidecar",
+				NetworkMode: "service:app",
+			},
+			{
+				Name: "app",
+			},
+		},
+	}
+	//nolint:errcheck, unparam
+	go inDependencyOrder(context.TODO(), &project, func(ctx context.Context, config types.ServiceConfig) error {
+		order <- config.Name
+		return nil
+	})
+	assert.Equal(t, <-order, "app")
+	assert.Equal(t, <-order, "sidecar")
+}
+
+func TestInDependencyOrderWithVolumesFrom(t *testing.T) {
+	order := make(chan string)
+	project := types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:        "app",
+				VolumesFrom: []string{"data:ro", "container:external"},
+			},
+			{
+				Name: "data",
+			},
+		},
+	}
+	//nolint:errcheck, unparam
+	go inDependencyOrder(context.TODO(), &project, func(ctx context.Context, config types.ServiceConfig) error {
+		order <- config.Name
+		return nil
+	})
+	assert.Equal(t, <-order, "data")
+	assert.Equal(t, <-order, "app")
+}
+
+func TestParseVolumesFrom(t *testing.T) {
+	for spec, expected := range map[string]struct {
+		source      string
+		isContainer bool
+		mode        string
+	}{
+		"data":                  {source: "data"},
+		"data:ro":               {source: "data", mode: "ro"},
+		"container:external":    {source: "external", isContainer: true},
+		"container:external:rw": {source: "external", isContainer: true, mode: "rw"},
+	} {
+		source, isContainer, mode := compose.ParseVolumesFrom(spec)
+		assert.Equal(t, source, expected.source, spec)
+		assert.Equal(t, isContainer, expected.isContainer, spec)
+		assert.Equal(t, mode, expected.mode, spec)
+	}
+}
@@ -0,0 +1,97 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"sort"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// projectDiff compares the services of a project with the containers deployed for it, so
+// converging the project only acts on the services which changed since the last up
+type projectDiff struct {
+	// Added services have no container yet
+	Added []string
+	// Removed services have containers but are not declared by the project anymore
+	Removed []string
+	// Modified services have diverging, missing, extra or stopped containers
+	Modified []string
+	// Unchanged services have all their containers up to date and running
+	Unchanged []string
+}
+
+func diffProject(project *types.Project, observed map[string][]moby.Container) (projectDiff, error) {
+	diff := projectDiff{}
+	declared := map[string]bool{}
+	for _, service := range project.Services {
+		declared[service.Name] = true
+		containers := observed[service.Name]
+		if len(containers) == 0 {
+			diff.Added = append(diff.Added, service.Name)
+			continue
+		}
+		upToDate, err := isUpToDate(service, containers)
+		if err != nil {
+			return diff, err
+		}
+		if upToDate {
+			diff.Unchanged = append(diff.Unchanged, service.Name)
+		} else {
+			diff.Modified = append(diff.Modified, service.Name)
+		}
+	}
+	for service := range observed {
+		if !declared[service] {
+			diff.Removed = append(diff.Removed, service)
+		}
+	}
+	sort.Strings(diff.Removed)
+	return diff, nil
+}
+
+func isUpToDate(service types.ServiceConfig, containers []moby.Container) (bool, error) {
+	if len(containers) != getScale(service) || service.Extensions[extLifecycle] == forceRecreate {
+		return false, nil
+	}
+	expected, err := serviceHash(service)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range containers {
+		if c.Labels[configHashLabel] != expected || c.State != "running" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (d projectDiff) isUnchanged(service string) bool {
+	return contains(d.Unchanged, service)
+}
+
+// UpPlan isn't implemented, local containers are converged without being reviewed first
+func (s *local) UpPlan(ctx context.Context, project *types.Project) (compose.ChangePlan, error) {
+	return compose.ChangePlan{}, errdefs.ErrNotImplemented
+}
@@ -0,0 +1,70 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestDiffProject(t *testing.T) {
+	replicas := uint64(2)
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "web", Image: "nginx"},
+			{Name: "db", Image: "postgres"},
+			{Name: "cache", Image: "redis"},
+			{Name: "worker", Image: "worker", Deploy: &types.DeployConfig{Replicas: &replicas}},
+			{Name: "proxy", Image: "traefik"},
+		},
+	}
+	hash := func(i int) string {
+		h, err := serviceHash(project.Services[i])
+		assert.NilError(t, err)
+		return h
+	}
+	container := func(hash string, state string) moby.Container {
+		return moby.Container{
+			Labels: map[string]string{configHashLabel: hash},
+			State:  state,
+		}
+	}
+	observed := map[string][]moby.Container{
+		"web":    {container(hash(0), "running")},
+		"db":     {container("outdated", "running")},
+		"worker": {container(hash(3), "running")},
+		"proxy":  {container(hash(4), "exited")},
+		"legacy": {container("legacy", "running")},
+	}
+
+	diff, err := diffProject(project, observed)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, diff, projectDiff{
+		Added:     []string{"cache"},
+		Removed:   []string{"legacy"},
+		Modified:  []string{"db", "worker", "proxy"},
+		Unchanged: []string{"web"},
+	})
+	assert.Assert(t, diff.isUnchanged("web"))
+	assert.Assert(t, !diff.isUnchanged("db"))
+}
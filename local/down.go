@@ -0,0 +1,212 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/api/containers"
+	"github.com/docker/compose-cli/progress"
+)
+
+func (s *local) Down(ctx context.Context, projectName string) error {
+	plan, list, err := s.downPlan(ctx, projectName)
+	if err != nil {
+		return err
+	}
+
+	eg, _ := errgroup.WithContext(ctx)
+	w := progress.ContextWriter(ctx)
+	for _, c := range list {
+		container := c
+		eg.Go(func() error {
+			w.Event(progress.Event{
+				ID:     getContainerName(container),
+				Text:   "Stopping",
+				Status: progress.Working,
+			})
+			err := s.containerService.Stop(ctx, container.ID, nil)
+			if err != nil {
+				return err
+			}
+			w.Event(progress.Event{
+				ID:     getContainerName(container),
+				Text:   "Removing",
+				Status: progress.Working,
+			})
+			err = s.containerService.Delete(ctx, container.ID, containers.DeleteRequest{})
+			if err != nil {
+				return err
+			}
+			w.Event(progress.Event{
+				ID:     getContainerName(container),
+				Text:   "Removed",
+				Status: progress.Done,
+			})
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	for _, r := range plan {
+		if r.Type != compose.NetworkResource || !r.Remove {
+			continue
+		}
+		id := fmt.Sprintf("Network %q", r.Name)
+		w.Event(progress.Event{
+			ID:     id,
+			Text:   "Removing",
+			Status: progress.Working,
+		})
+		if err := s.containerService.apiClient.NetworkRemove(ctx, r.Name); err != nil {
+			return err
+		}
+		w.Event(progress.Event{
+			ID:     id,
+			Text:   "Removed",
+			Status: progress.Done,
+		})
+	}
+	return nil
+}
+
+func (s *local) DownPlan(ctx context.Context, projectName string) ([]compose.DownResource, error) {
+	plan, _, err := s.downPlan(ctx, projectName)
+	return plan, err
+}
+
+// downPlan lists the resources used by the project containers, networks are removed unless
+// they are external or shared with other containers, volumes are always preserved
+func (s *local) downPlan(ctx context.Context, projectName string) ([]compose.DownResource, []moby.Container, error) {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+		),
+		All: true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return getContainerName(list[i]) < getContainerName(list[j])
+	})
+
+	plan := []compose.DownResource{}
+	projectContainers := map[string]bool{}
+	var networks, volumes []string
+	for _, c := range list {
+		projectContainers[c.ID] = true
+		plan = append(plan, compose.DownResource{
+			Type:   compose.ContainerResource,
+			Name:   getContainerName(c),
+			Remove: true,
+		})
+		if c.NetworkSettings != nil {
+			for name := range c.NetworkSettings.Networks {
+				if !contains(networks, name) {
+					networks = append(networks, name)
+				}
+			}
+		}
+		for _, m := range c.Mounts {
+			if m.Type == mount.TypeVolume && !contains(volumes, m.Name) {
+				volumes = append(volumes, m.Name)
+			}
+		}
+	}
+	sort.Strings(networks)
+	sort.Strings(volumes)
+
+	for _, name := range networks {
+		resource, err := s.planNetworkRemoval(ctx, projectName, name, projectContainers)
+		if err != nil {
+			return nil, nil, err
+		}
+		plan = append(plan, resource)
+	}
+	for _, name := range volumes {
+		resource, err := s.planVolumeRemoval(ctx, name, projectContainers)
+		if err != nil {
+			return nil, nil, err
+		}
+		plan = append(plan, resource)
+	}
+	return plan, list, nil
+}
+
+func (s *local) planNetworkRemoval(ctx context.Context, projectName string, name string, projectContainers map[string]bool) (compose.DownResource, error) {
+	resource := compose.DownResource{
+		Type: compose.NetworkResource,
+		Name: name,
+	}
+	if !container.NetworkMode(name).IsUserDefined() {
+		resource.Reason = "predefined"
+		return resource, nil
+	}
+	network, err := s.containerService.apiClient.NetworkInspect(ctx, name, moby.NetworkInspectOptions{})
+	if err != nil {
+		return resource, err
+	}
+	// only the networks compose created for the project are labeled with it
+	if network.Labels[projectLabel] != projectName {
+		resource.Reason = "external"
+		return resource, nil
+	}
+	for id, endpoint := range network.Containers {
+		if !projectContainers[id] {
+			resource.Reason = fmt.Sprintf("shared with container %s", endpoint.Name)
+			return resource, nil
+		}
+	}
+	resource.Remove = true
+	return resource, nil
+}
+
+func (s *local) planVolumeRemoval(ctx context.Context, name string, projectContainers map[string]bool) (compose.DownResource, error) {
+	resource := compose.DownResource{
+		Type:   compose.VolumeResource,
+		Name:   name,
+		Reason: "volumes are preserved",
+	}
+	users, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", name)),
+		All:     true,
+	})
+	if err != nil {
+		return resource, err
+	}
+	for _, c := range users {
+		if !projectContainers[c.ID] {
+			resource.Reason = fmt.Sprintf("shared with container %s", getContainerName(c))
+			break
+		}
+	}
+	return resource, nil
+}
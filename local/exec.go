@@ -0,0 +1,136 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *local) Exec(ctx context.Context, projectName string, opts compose.ExecOptions) error {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(opts.Service),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	container, err := getReplica(withoutOneOffContainers(list), opts.Service, opts.Index)
+	if err != nil {
+		return err
+	}
+
+	if opts.Detach {
+		exec, err := s.containerService.apiClient.ContainerExecCreate(ctx, container.ID, moby.ExecConfig{
+			Cmd:    opts.Command,
+			Env:    opts.Environment,
+			Detach: true,
+		})
+		if err != nil {
+			return err
+		}
+		return s.containerService.apiClient.ContainerExecStart(ctx, exec.ID, moby.ExecStartCheck{
+			Detach: true,
+		})
+	}
+
+	exec, err := s.containerService.apiClient.ContainerExecCreate(ctx, container.ID, moby.ExecConfig{
+		Cmd:          opts.Command,
+		Env:          opts.Environment,
+		Tty:          opts.Tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := s.containerService.apiClient.ContainerExecAttach(ctx, exec.ID, moby.ExecStartCheck{
+		Tty: opts.Tty,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	err = pipeStreams(resp, opts.Stdin, opts.Stdout, opts.Stderr, opts.Tty)
+	if err != nil {
+		return err
+	}
+	return s.checkExecExitCode(ctx, container.ID, exec.ID)
+}
+
+// checkExecExitCode returns an error carrying the exit code of a finished exec, when it failed
+func (s *local) checkExecExitCode(ctx context.Context, containerID string, execID string) error {
+	inspect, err := s.containerService.apiClient.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return err
+	}
+	if inspect.ExitCode != 0 {
+		return errdefs.StatusError{
+			Status:     fmt.Sprintf("exec in container %s exited with code %d", containerID, inspect.ExitCode),
+			StatusCode: inspect.ExitCode,
+		}
+	}
+	return nil
+}
+
+// pipeStreams copies stdin to a hijacked connection, and its output to stdout and stderr until the connection is closed.
+// Without a tty both outputs are multiplexed on the connection.
+func pipeStreams(resp moby.HijackedResponse, stdin io.Reader, stdout io.Writer, stderr io.Writer, tty bool) error {
+	if stdin != nil {
+		go func() {
+			_, _ = io.Copy(resp.Conn, stdin)
+			_ = resp.CloseWrite()
+		}()
+	}
+
+	var err error
+	if tty {
+		_, err = io.Copy(stdout, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, resp.Reader)
+	}
+	return err
+}
+
+// getReplica selects the service container with the requested container number
+func getReplica(containers []moby.Container, service string, index int) (moby.Container, error) {
+	for _, c := range containers {
+		if c.Labels[containerNumberLabel] == strconv.Itoa(index) {
+			return c, nil
+		}
+	}
+	if len(containers) == 0 {
+		return moby.Container{}, errors.Wrapf(errdefs.ErrNotFound, "service %q is not running", service)
+	}
+	return moby.Container{}, errors.Wrapf(errdefs.ErrNotFound, "service %q is not running container #%d", service, index)
+}
@@ -0,0 +1,70 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestGetReplica(t *testing.T) {
+	containers := []moby.Container{
+		{
+			ID:     "c1",
+			Labels: map[string]string{containerNumberLabel: "1"},
+		},
+		{
+			ID:     "c2",
+			Labels: map[string]string{containerNumberLabel: "2"},
+		},
+	}
+
+	c, err := getReplica(containers, "web", 2)
+	assert.NilError(t, err)
+	assert.Equal(t, c.ID, "c2")
+
+	_, err = getReplica(containers, "web", 3)
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+	assert.Error(t, err, `service "web" is not running container #3: not found`)
+}
+
+func TestExecExitCode(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.40/exec/ok/json":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"ExitCode": 0})
+		case "/v1.40/exec/failed/json":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"ExitCode": 3})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	s := newLocal(engine)
+
+	assert.NilError(t, s.checkExecExitCode(context.Background(), "c1", "ok"))
+	err := s.checkExecExitCode(context.Background(), "c1", "failed")
+	assert.Error(t, err, "exec in container c1 exited with code 3")
+	assert.Equal(t, errdefs.ExitCode(err), 3)
+}
@@ -0,0 +1,127 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// defaultInjectedFileMode is the mode of the injected secrets and configs which don't set one
+const defaultInjectedFileMode = 0444
+
+// injectedFile is a file copied into a container once created, for the secrets and configs
+// which content doesn't come from a file of the host
+type injectedFile struct {
+	Target  string
+	Content []byte
+	UID     int
+	GID     int
+	Mode    int64
+}
+
+func newInjectedFile(ref types.FileReferenceConfig, target string, content []byte) (injectedFile, error) {
+	file := injectedFile{
+		Target:  target,
+		Content: content,
+		Mode:    defaultInjectedFileMode,
+	}
+	var err error
+	if ref.UID != "" {
+		if file.UID, err = strconv.Atoi(ref.UID); err != nil {
+			return file, errors.Errorf("invalid uid %q of %q: numeric id expected", ref.UID, ref.Source)
+		}
+	}
+	if ref.GID != "" {
+		if file.GID, err = strconv.Atoi(ref.GID); err != nil {
+			return file, errors.Errorf("invalid gid %q of %q: numeric id expected", ref.GID, ref.Source)
+		}
+	}
+	if ref.Mode != nil {
+		file.Mode = int64(*ref.Mode)
+	}
+	return file, nil
+}
+
+// injectFiles copies the files into a created container, before it is started. Nothing is
+// written on the host, the files only live in the container filesystem.
+func (s *local) injectFiles(ctx context.Context, id string, files []injectedFile) error {
+	if len(files) == 0 {
+		return nil
+	}
+	archive, err := archiveInjectedFiles(files)
+	if err != nil {
+		return err
+	}
+	return s.containerService.apiClient.CopyToContainer(ctx, id, "/", archive, moby.CopyToContainerOptions{})
+}
+
+// buildInjectedFiles returns the files to copy into the containers of a service, which must not
+// have a read-only root filesystem then
+func buildInjectedFiles(p *types.Project, s types.ServiceConfig) ([]injectedFile, error) {
+	configs, err := buildInjectedConfigs(p, s)
+	if err != nil {
+		return nil, err
+	}
+	secrets, err := buildInjectedSecrets(p, s)
+	if err != nil {
+		return nil, err
+	}
+	files := append(configs, secrets...)
+	if s.ReadOnly && len(files) > 0 {
+		return nil, errors.Errorf("service %q: secrets and configs which aren't read from a file can't be copied to a read-only root filesystem", s.Name)
+	}
+	return files, nil
+}
+
+func archiveInjectedFiles(files []injectedFile) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	for _, f := range files {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.TrimPrefix(f.Target, "/"),
+			Size:     int64(len(f.Content)),
+			Mode:     f.Mode,
+			Uid:      f.UID,
+			Gid:      f.GID,
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(f.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
@@ -0,0 +1,84 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"strings"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func (s *local) Inspect(ctx context.Context, projectName string, service string) ([]compose.ContainerInspect, error) {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(service),
+		),
+		All: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inspected, err := s.inspectContainers(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	result := []compose.ContainerInspect{}
+	for _, container := range inspected {
+		result = append(result, toContainerInspect(container))
+	}
+	return result, nil
+}
+
+func toContainerInspect(c moby.ContainerJSON) compose.ContainerInspect {
+	inspect := compose.ContainerInspect{
+		ID:       c.ID,
+		Name:     strings.TrimPrefix(c.Name, "/"),
+		Networks: map[string]string{},
+	}
+	if c.State != nil {
+		inspect.State = c.State.Status
+		if c.State.Health != nil {
+			inspect.Health = c.State.Health.Status
+		}
+	}
+	if c.NetworkSettings != nil {
+		for name, endpoint := range c.NetworkSettings.Networks {
+			inspect.Networks[name] = endpoint.IPAddress
+		}
+	}
+	for _, m := range c.Mounts {
+		source := m.Source
+		if m.Type == "volume" {
+			source = m.Name
+		}
+		inspect.Mounts = append(inspect.Mounts, compose.MountPoint{
+			Type:        string(m.Type),
+			Source:      source,
+			Destination: m.Destination,
+			ReadOnly:    !m.RW,
+		})
+	}
+	return inspect
+}
@@ -29,12 +29,17 @@ const (
 	serviceLabel         = "com.docker.compose.service"
 	configHashLabel      = "com.docker.compose.config-hash"
 	containerNumberLabel = "com.docker.compose.container-number"
+	oneoffLabel          = "com.docker.compose.oneoff"
 )
 
 func projectFilter(projectName string) filters.KeyValuePair {
 	return filters.Arg("label", fmt.Sprintf("%s=%s", projectLabel, projectName))
 }
 
+func serviceFilter(serviceName string) filters.KeyValuePair {
+	return filters.Arg("label", fmt.Sprintf("%s=%s", serviceLabel, serviceName))
+}
+
 func hasProjectLabelFilter() filters.KeyValuePair {
 	return filters.Arg("label", projectLabel)
 }
@@ -0,0 +1,134 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+)
+
+var linkEnvironmentName = regexp.MustCompile("[^A-Z0-9]")
+
+// parseLink splits a `links` entry into the linked service and the alias it is reachable with
+func parseLink(link string) (string, string) {
+	parts := strings.SplitN(link, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], parts[0]
+	}
+	return parts[0], parts[1]
+}
+
+// getLinkAliases returns the aliases other services link to the service with, to be set as
+// network aliases of the service containers on the networks they share with the service
+func getLinkAliases(p *types.Project, s types.ServiceConfig, networkKey string) []string {
+	var aliases []string
+	for _, service := range p.Services {
+		if _, ok := getNetworksForService(service)[networkKey]; !ok {
+			continue
+		}
+		for _, link := range service.Links {
+			target, alias := parseLink(link)
+			if target == s.Name && alias != s.Name && !contains(aliases, alias) {
+				aliases = append(aliases, alias)
+			}
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// getLinkEnvironment returns the environment variables legacy links used to inject, describing
+// the ports of the linked services
+func getLinkEnvironment(p *types.Project, s types.ServiceConfig) []string {
+	var env []string
+	for _, link := range s.Links {
+		target, alias := parseLink(link)
+		service, err := getService(p, target)
+		if err != nil {
+			continue
+		}
+		prefix := linkEnvironmentName.ReplaceAllString(strings.ToUpper(alias), "_")
+		for i, port := range getExposedPorts(service) {
+			number, protocol := port[0], port[1]
+			url := fmt.Sprintf("%s://%s:%s", protocol, alias, number)
+			if i == 0 {
+				env = append(env, fmt.Sprintf("%s_PORT=%s", prefix, url))
+			}
+			portPrefix := fmt.Sprintf("%s_PORT_%s_%s", prefix, number, strings.ToUpper(protocol))
+			env = append(env,
+				fmt.Sprintf("%s=%s", portPrefix, url),
+				fmt.Sprintf("%s_ADDR=%s", portPrefix, alias),
+				fmt.Sprintf("%s_PORT=%s", portPrefix, number),
+				fmt.Sprintf("%s_PROTO=%s", portPrefix, protocol),
+			)
+		}
+	}
+	return env
+}
+
+// getExposedPorts returns the sorted [port, protocol] pairs the service exposes
+func getExposedPorts(s types.ServiceConfig) [][2]string {
+	seen := map[[2]string]bool{}
+	var ports [][2]string
+	add := func(port [2]string) {
+		if !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+	for _, p := range s.Ports {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		add([2]string{fmt.Sprint(p.Target), protocol})
+	}
+	for _, e := range s.Expose {
+		parts := strings.SplitN(e, "/", 2)
+		protocol := "tcp"
+		if len(parts) == 2 {
+			protocol = parts[1]
+		}
+		add([2]string{parts[0], protocol})
+	}
+	sort.Slice(ports, func(i, j int) bool {
+		if a, b := portNumber(ports[i][0]), portNumber(ports[j][0]); a != b {
+			return a < b
+		}
+		if ports[i][0] != ports[j][0] {
+			return ports[i][0] < ports[j][0]
+		}
+		return ports[i][1] < ports[j][1]
+	})
+	return ports
+}
+
+// portNumber returns the first port of a port or port range, so that ports sort numerically
+func portNumber(port string) int {
+	n, err := strconv.Atoi(strings.SplitN(port, "-", 2)[0])
+	if err != nil {
+		return 0
+	}
+	return n
+}
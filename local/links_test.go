@@ -0,0 +1,104 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestLinks(t *testing.T) {
+	project := &types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:  "web",
+				Links: []string{"db:database", "cache"},
+			},
+			{
+				Name:  "db",
+				Ports: []types.ServicePortConfig{{Target: 5432, Protocol: "tcp"}},
+			},
+			{
+				Name:   "cache",
+				Expose: []string{"6379"},
+			},
+		},
+	}
+	db, err := getService(project, "db")
+	assert.NilError(t, err)
+	web, err := getService(project, "web")
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, getLinkAliases(project, db, "default"), []string{"database"})
+	assert.DeepEqual(t, getLinkEnvironment(project, web), []string{
+		"DATABASE_PORT=tcp://database:5432",
+		"DATABASE_PORT_5432_TCP=tcp://database:5432",
+		"DATABASE_PORT_5432_TCP_ADDR=database",
+		"DATABASE_PORT_5432_TCP_PORT=5432",
+		"DATABASE_PORT_5432_TCP_PROTO=tcp",
+		"CACHE_PORT=tcp://cache:6379",
+		"CACHE_PORT_6379_TCP=tcp://cache:6379",
+		"CACHE_PORT_6379_TCP_ADDR=cache",
+		"CACHE_PORT_6379_TCP_PORT=6379",
+		"CACHE_PORT_6379_TCP_PROTO=tcp",
+	})
+}
+
+func TestLinkAliasesOnSharedNetworks(t *testing.T) {
+	project := &types.Project{
+		Services: []types.ServiceConfig{
+			{
+				Name:     "web",
+				Links:    []string{"db:database"},
+				Networks: map[string]*types.ServiceNetworkConfig{"front": nil, "back": nil},
+			},
+			{
+				Name:     "admin",
+				Links:    []string{"db:admindb"},
+				Networks: map[string]*types.ServiceNetworkConfig{"back": nil},
+			},
+			{
+				Name:     "db",
+				Networks: map[string]*types.ServiceNetworkConfig{"back": nil, "storage": nil},
+			},
+		},
+	}
+	db, err := getService(project, "db")
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, getLinkAliases(project, db, "back"), []string{"admindb", "database"})
+	assert.Assert(t, getLinkAliases(project, db, "storage") == nil)
+}
+
+func TestExposedPortsSortedNumerically(t *testing.T) {
+	service := types.ServiceConfig{
+		Name:   "web",
+		Ports:  []types.ServicePortConfig{{Target: 8080}, {Target: 443}},
+		Expose: []string{"9000-9001", "80/udp", "80"},
+	}
+	assert.DeepEqual(t, getExposedPorts(service), [][2]string{
+		{"80", "tcp"},
+		{"80", "udp"},
+		{"443", "tcp"},
+		{"8080", "tcp"},
+		{"9000-9001", "tcp"},
+	})
+}
@@ -0,0 +1,109 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// defaultContainerNameTemplate names the service containers when neither the service declares a
+// container_name nor the project a name template
+const defaultContainerNameTemplate = "{{.Project}}_{{.Service}}_{{.Number}}"
+
+type containerNameData struct {
+	Project string
+	Service string
+	Number  int
+}
+
+// containerName returns the name of the numbered container of a service
+func containerName(project *types.Project, service types.ServiceConfig, number int) (string, error) {
+	if service.ContainerName != "" {
+		return service.ContainerName, nil
+	}
+	tmpl, err := containerNameTemplate(project)
+	if err != nil {
+		return "", err
+	}
+	var name bytes.Buffer
+	err = tmpl.Execute(&name, containerNameData{
+		Project: project.Name,
+		Service: service.Name,
+		Number:  number,
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s", compose.ContainerNameTemplateExtension)
+	}
+	return name.String(), nil
+}
+
+func containerNameTemplate(project *types.Project) (*template.Template, error) {
+	text := defaultContainerNameTemplate
+	if x, ok := project.Extensions[compose.ContainerNameTemplateExtension]; ok {
+		s, ok := x.(string)
+		if !ok || s == "" {
+			return nil, errors.Errorf("invalid %s: expected a template string", compose.ContainerNameTemplateExtension)
+		}
+		text = s
+	}
+	tmpl, err := template.New("container_name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s", compose.ContainerNameTemplateExtension)
+	}
+	return tmpl, nil
+}
+
+// checkContainerNames detects the services which containers would be given the same name
+func checkContainerNames(project *types.Project) error {
+	names := map[string]string{}
+	for _, service := range project.Services {
+		if service.ContainerName != "" {
+			if scale := getScale(service); scale > 1 {
+				return errors.Errorf("service %q: container_name %q can't be used with a scale of %d, container names must be unique", service.Name, service.ContainerName, scale)
+			}
+		} else if getScale(service) > 1 {
+			first, err := containerName(project, service, 1)
+			if err != nil {
+				return err
+			}
+			second, err := containerName(project, service, 2)
+			if err != nil {
+				return err
+			}
+			if first == second {
+				return errors.Errorf("service %q: %s must include the container {{.Number}} to scale the service", service.Name, compose.ContainerNameTemplateExtension)
+			}
+		}
+		name, err := containerName(project, service, 1)
+		if err != nil {
+			return err
+		}
+		if other, ok := names[name]; ok {
+			return errors.Errorf("services %q and %q both name their container %q", other, service.Name, name)
+		}
+		names[name] = service.Name
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestContainerName(t *testing.T) {
+	project := &types.Project{Name: "shop"}
+	name, err := containerName(project, types.ServiceConfig{Name: "web"}, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "shop_web_2")
+
+	name, err = containerName(project, types.ServiceConfig{Name: "db", ContainerName: "shop-database"}, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "shop-database")
+
+	project.Extensions = map[string]interface{}{
+		compose.ContainerNameTemplateExtension: "prod-{{.Project}}-{{.Service}}-{{.Number}}",
+	}
+	name, err = containerName(project, types.ServiceConfig{Name: "web"}, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, name, "prod-shop-web-2")
+
+	project.Extensions[compose.ContainerNameTemplateExtension] = "{{.Team}}-{{.Service}}"
+	_, err = containerName(project, types.ServiceConfig{Name: "web"}, 1)
+	assert.ErrorContains(t, err, "invalid x-container-name-template")
+}
+
+func TestCheckContainerNames(t *testing.T) {
+	replicas := uint64(2)
+	scaled := &types.DeployConfig{Replicas: &replicas}
+
+	err := checkContainerNames(&types.Project{
+		Name: "shop",
+		Services: types.Services{
+			{Name: "web", Deploy: scaled},
+			{Name: "db", ContainerName: "database"},
+		},
+	})
+	assert.NilError(t, err)
+
+	err = checkContainerNames(&types.Project{
+		Name: "shop",
+		Services: types.Services{
+			{Name: "web", ContainerName: "web", Deploy: scaled},
+		},
+	})
+	assert.ErrorContains(t, err, `service "web": container_name "web" can't be used with a scale of 2`)
+
+	err = checkContainerNames(&types.Project{
+		Name: "shop",
+		Services: types.Services{
+			{Name: "db", ContainerName: "database"},
+			{Name: "replica", ContainerName: "database"},
+		},
+	})
+	assert.ErrorContains(t, err, `services "db" and "replica" both name their container "database"`)
+
+	err = checkContainerNames(&types.Project{
+		Name:       "shop",
+		Services:   types.Services{{Name: "web", Deploy: scaled}},
+		Extensions: map[string]interface{}{compose.ContainerNameTemplateExtension: "{{.Project}}-{{.Service}}"},
+	})
+	assert.ErrorContains(t, err, `service "web": x-container-name-template must include the container {{.Number}}`)
+}
@@ -0,0 +1,103 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/pkg/errors"
+)
+
+const servicePrefix = "service:"
+
+// resolveServiceNamespaces replaces the `service:` namespace references of the host config,
+// which the engine doesn't know about, by a reference to a container of the target service
+func (s *local) resolveServiceNamespaces(ctx context.Context, project *types.Project, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig) error {
+	resolve := func(mode string) (string, error) {
+		if !strings.HasPrefix(mode, servicePrefix) {
+			return mode, nil
+		}
+		id, err := s.getServiceContainerID(ctx, project.Name, strings.TrimPrefix(mode, servicePrefix))
+		if err != nil {
+			return "", err
+		}
+		return "container:" + id, nil
+	}
+
+	mode, err := resolve(string(hostConfig.NetworkMode))
+	if err != nil {
+		return err
+	}
+	hostConfig.NetworkMode = container.NetworkMode(mode)
+	if hostConfig.NetworkMode.IsContainer() {
+		// containers joining another network namespace can't have their own endpoints
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{}
+	}
+
+	mode, err = resolve(string(hostConfig.PidMode))
+	if err != nil {
+		return err
+	}
+	hostConfig.PidMode = container.PidMode(mode)
+
+	mode, err = resolve(string(hostConfig.IpcMode))
+	if err != nil {
+		return err
+	}
+	hostConfig.IpcMode = container.IpcMode(mode)
+	return nil
+}
+
+// getIpcMode returns the ipc mode of the service, which is made shareable when another service
+// joins its ipc namespace
+func getIpcMode(p *types.Project, s types.ServiceConfig) container.IpcMode {
+	if s.Ipc != "" {
+		return container.IpcMode(s.Ipc)
+	}
+	for _, service := range p.Services {
+		if service.Ipc == servicePrefix+s.Name {
+			return container.IpcMode("shareable")
+		}
+	}
+	return ""
+}
+
+// getServiceContainerID returns the ID of a running container of the service
+func (s *local) getServiceContainerID(ctx context.Context, projectName string, service string) (string, error) {
+	containers, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(service),
+		),
+	})
+	if err != nil {
+		return "", err
+	}
+	containers = withoutOneOffContainers(containers)
+	if len(containers) == 0 {
+		return "", errors.Errorf("no running container for service %q", service)
+	}
+	return containers[0].ID, nil
+}
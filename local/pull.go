@@ -0,0 +1,249 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/containerd/containerd/platforms"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/progress"
+)
+
+func (s *local) Pull(ctx context.Context, project *types.Project) error {
+	w := progress.ContextWriter(ctx)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, srv := range project.Services {
+		service := srv
+		if service.Image == "" {
+			continue
+		}
+		eg.Go(func() error {
+			if err := s.checkPlatformSupport(ctx, service); err != nil {
+				return err
+			}
+			return s.pullImage(ctx, service, w)
+		})
+	}
+	return eg.Wait()
+}
+
+// applyPullPolicy pulls the service image according to the pull policy, the one of the service
+// being overridden by a non-empty override
+func (s *local) applyPullPolicy(ctx context.Context, service types.ServiceConfig, override string) error {
+	w := progress.ContextWriter(ctx)
+	// TODO build vs pull should be controlled by pull policy
+	// if service.Build {}
+	if service.Image == "" {
+		return nil
+	}
+	policy, err := pullPolicy(service, override)
+	if err != nil {
+		return err
+	}
+	if err := s.checkPlatformSupport(ctx, service); err != nil {
+		return err
+	}
+	if policy == compose.PullPolicyAlways {
+		return s.pullImage(ctx, service, w)
+	}
+	image, _, err := s.containerService.apiClient.ImageInspectWithRaw(ctx, service.Image)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return nil
+		}
+		if policy == compose.PullPolicyNever {
+			return errors.Errorf("service %q: image %s is not available locally and pull policy is %q", service.Name, service.Image, policy)
+		}
+		return s.pullImage(ctx, service, w)
+	}
+	if service.Platform != "" && !matchesPlatform(image, service.Platform) {
+		if policy == compose.PullPolicyNever {
+			return errors.Errorf("service %q: image %s is not available locally for platform %s and pull policy is %q", service.Name, service.Image, service.Platform, policy)
+		}
+		return s.pullImage(ctx, service, w)
+	}
+	return nil
+}
+
+// pullPolicy returns the pull policy applying to a service, `missing` when neither the service nor the override set one
+func pullPolicy(service types.ServiceConfig, override string) (string, error) {
+	policy := override
+	if policy == "" {
+		policy, _ = service.Extensions[compose.PullPolicyExtension].(string)
+	}
+	switch policy {
+	case "", compose.PullPolicyMissing, "if_not_present":
+		return compose.PullPolicyMissing, nil
+	case compose.PullPolicyAlways, compose.PullPolicyNever:
+		return policy, nil
+	}
+	return "", errors.Errorf("service %q: invalid pull policy %q, expected %q, %q or %q",
+		service.Name, policy, compose.PullPolicyAlways, compose.PullPolicyMissing, compose.PullPolicyNever)
+}
+
+// checkPlatformSupport validates the platform requested by the service and checks the engine can
+// pull images for a specific platform, which requires API 1.40 or experimental mode
+func (s *local) checkPlatformSupport(ctx context.Context, service types.ServiceConfig) error {
+	if service.Platform == "" {
+		return nil
+	}
+	if _, err := platforms.Parse(service.Platform); err != nil {
+		return errors.Wrapf(err, "service %q declares an invalid platform", service.Name)
+	}
+	version, err := s.containerService.apiClient.ServerVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if versions.LessThan(version.APIVersion, "1.40") && !version.Experimental {
+		return errors.Errorf("service %q requires platform %q, which is not supported by docker engine API %s, upgrade the engine or enable experimental mode",
+			service.Name, service.Platform, version.APIVersion)
+	}
+	return nil
+}
+
+// matchesPlatform checks whether a local image has been built for the platform
+func matchesPlatform(image moby.ImageInspect, platform string) bool {
+	p, err := platforms.Parse(platform)
+	if err != nil {
+		return false
+	}
+	return image.Os == p.OS && image.Architecture == p.Architecture
+}
+
+func (s *local) pullImage(ctx context.Context, service types.ServiceConfig, w progress.Writer) error {
+	w.Event(progress.Event{
+		ID:     service.Name,
+		Text:   "Pulling",
+		Status: progress.Working,
+	})
+	// layers are reported through a sub writer, so a layer shared with the
+	// image of another service being pulled concurrently is rendered twice
+	layers := progress.SubWriter(w, service.Name)
+	err := s.doPullImage(ctx, service, layers)
+	if isUnauthorized(err) {
+		// registry tokens can expire during long pulls, the credentials are resolved again
+		// and the pull resumed, layers already downloaded being kept by the engine
+		w.Event(progress.Event{
+			ID:     service.Name,
+			Text:   "Refreshing credentials",
+			Status: progress.Working,
+		})
+		err = s.doPullImage(ctx, service, layers)
+	}
+	if err != nil {
+		w.Event(progress.Event{
+			ID:         service.Name,
+			Text:       "Error",
+			Status:     progress.Error,
+			StatusText: err.Error(),
+		})
+		return err
+	}
+	w.Event(progress.Event{
+		ID:     service.Name,
+		Text:   "Pulled",
+		Status: progress.Done,
+	})
+	return nil
+}
+
+func (s *local) doPullImage(ctx context.Context, service types.ServiceConfig, layers progress.Writer) error {
+	auth, err := registryAuth(service.Image)
+	if err != nil {
+		logrus.Debugf("could not resolve registry credentials for image %s: %v", service.Image, err)
+	}
+	stream, err := s.containerService.apiClient.ImagePull(ctx, service.Image, moby.ImagePullOptions{
+		Platform:     service.Platform,
+		RegistryAuth: auth,
+		PrivilegeFunc: func() (string, error) {
+			return registryAuth(service.Image)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer stream.Close() // nolint:errcheck
+
+	dec := json.NewDecoder(stream)
+	for {
+		var jm jsonmessage.JSONMessage
+		if err := dec.Decode(&jm); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if jm.Error != nil && jm.Progress == nil {
+			return errors.New(jm.Error.Message)
+		}
+		toProgressEvent(jm, layers)
+	}
+}
+
+func toProgressEvent(jm jsonmessage.JSONMessage, w progress.Writer) {
+	if jm.Progress != nil {
+		if jm.Progress.Total != 0 {
+			status := progress.Working
+			if jm.Status == "Pull complete" {
+				status = progress.Done
+			}
+			w.Event(progress.Event{
+				ID:         jm.ID,
+				Text:       jm.Status,
+				Status:     status,
+				StatusText: jm.Progress.String(),
+				Current:    jm.Progress.Current,
+				Total:      jm.Progress.Total,
+			})
+		} else {
+			if jm.Error != nil {
+				w.Event(progress.Event{
+					ID:         jm.ID,
+					Text:       jm.Status,
+					Status:     progress.Error,
+					StatusText: jm.Error.Message,
+				})
+			} else if jm.Status == "Pull complete" || jm.Status == "Already exists" {
+				w.Event(progress.Event{
+					ID:     jm.ID,
+					Text:   jm.Status,
+					Status: progress.Done,
+				})
+			} else {
+				w.Event(progress.Event{
+					ID:     jm.ID,
+					Text:   jm.Status,
+					Status: progress.Working,
+				})
+			}
+		}
+	}
+}
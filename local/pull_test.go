@@ -0,0 +1,61 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestMatchesPlatform(t *testing.T) {
+	image := moby.ImageInspect{Os: "linux", Architecture: "arm64"}
+	assert.Assert(t, matchesPlatform(image, "linux/arm64"))
+	assert.Assert(t, matchesPlatform(image, "linux/aarch64"))
+	assert.Assert(t, !matchesPlatform(image, "linux/amd64"))
+	assert.Assert(t, !matchesPlatform(image, "not a platform"))
+}
+
+func TestPullPolicy(t *testing.T) {
+	service := types.ServiceConfig{Name: "web"}
+	policy, err := pullPolicy(service, "")
+	assert.NilError(t, err)
+	assert.Equal(t, policy, compose.PullPolicyMissing)
+
+	service.Extensions = map[string]interface{}{compose.PullPolicyExtension: "never"}
+	policy, err = pullPolicy(service, "")
+	assert.NilError(t, err)
+	assert.Equal(t, policy, compose.PullPolicyNever)
+
+	policy, err = pullPolicy(service, "always")
+	assert.NilError(t, err)
+	assert.Equal(t, policy, compose.PullPolicyAlways)
+
+	service.Extensions = map[string]interface{}{compose.PullPolicyExtension: "if_not_present"}
+	policy, err = pullPolicy(service, "")
+	assert.NilError(t, err)
+	assert.Equal(t, policy, compose.PullPolicyMissing)
+
+	_, err = pullPolicy(service, "sometimes")
+	assert.ErrorContains(t, err, `service "web": invalid pull policy "sometimes"`)
+}
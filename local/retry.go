@@ -0,0 +1,115 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+)
+
+const extRetry = "x-retry"
+
+// retryPolicy controls how a service failing to start is retried, it is declared by the
+// x-retry service extension, i.e. `x-retry: {attempts: 3, delay: 2s, backoff: 2}`
+type retryPolicy struct {
+	attempts int
+	delay    time.Duration
+	backoff  float64
+}
+
+func getRetryPolicy(service types.ServiceConfig) (retryPolicy, error) {
+	policy := retryPolicy{attempts: 1, delay: time.Second, backoff: 1}
+	ext, ok := service.Extensions[extRetry]
+	if !ok {
+		return policy, nil
+	}
+	config, ok := ext.(map[string]interface{})
+	if !ok {
+		return policy, errors.Errorf("service %q: %s must be a mapping", service.Name, extRetry)
+	}
+	for key, value := range config {
+		switch key {
+		case "attempts":
+			attempts, ok := toFloat(value)
+			if !ok || attempts < 1 {
+				return policy, errors.Errorf("service %q: invalid %s attempts %v", service.Name, extRetry, value)
+			}
+			policy.attempts = int(attempts)
+		case "delay":
+			delay, err := time.ParseDuration(fmt.Sprint(value))
+			if err != nil {
+				return policy, errors.Wrapf(err, "service %q: invalid %s delay", service.Name, extRetry)
+			}
+			policy.delay = delay
+		case "backoff":
+			backoff, ok := toFloat(value)
+			if !ok || backoff < 1 {
+				return policy, errors.Errorf("service %q: invalid %s backoff %v", service.Name, extRetry, value)
+			}
+			policy.backoff = backoff
+		default:
+			return policy, errors.Errorf("service %q: unsupported %s attribute %q", service.Name, extRetry, key)
+		}
+	}
+	return policy, nil
+}
+
+// wait returns the delay before the given retry, starting at 1
+func (p retryPolicy) wait(retry int) time.Duration {
+	return time.Duration(float64(p.delay) * math.Pow(p.backoff, float64(retry-1)))
+}
+
+// withRetry runs fn until it succeeds or the attempts of the service retry policy are exhausted,
+// onRetry is notified of the failed attempts which are retried
+func withRetry(ctx context.Context, service types.ServiceConfig, fn func(context.Context, types.ServiceConfig) error, onRetry func(err error)) error {
+	policy, err := getRetryPolicy(service)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx, service)
+		if err == nil || attempt >= policy.attempts || ctx.Err() != nil {
+			return err
+		}
+		onRetry(err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(policy.wait(attempt)):
+		}
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
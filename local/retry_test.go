@@ -0,0 +1,76 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetRetryPolicy(t *testing.T) {
+	policy, err := getRetryPolicy(types.ServiceConfig{Name: "web"})
+	assert.NilError(t, err)
+	assert.Equal(t, policy.attempts, 1)
+
+	policy, err = getRetryPolicy(types.ServiceConfig{
+		Name: "web",
+		Extensions: map[string]interface{}{
+			extRetry: map[string]interface{}{"attempts": 3, "delay": "2s", "backoff": 2},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, policy.attempts, 3)
+	assert.Equal(t, policy.wait(1), 2*time.Second)
+	assert.Equal(t, policy.wait(3), 8*time.Second)
+
+	_, err = getRetryPolicy(types.ServiceConfig{
+		Name: "web",
+		Extensions: map[string]interface{}{
+			extRetry: map[string]interface{}{"attempts": 0},
+		},
+	})
+	assert.Error(t, err, `service "web": invalid x-retry attempts 0`)
+}
+
+func TestWithRetry(t *testing.T) {
+	service := types.ServiceConfig{
+		Name: "web",
+		Extensions: map[string]interface{}{
+			extRetry: map[string]interface{}{"attempts": 3, "delay": "1ms"},
+		},
+	}
+	calls, retries := 0, 0
+	err := withRetry(context.TODO(), service, func(ctx context.Context, config types.ServiceConfig) error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	}, func(err error) {
+		retries++
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, calls, 3)
+	assert.Equal(t, retries, 2)
+}
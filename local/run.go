@@ -0,0 +1,237 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/compose-spec/compose-go/loader"
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/docker/pkg/stringid"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func (s *local) RunOneOffContainer(ctx context.Context, project *types.Project, opts compose.RunOptions) error {
+	err := s.ensureProjectResources(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	service, err := getService(project, opts.Service)
+	if err != nil {
+		return err
+	}
+	err = applyRunOptions(&service, opts)
+	if err != nil {
+		return err
+	}
+	err = s.applyPullPolicy(ctx, service, opts.Pull)
+	if err != nil {
+		return err
+	}
+
+	containerConfig, hostConfig, networkingConfig, err := getContainerCreateOptions(project, service, 1, nil)
+	if err != nil {
+		return err
+	}
+	err = s.resolveServiceNamespaces(ctx, project, hostConfig, networkingConfig)
+	if err != nil {
+		return err
+	}
+	err = s.resolveVolumesFrom(ctx, project, hostConfig)
+	if err != nil {
+		return err
+	}
+	containerConfig.Labels[oneoffLabel] = "True"
+	containerConfig.AttachStdin = !opts.Detach
+	hostConfig.AutoRemove = opts.AutoRemove
+	if !opts.UseAliases {
+		removeAliases(networkingConfig)
+	}
+
+	injected, err := buildInjectedFiles(project, service)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s_%s_run_%s", project.Name, service.Name, stringid.TruncateID(stringid.GenerateRandomID()))
+	id, err := s.containerService.create(ctx, containerConfig, hostConfig, networkingConfig, name)
+	if err != nil {
+		return err
+	}
+	err = s.injectFiles(ctx, id, injected)
+	if err != nil {
+		return err
+	}
+	for net, config := range service.Networks {
+		networkName := fmt.Sprintf("%s_%s", project.Name, net)
+		if networkName == string(hostConfig.NetworkMode) {
+			continue
+		}
+		err = s.containerService.apiClient.NetworkConnect(ctx, networkName, id, &network.EndpointSettings{
+			Aliases: oneOffAliases(project, service, net, config, opts.UseAliases),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.Detach {
+		err = s.containerService.apiClient.ContainerStart(ctx, id, moby.ContainerStartOptions{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(opts.Stdout, name)
+		return nil
+	}
+	return s.attachAndStart(ctx, id, opts)
+}
+
+func (s *local) attachAndStart(ctx context.Context, id string, opts compose.RunOptions) error {
+	resp, err := s.containerService.apiClient.ContainerAttach(ctx, id, moby.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	go func() {
+		_, _ = io.Copy(resp.Conn, opts.Stdin)
+		_ = resp.CloseWrite()
+	}()
+
+	outputDone := make(chan error, 1)
+	go func() {
+		var err error
+		if opts.Tty {
+			_, err = io.Copy(opts.Stdout, resp.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(opts.Stdout, opts.Stderr, resp.Reader)
+		}
+		outputDone <- err
+	}()
+
+	// the container is removed as soon as it exits with --rm, waiting for the next exit would race the removal
+	condition := container.WaitConditionNextExit
+	if opts.AutoRemove {
+		condition = container.WaitConditionRemoved
+	}
+	statusC, errC := s.containerService.apiClient.ContainerWait(ctx, id, condition)
+	err = s.containerService.apiClient.ContainerStart(ctx, id, moby.ContainerStartOptions{})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case status := <-statusC:
+		<-outputDone
+		if status.StatusCode != 0 {
+			return errdefs.StatusError{
+				Status:     fmt.Sprintf("container %s exited with code %d", id, status.StatusCode),
+				StatusCode: int(status.StatusCode),
+			}
+		}
+		return nil
+	case err := <-errC:
+		return err
+	}
+}
+
+// applyRunOptions overrides the service configuration with the one-off container options
+func applyRunOptions(service *types.ServiceConfig, opts compose.RunOptions) error {
+	if len(opts.Command) > 0 {
+		service.Command = opts.Command
+	}
+	if len(opts.Entrypoint) > 0 {
+		service.Entrypoint = opts.Entrypoint
+	}
+	if opts.User != "" {
+		service.User = opts.User
+	}
+	if opts.WorkingDir != "" {
+		service.WorkingDir = opts.WorkingDir
+	}
+	if !opts.ServicePorts {
+		service.Ports = nil
+	}
+	service.Tty = opts.Tty
+	service.StdinOpen = !opts.Detach
+
+	if len(opts.Environment) > 0 {
+		environment := types.MappingWithEquals{}
+		for k, v := range service.Environment {
+			environment[k] = v
+		}
+		for _, e := range opts.Environment {
+			kv := strings.SplitN(e, "=", 2)
+			if len(kv) == 2 {
+				environment[kv[0]] = &kv[1]
+			} else {
+				environment[kv[0]] = nil
+			}
+		}
+		service.Environment = environment
+	}
+
+	for _, v := range opts.Volumes {
+		volume, err := loader.ParseVolume(v)
+		if err != nil {
+			return err
+		}
+		service.Volumes = append(service.Volumes, volume)
+	}
+	return nil
+}
+
+// oneOffAliases returns the aliases of a one-off container on a network, it only gets the service ones with --use-aliases
+func oneOffAliases(project *types.Project, service types.ServiceConfig, networkKey string, config *types.ServiceNetworkConfig, useAliases bool) []string {
+	if !useAliases {
+		return nil
+	}
+	return getAliases(project, service, networkKey, config)
+}
+
+func removeAliases(networkingConfig *network.NetworkingConfig) {
+	for _, endpoint := range networkingConfig.EndpointsConfig {
+		endpoint.Aliases = nil
+	}
+}
+
+func getService(project *types.Project, name string) (types.ServiceConfig, error) {
+	for _, s := range project.Services {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return types.ServiceConfig{}, errors.Wrapf(errdefs.ErrNotFound, "no such service: %q", name)
+}
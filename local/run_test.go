@@ -0,0 +1,130 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestApplyRunOptions(t *testing.T) {
+	value := "value"
+	service := types.ServiceConfig{
+		Name:       "web",
+		Command:    types.ShellCommand{"serve"},
+		User:       "nobody",
+		WorkingDir: "/app",
+		Ports: []types.ServicePortConfig{
+			{Target: 80, Published: 8080, Protocol: "tcp"},
+		},
+		Environment: types.MappingWithEquals{
+			"FOO": &value,
+		},
+	}
+
+	err := applyRunOptions(&service, compose.RunOptions{
+		Command:     []string{"sh"},
+		Entrypoint:  []string{"/bin/entrypoint", "-x"},
+		User:        "root",
+		WorkingDir:  "/tmp",
+		Environment: []string{"BAR=baz", "QIX"},
+		Volumes:     []string{"/src:/dst:ro"},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string(service.Command), []string{"sh"})
+	assert.DeepEqual(t, []string(service.Entrypoint), []string{"/bin/entrypoint", "-x"})
+	assert.Equal(t, service.User, "root")
+	assert.Equal(t, service.WorkingDir, "/tmp")
+	assert.Equal(t, len(service.Ports), 0)
+	assert.Equal(t, *service.Environment["FOO"], "value")
+	assert.Equal(t, *service.Environment["BAR"], "baz")
+	assert.Assert(t, service.Environment["QIX"] == nil)
+	assert.Equal(t, len(service.Volumes), 1)
+	assert.Equal(t, service.Volumes[0].Source, "/src")
+	assert.Equal(t, service.Volumes[0].Target, "/dst")
+	assert.Assert(t, service.Volumes[0].ReadOnly)
+}
+
+func TestApplyRunOptionsServicePorts(t *testing.T) {
+	service := types.ServiceConfig{
+		Name: "web",
+		Ports: []types.ServicePortConfig{
+			{Target: 80, Published: 8080, Protocol: "tcp"},
+		},
+	}
+
+	err := applyRunOptions(&service, compose.RunOptions{
+		ServicePorts: true,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(service.Ports), 1)
+}
+
+func TestRunServicePortsBindings(t *testing.T) {
+	project := &types.Project{Name: "myproject"}
+	for _, servicePorts := range []bool{true, false} {
+		service := types.ServiceConfig{
+			Name:  "web",
+			Image: "nginx",
+			Ports: []types.ServicePortConfig{
+				{Target: 80, Published: 8080, Protocol: "tcp"},
+			},
+		}
+		err := applyRunOptions(&service, compose.RunOptions{ServicePorts: servicePorts})
+		assert.NilError(t, err)
+
+		_, hostConfig, _, err := getContainerCreateOptions(project, service, 1, nil)
+		assert.NilError(t, err)
+		if !servicePorts {
+			assert.Equal(t, len(hostConfig.PortBindings), 0)
+			continue
+		}
+		bindings := hostConfig.PortBindings["80/tcp"]
+		assert.Equal(t, len(bindings), 1)
+		assert.Equal(t, bindings[0].HostPort, "8080")
+	}
+}
+
+func TestRunAliases(t *testing.T) {
+	project := &types.Project{
+		Name:     "myproject",
+		Networks: types.Networks{"front": {Name: "myproject_front"}},
+	}
+	service := types.ServiceConfig{
+		Name:  "web",
+		Image: "nginx",
+		Networks: map[string]*types.ServiceNetworkConfig{
+			"front": {Aliases: []string{"www"}},
+		},
+	}
+	project.Services = types.Services{service}
+
+	assert.DeepEqual(t, oneOffAliases(project, service, "front", service.Networks["front"], true), []string{"web", "www"})
+	assert.Assert(t, oneOffAliases(project, service, "front", service.Networks["front"], false) == nil)
+
+	_, _, networkingConfig, err := getContainerCreateOptions(project, service, 1, nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, networkingConfig.EndpointsConfig["myproject_front"].Aliases, []string{"web", "www"})
+	removeAliases(networkingConfig)
+	assert.Assert(t, networkingConfig.EndpointsConfig["myproject_front"].Aliases == nil)
+}
@@ -0,0 +1,104 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func (s *local) Scale(ctx context.Context, project *types.Project, options compose.ScaleOptions) error {
+	for name, replicas := range options.Services {
+		service, err := project.GetService(name)
+		if err != nil {
+			return err
+		}
+		if replicas < 0 {
+			return errors.Errorf("invalid number of replicas for service %q: %d", name, replicas)
+		}
+		if service.ContainerName != "" && replicas > 1 {
+			return errors.Errorf("service %q: container_name %q can't be used with a scale of %d, container names must be unique", name, service.ContainerName, replicas)
+		}
+	}
+	observed, err := s.listServiceContainers(ctx, project)
+	if err != nil {
+		return err
+	}
+	targets := scaleTargets(project, options.Services, observed)
+	return inDependencyOrder(ctx, project, func(ctx context.Context, service types.ServiceConfig) error {
+		scale, ok := targets[service.Name]
+		if !ok {
+			return nil
+		}
+		actual := observed[service.Name]
+		if len(actual) == 0 && scale > 0 {
+			// like on up, a service scaled from zero waits for its dependencies
+			if err := s.waitDependencies(ctx, project, service, compose.UpOptions{}); err != nil {
+				return err
+			}
+		}
+		eg, _ := errgroup.WithContext(ctx)
+		if _, err := s.scaleService(ctx, eg, project, service, actual, scale); err != nil {
+			return err
+		}
+		return eg.Wait()
+	})
+}
+
+// scaleTargets returns the number of replicas of the services to scale. The dependencies of the services
+// scaled from zero which have no container are included, to be started with their configured scale.
+func scaleTargets(project *types.Project, requested map[string]int, observed map[string][]moby.Container) map[string]int {
+	targets := map[string]int{}
+	for name, replicas := range requested {
+		targets[name] = replicas
+	}
+	var ensure func(name string)
+	ensure = func(name string) {
+		if _, ok := targets[name]; ok || len(observed[name]) > 0 {
+			return
+		}
+		service, err := project.GetService(name)
+		if err != nil {
+			return
+		}
+		targets[name] = getScale(service)
+		for _, dependency := range compose.ServiceDependencies(service) {
+			ensure(dependency)
+		}
+	}
+	for name, replicas := range requested {
+		if replicas == 0 || len(observed[name]) > 0 {
+			continue
+		}
+		service, err := project.GetService(name)
+		if err != nil {
+			continue
+		}
+		for _, dependency := range compose.ServiceDependencies(service) {
+			ensure(dependency)
+		}
+	}
+	return targets
+}
@@ -0,0 +1,65 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+)
+
+func TestScaleTargets(t *testing.T) {
+	replicas := uint64(2)
+	project := &types.Project{
+		Name: "demo",
+		Services: types.Services{
+			{Name: "web", DependsOn: types.DependsOnConfig{"api": {}}},
+			{Name: "api", DependsOn: types.DependsOnConfig{"db": {}, "cache": {}}},
+			{Name: "db", Deploy: &types.DeployConfig{Replicas: &replicas}},
+			{Name: "cache"},
+			{Name: "worker", DependsOn: types.DependsOnConfig{"db": {}}},
+		},
+	}
+	observed := map[string][]moby.Container{
+		"web":   {{ID: "web_1"}},
+		"cache": {{ID: "cache_1"}},
+	}
+
+	// scaling a running service doesn't start its dependencies
+	assert.DeepEqual(t, scaleTargets(project, map[string]int{"web": 3}, observed), map[string]int{"web": 3})
+	// scaling from zero starts the dependencies which are not running
+	assert.DeepEqual(t, scaleTargets(project, map[string]int{"worker": 2}, observed), map[string]int{"worker": 2, "db": 2})
+	assert.DeepEqual(t, scaleTargets(project, map[string]int{"api": 1, "db": 1}, observed), map[string]int{"api": 1, "db": 1})
+	assert.DeepEqual(t, scaleTargets(project, map[string]int{"worker": 0}, observed), map[string]int{"worker": 0})
+}
+
+func TestServiceHashIgnoresScale(t *testing.T) {
+	replicas := uint64(3)
+	scaled := types.ServiceConfig{Name: "web", Image: "nginx", Scale: 2, Deploy: &types.DeployConfig{Replicas: &replicas}}
+	deployed := types.ServiceConfig{Name: "web", Image: "nginx", Deploy: &types.DeployConfig{}}
+
+	scaledHash, err := serviceHash(scaled)
+	assert.NilError(t, err)
+	deployedHash, err := serviceHash(deployed)
+	assert.NilError(t, err)
+	assert.Equal(t, deployedHash, scaledHash)
+	assert.Equal(t, *scaled.Deploy.Replicas, uint64(3))
+}
@@ -0,0 +1,107 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/secrets"
+)
+
+// secretContentExtension holds the content of the secrets sourced from the environment or from a
+// secret provider, once read by up
+const secretContentExtension = "x-content"
+
+// resolveSecrets reads the content of the secrets sourced from the environment or from a secret
+// provider, so that up fails before creating anything when one can't be read. The content is
+// copied into the service containers, it is never written on the host.
+func resolveSecrets(ctx context.Context, project *types.Project) error {
+	for name, secret := range project.Secrets {
+		if secret.External.External || secret.File != "" {
+			continue
+		}
+		content, err := secrets.ReadContent(ctx, secret)
+		if err != nil {
+			return err
+		}
+		if secret.Extensions == nil {
+			secret.Extensions = map[string]interface{}{}
+		}
+		secret.Extensions[secretContentExtension] = content
+		project.Secrets[name] = secret
+	}
+	return nil
+}
+
+// secretTarget returns the path of a secret in the service containers, in /run/secrets unless an absolute target is set
+func secretTarget(ref types.ServiceSecretConfig) string {
+	target := ref.Target
+	if target == "" {
+		target = ref.Source
+	}
+	if !filepath.IsAbs(target) {
+		target = "/run/secrets/" + target
+	}
+	return target
+}
+
+// buildInjectedSecrets returns the secrets of a service resolved by resolveSecrets, with the
+// ownership and mode set by the service
+func buildInjectedSecrets(p *types.Project, s types.ServiceConfig) ([]injectedFile, error) {
+	var files []injectedFile
+	for _, ref := range s.Secrets {
+		content, ok := p.Secrets[ref.Source].Extensions[secretContentExtension].([]byte)
+		if !ok {
+			continue
+		}
+		file, err := newInjectedFile(types.FileReferenceConfig(ref), secretTarget(ref), content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "service %q", s.Name)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// buildSecretMounts bind mounts the file based secrets of a service
+func buildSecretMounts(p *types.Project, s types.ServiceConfig) []mount.Mount {
+	var mounts []mount.Mount
+	for _, ref := range s.Secrets {
+		secret, ok := p.Secrets[ref.Source]
+		if !ok || secret.External.External || secret.File == "" {
+			continue
+		}
+		source := secret.File
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(p.WorkingDir, source)
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   secretTarget(ref),
+			ReadOnly: true,
+		})
+	}
+	return mounts
+}
@@ -0,0 +1,118 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"archive/tar"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/docker/api/types/mount"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/secrets"
+)
+
+func TestSecretMounts(t *testing.T) {
+	assert.NilError(t, os.Setenv("TEST_SECRET_TOKEN", "s3cr3t"))
+	defer os.Unsetenv("TEST_SECRET_TOKEN") // nolint:errcheck
+
+	mode := uint32(0400)
+	project := &types.Project{
+		Name:       "secrets_test",
+		WorkingDir: "/project",
+		Secrets: map[string]types.SecretConfig{
+			"token":    {Extensions: map[string]interface{}{secrets.EnvironmentExtension: "TEST_SECRET_TOKEN"}},
+			"password": {File: "password.txt"},
+			"external": {External: types.External{External: true}},
+		},
+	}
+	service := types.ServiceConfig{
+		Name: "web",
+		Secrets: []types.ServiceSecretConfig{
+			{Source: "token", UID: "1000", GID: "1001", Mode: &mode},
+			{Source: "password", Target: "db_password"},
+			{Source: "external"},
+		},
+	}
+
+	assert.NilError(t, resolveSecrets(context.Background(), project))
+	assert.Equal(t, project.Secrets["token"].File, "")
+
+	assert.DeepEqual(t, buildSecretMounts(project, service), []mount.Mount{
+		{Type: mount.TypeBind, Source: "/project/password.txt", Target: "/run/secrets/db_password", ReadOnly: true},
+	})
+	files, err := buildInjectedFiles(project, service)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, files, []injectedFile{
+		{Target: "/run/secrets/token", Content: []byte("s3cr3t"), UID: 1000, GID: 1001, Mode: 0400},
+	})
+
+	archive, err := archiveInjectedFiles(files)
+	assert.NilError(t, err)
+	tr := tar.NewReader(archive)
+	header, err := tr.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, header.Name, "run/secrets/token")
+	assert.Equal(t, header.Uid, 1000)
+	assert.Equal(t, header.Gid, 1001)
+	assert.Equal(t, header.Mode, int64(0400))
+	content, err := ioutil.ReadAll(tr)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "s3cr3t")
+
+	service.ReadOnly = true
+	_, err = buildInjectedFiles(project, service)
+	assert.ErrorContains(t, err, "can't be copied to a read-only root filesystem")
+}
+
+func TestInjectedSecretDefaults(t *testing.T) {
+	project := &types.Project{
+		Secrets: map[string]types.SecretConfig{
+			"token": {Extensions: map[string]interface{}{secretContentExtension: []byte("s3cr3t")}},
+		},
+	}
+	service := types.ServiceConfig{
+		Name:    "web",
+		Secrets: []types.ServiceSecretConfig{{Source: "token", Target: "/etc/token"}},
+	}
+	files, err := buildInjectedFiles(project, service)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, files, []injectedFile{
+		{Target: "/etc/token", Content: []byte("s3cr3t"), Mode: 0444},
+	})
+
+	service.Secrets[0].UID = "www-data"
+	_, err = buildInjectedFiles(project, service)
+	assert.Error(t, err, `service "web": invalid uid "www-data" of "token": numeric id expected`)
+}
+
+func TestResolveSecretsUnsetEnvironment(t *testing.T) {
+	project := &types.Project{
+		Name: "secrets_test",
+		Secrets: map[string]types.SecretConfig{
+			"token": {Name: "token", Extensions: map[string]interface{}{secrets.EnvironmentExtension: "TEST_SECRET_UNSET"}},
+		},
+	}
+	err := resolveSecrets(context.Background(), project)
+	assert.Error(t, err, `environment variable "TEST_SECRET_UNSET" of secret "token" is not set`)
+}
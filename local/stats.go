@@ -0,0 +1,171 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// statsInterval is how often aggregated stats are reported when streaming
+const statsInterval = time.Second
+
+type containerStats struct {
+	service       string
+	cpuPercentage float64
+	memoryUsage   uint64
+	memoryLimit   uint64
+}
+
+func (s *local) Stats(ctx context.Context, projectName string, options compose.StatsOptions) error {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(projectName)),
+	})
+	if err != nil {
+		return err
+	}
+	list = withoutOneOffContainers(list)
+	if len(list) == 0 {
+		return errors.Wrapf(errdefs.ErrNotFound, "no running container for project %q", projectName)
+	}
+
+	var (
+		lock    sync.Mutex
+		samples = map[string]containerStats{}
+	)
+	eg, ctx := errgroup.WithContext(ctx)
+	for _, c := range list {
+		c := c
+		eg.Go(func() error {
+			resp, err := s.containerService.apiClient.ContainerStats(ctx, c.ID, options.Stream)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close() // nolint:errcheck
+			dec := json.NewDecoder(resp.Body)
+			for {
+				var stats moby.StatsJSON
+				if err := dec.Decode(&stats); err != nil {
+					if err == io.EOF || ctx.Err() != nil {
+						return nil
+					}
+					return err
+				}
+				lock.Lock()
+				samples[c.ID] = toContainerStats(c.Labels[serviceLabel], stats)
+				lock.Unlock()
+				if !options.Stream {
+					return nil
+				}
+			}
+		})
+	}
+
+	report := func() {
+		lock.Lock()
+		defer lock.Unlock()
+		options.Consumer(aggregateStats(samples))
+	}
+	if !options.Stream {
+		if err := eg.Wait(); err != nil {
+			return err
+		}
+		report()
+		return nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- eg.Wait()
+	}()
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			report()
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+func toContainerStats(service string, stats moby.StatsJSON) containerStats {
+	memory := stats.MemoryStats.Usage
+	if cache, ok := stats.MemoryStats.Stats["cache"]; ok && cache < memory {
+		memory -= cache
+	}
+	return containerStats{
+		service:       service,
+		cpuPercentage: cpuPercentage(stats),
+		memoryUsage:   memory,
+		memoryLimit:   stats.MemoryStats.Limit,
+	}
+}
+
+// cpuPercentage computes the CPU usage between the previous and the current sample, the
+// same way `docker stats` does
+func cpuPercentage(stats moby.StatsJSON) float64 {
+	cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(stats.CPUStats.SystemUsage) - float64(stats.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(stats.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+	return cpuDelta / systemDelta * onlineCPUs * 100
+}
+
+// aggregateStats sums the stats of the containers by service
+func aggregateStats(samples map[string]containerStats) []compose.ServiceStats {
+	byService := map[string]*compose.ServiceStats{}
+	for _, sample := range samples {
+		stats, ok := byService[sample.service]
+		if !ok {
+			stats = &compose.ServiceStats{Service: sample.service}
+			byService[sample.service] = stats
+		}
+		stats.Replicas++
+		stats.CPUPercentage += sample.cpuPercentage
+		stats.MemoryUsage += sample.memoryUsage
+		stats.MemoryLimit += sample.memoryLimit
+	}
+	result := []compose.ServiceStats{}
+	for _, stats := range byService {
+		result = append(result, *stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Service < result[j].Service
+	})
+	return result
+}
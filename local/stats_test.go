@@ -0,0 +1,52 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestCPUPercentage(t *testing.T) {
+	stats := moby.StatsJSON{}
+	stats.PreCPUStats.CPUUsage.TotalUsage = 100
+	stats.PreCPUStats.SystemUsage = 1000
+	stats.CPUStats.CPUUsage.TotalUsage = 200
+	stats.CPUStats.SystemUsage = 2000
+	stats.CPUStats.OnlineCPUs = 2
+	assert.Equal(t, cpuPercentage(stats), 20.0)
+
+	assert.Equal(t, cpuPercentage(moby.StatsJSON{}), 0.0)
+}
+
+func TestAggregateStats(t *testing.T) {
+	samples := map[string]containerStats{
+		"web1": {service: "web", cpuPercentage: 10, memoryUsage: 100, memoryLimit: 1000},
+		"web2": {service: "web", cpuPercentage: 5, memoryUsage: 50, memoryLimit: 1000},
+		"db1":  {service: "db", cpuPercentage: 1, memoryUsage: 500, memoryLimit: 2000},
+	}
+	assert.DeepEqual(t, aggregateStats(samples), []compose.ServiceStats{
+		{Service: "db", Replicas: 1, CPUPercentage: 1, MemoryUsage: 500, MemoryLimit: 2000},
+		{Service: "web", Replicas: 2, CPUPercentage: 15, MemoryUsage: 150, MemoryLimit: 2000},
+	})
+}
@@ -0,0 +1,105 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/progress"
+)
+
+func (s *local) Stop(ctx context.Context, project *types.Project) error {
+	w := progress.ContextWriter(ctx)
+	return inReverseDependencyOrder(ctx, project, func(ctx context.Context, service types.ServiceConfig) error {
+		list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+			Filters: filters.NewArgs(
+				projectFilter(project.Name),
+				serviceFilter(service.Name),
+			),
+		})
+		if err != nil {
+			return err
+		}
+		eg, _ := errgroup.WithContext(ctx)
+		for _, c := range list {
+			container := c
+			eg.Go(func() error {
+				w.Event(progress.Event{
+					ID:     getContainerName(container),
+					Text:   "Stopping",
+					Status: progress.Working,
+				})
+				// the engine waits for the stop_grace_period the container was created with before killing it
+				if err := s.containerService.Stop(ctx, container.ID, nil); err != nil {
+					w.Event(progress.Event{
+						ID:     getContainerName(container),
+						Text:   "Error",
+						Status: progress.Error,
+					})
+					return err
+				}
+				w.Event(progress.Event{
+					ID:     getContainerName(container),
+					Text:   "Stopped",
+					Status: progress.Done,
+				})
+				return nil
+			})
+		}
+		return eg.Wait()
+	})
+}
+
+func (s *local) Kill(ctx context.Context, projectName string) error {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	w := progress.ContextWriter(ctx)
+	eg, _ := errgroup.WithContext(ctx)
+	for _, c := range list {
+		container := c
+		eg.Go(func() error {
+			w.Event(progress.Event{
+				ID:     getContainerName(container),
+				Text:   "Killing",
+				Status: progress.Working,
+			})
+			if err := s.containerService.Kill(ctx, container.ID, "SIGKILL"); err != nil {
+				return err
+			}
+			w.Event(progress.Event{
+				ID:     getContainerName(container),
+				Text:   "Killed",
+				Status: progress.Done,
+			})
+			return nil
+		})
+	}
+	return eg.Wait()
+}
@@ -0,0 +1,87 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/docker/docker/client"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+// defaultDialRetryDelay is the delay before dialing the engine again when the context retry policy doesn't set one
+const defaultDialRetryDelay = 500 * time.Millisecond
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// withRetryPolicy configures the engine API client with the retry policy of the context. The timeout bounds
+// how long a call waits for the response headers, so streamed responses (logs, events, attach) aren't cut.
+// Failing connections to the engine are dialed again, requests are never sent twice as they may not be
+// idempotent.
+func withRetryPolicy(policy store.RetryPolicy) client.Opt {
+	return func(c *client.Client) error {
+		if policy == (store.RetryPolicy{}) {
+			return nil
+		}
+		httpClient := *c.HTTPClient()
+		transport, ok := httpClient.Transport.(*http.Transport)
+		if !ok {
+			return nil
+		}
+		// the hijacked connections of attach and exec reuse the dialer of an *http.Transport, it isn't wrapped
+		transport = transport.Clone()
+		if policy.Timeout > 0 {
+			transport.ResponseHeaderTimeout = policy.Timeout
+		}
+		if policy.MaxRetries > 0 {
+			dial := transport.DialContext
+			if dial == nil {
+				dial = (&net.Dialer{}).DialContext
+			}
+			transport.DialContext = withDialRetries(dial, policy)
+		}
+		httpClient.Transport = transport
+		return client.WithHTTPClient(&httpClient)(c)
+	}
+}
+
+// withDialRetries dials the engine again with an exponential backoff until the retries of the policy are exhausted
+func withDialRetries(dial dialFunc, policy store.RetryPolicy) dialFunc {
+	retry := retryPolicy{attempts: policy.MaxRetries + 1, delay: policy.RetryDelay, backoff: 2}
+	if retry.delay == 0 {
+		retry.delay = defaultDialRetryDelay
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		for attempt := 1; ; attempt++ {
+			conn, err := dial(ctx, network, addr)
+			if err == nil || attempt >= retry.attempts {
+				return conn, err
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retry.wait(attempt)):
+			}
+		}
+	}
+}
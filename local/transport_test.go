@@ -0,0 +1,64 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+func TestWithRetryPolicyTimeout(t *testing.T) {
+	c, err := client.NewClientWithOpts(withRetryPolicy(store.RetryPolicy{Timeout: 42 * time.Second}))
+	assert.NilError(t, err)
+	transport, ok := c.HTTPClient().Transport.(*http.Transport)
+	assert.Assert(t, ok)
+	assert.Equal(t, transport.ResponseHeaderTimeout, 42*time.Second)
+}
+
+func TestWithDialRetries(t *testing.T) {
+	calls := 0
+	dial := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("connection refused")
+		}
+		return &net.TCPConn{}, nil
+	}
+
+	policy := store.RetryPolicy{MaxRetries: 2, RetryDelay: time.Millisecond}
+	conn, err := withDialRetries(dial, policy)(context.TODO(), "unix", "/var/run/docker.sock")
+	assert.NilError(t, err)
+	assert.Assert(t, conn != nil)
+	assert.Equal(t, calls, 3)
+
+	calls = 0
+	policy.MaxRetries = 1
+	_, err = withDialRetries(dial, policy)(context.TODO(), "unix", "/var/run/docker.sock")
+	assert.Error(t, err, "connection refused")
+	assert.Equal(t, calls, 2)
+}
@@ -21,6 +21,7 @@ package local
 import (
 	"encoding/json"
 
+	"github.com/compose-spec/compose-go/types"
 	"github.com/opencontainers/go-digest"
 )
 
@@ -32,6 +33,31 @@ func jsonHash(o interface{}) (string, error) {
 	return digest.SHA256.FromBytes(bytes).String(), nil
 }
 
+// serviceHash returns the hash of the service configuration its containers are labelled with, the scale
+// of the service is excluded so scaling it doesn't recreate the existing containers, as is the Lifecycle
+// strategy which only applies to the current convergence
+func serviceHash(service types.ServiceConfig) (string, error) {
+	service.Scale = 0
+	if _, ok := service.Extensions[extLifecycle]; ok {
+		extensions := map[string]interface{}{}
+		for k, v := range service.Extensions {
+			if k != extLifecycle {
+				extensions[k] = v
+			}
+		}
+		if len(extensions) == 0 {
+			extensions = nil
+		}
+		service.Extensions = extensions
+	}
+	if service.Deploy != nil {
+		deploy := *service.Deploy
+		deploy.Replicas = nil
+		service.Deploy = &deploy
+	}
+	return jsonHash(service)
+}
+
 func contains(slice []string, item string) bool {
 	for _, v := range slice {
 		if v == item {
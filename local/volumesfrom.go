@@ -0,0 +1,77 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// resolveVolumesFrom replaces the services referenced by the `volumes_from` entries of the host config
+// by a container of the service, so the container mounts its volumes, anonymous ones included
+func (s *local) resolveVolumesFrom(ctx context.Context, project *types.Project, hostConfig *container.HostConfig) error {
+	var volumesFrom []string
+	for _, spec := range hostConfig.VolumesFrom {
+		source, isContainer, mode := compose.ParseVolumesFrom(spec)
+		if !isContainer {
+			if _, err := project.GetService(source); err != nil {
+				return errors.Wrapf(errdefs.ErrNotFound, "volumes_from references unknown service %q", source)
+			}
+			id, err := s.getVolumesSourceContainerID(ctx, project.Name, source)
+			if err != nil {
+				return err
+			}
+			source = id
+		}
+		if mode != "" {
+			source += ":" + mode
+		}
+		volumesFrom = append(volumesFrom, source)
+	}
+	hostConfig.VolumesFrom = volumesFrom
+	return nil
+}
+
+// getVolumesSourceContainerID returns the ID of a container of the service, which doesn't need to be
+// running as services sharing their volumes are often data-only containers
+func (s *local) getVolumesSourceContainerID(ctx context.Context, projectName string, service string) (string, error) {
+	containers, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(service),
+		),
+		All: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	containers = withoutOneOffContainers(containers)
+	if len(containers) == 0 {
+		return "", errors.Errorf("no container for service %q to mount volumes from", service)
+	}
+	return containers[0].ID, nil
+}
@@ -0,0 +1,77 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"sort"
+
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// Wait blocks on the engine wait endpoint of the selected containers, which reports their exit without polling
+func (s *local) Wait(ctx context.Context, projectName string, services []string) ([]compose.ContainerExit, error) {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(projectFilter(projectName)),
+		All:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var selected []moby.Container
+	for _, c := range withoutOneOffContainers(list) {
+		if len(services) == 0 || contains(services, c.Labels[serviceLabel]) {
+			selected = append(selected, c)
+		}
+	}
+	if len(selected) == 0 {
+		return nil, errors.Wrapf(errdefs.ErrNotFound, "no container found for project %q", projectName)
+	}
+
+	result := make([]compose.ContainerExit, len(selected))
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, c := range selected {
+		i, c := i, c
+		eg.Go(func() error {
+			code, err := s.containerService.Wait(ctx, c.ID)
+			if err != nil {
+				return err
+			}
+			result[i] = compose.ContainerExit{
+				Name:     getContainerName(c),
+				Service:  c.Labels[serviceLabel],
+				ExitCode: code,
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
@@ -0,0 +1,70 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	moby "github.com/docker/docker/api/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+func TestWaitProject(t *testing.T) {
+	engine := newFakeEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1.40/containers/json":
+			assert.Assert(t, r.URL.Query().Get("filters") != "")
+			var list []moby.Container
+			if r.URL.Query().Get("filters") == `{"label":{"com.docker.compose.project=demo":true}}` {
+				list = []moby.Container{
+					{ID: "w1", Names: []string{"/demo_web_1"}, Labels: map[string]string{serviceLabel: "web"}},
+					{ID: "d1", Names: []string{"/demo_db_1"}, Labels: map[string]string{serviceLabel: "db"}},
+					{ID: "r1", Names: []string{"/demo_web_run_1"}, Labels: map[string]string{serviceLabel: "web", oneoffLabel: "True"}},
+				}
+			}
+			writeJSON(t, w, http.StatusOK, list)
+		case "/v1.40/containers/w1/wait":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"StatusCode": 0})
+		case "/v1.40/containers/d1/wait":
+			writeJSON(t, w, http.StatusOK, map[string]interface{}{"StatusCode": 137})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	s := newLocal(engine)
+
+	exits, err := s.Wait(context.Background(), "demo", nil)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, exits, []compose.ContainerExit{
+		{Name: "demo_db_1", Service: "db", ExitCode: 137},
+		{Name: "demo_web_1", Service: "web", ExitCode: 0},
+	})
+
+	exits, err = s.Wait(context.Background(), "demo", []string{"web"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, exits, []compose.ContainerExit{{Name: "demo_web_1", Service: "web", ExitCode: 0}})
+
+	_, err = s.Wait(context.Background(), "other", nil)
+	assert.Assert(t, errdefs.IsNotFoundError(err))
+}
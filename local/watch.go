@@ -0,0 +1,278 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	moby "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/errdefs"
+)
+
+// watchInterval is how often the files watched by the develop.watch rules are checked for changes
+var watchInterval = time.Second
+
+type watchRule struct {
+	service string
+	trigger compose.Trigger
+	// files holds the modification time of the watched files on last check
+	files map[string]time.Time
+}
+
+func (s *local) Watch(ctx context.Context, project *types.Project, options compose.WatchOptions) error {
+	rules, err := watchRules(project, options.Services)
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return errors.New("none of the services declares develop.watch rules")
+	}
+	out := options.Out
+	if out == nil {
+		out = ioutil.Discard
+	}
+	for _, r := range rules {
+		if r.trigger.Action == compose.WatchActionRebuild {
+			return errors.Wrapf(errdefs.ErrNotImplemented, "service %q: watch action %q, build is not supported yet", r.service, r.trigger.Action)
+		}
+		if r.files, err = scanFiles(r.trigger); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, r := range rules {
+				files, err := scanFiles(r.trigger)
+				if err != nil {
+					return err
+				}
+				changed := changedFiles(r.files, files)
+				r.files = files
+				if len(changed) == 0 {
+					continue
+				}
+				if err := s.applyWatchRule(ctx, project.Name, r, changed, out); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// watchRules collects the develop.watch rules of the selected services, with paths resolved from the project working directory
+func watchRules(project *types.Project, services []string) ([]*watchRule, error) {
+	var rules []*watchRule
+	for _, service := range project.Services {
+		if len(services) > 0 && !contains(services, service.Name) {
+			continue
+		}
+		config, err := compose.ServiceDevelopConfig(service)
+		if err != nil {
+			return nil, err
+		}
+		if config == nil {
+			continue
+		}
+		for _, trigger := range config.Watch {
+			if !filepath.IsAbs(trigger.Path) {
+				trigger.Path = filepath.Join(project.WorkingDir, trigger.Path)
+			}
+			rules = append(rules, &watchRule{
+				service: service.Name,
+				trigger: trigger,
+			})
+		}
+	}
+	return rules, nil
+}
+
+func (s *local) applyWatchRule(ctx context.Context, projectName string, r *watchRule, changed []string, out io.Writer) error {
+	list, err := s.containerService.apiClient.ContainerList(ctx, moby.ContainerListOptions{
+		Filters: filters.NewArgs(
+			projectFilter(projectName),
+			serviceFilter(r.service),
+		),
+	})
+	if err != nil {
+		return err
+	}
+	containers := withoutOneOffContainers(list)
+
+	switch r.trigger.Action {
+	case compose.WatchActionSync:
+		// removed files are left in the containers, only created and updated files are synced
+		for _, c := range containers {
+			archive, destination, err := archiveFiles(r.trigger, changed)
+			if err != nil {
+				return err
+			}
+			err = s.containerService.apiClient.CopyToContainer(ctx, c.ID, destination, archive, moby.CopyToContainerOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "service %q: failed to sync files to %s", r.service, r.trigger.Target)
+			}
+		}
+		fmt.Fprintf(out, "%s: synced %d file(s) to %s\n", r.service, len(changed), r.trigger.Target)
+	case compose.WatchActionRestart:
+		for _, c := range containers {
+			if err := s.containerService.apiClient.ContainerRestart(ctx, c.ID, nil); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(out, "%s: restarted after %d file(s) changed\n", r.service, len(changed))
+	}
+	return nil
+}
+
+// scanFiles returns the modification time of the files under the path of a trigger, ignored files excepted
+func scanFiles(trigger compose.Trigger) (map[string]time.Time, error) {
+	files := map[string]time.Time{}
+	err := filepath.Walk(trigger.Path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(trigger.Path, p)
+		if err != nil {
+			return err
+		}
+		if ignored(trigger.Ignore, rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			files[p] = info.ModTime()
+		}
+		return nil
+	})
+	return files, err
+}
+
+// ignored checks whether a path, relative to the watched path, matches one of the ignore patterns.
+// Patterns match the whole relative path, its base name, or a parent directory.
+func ignored(patterns []string, rel string) bool {
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(filepath.ToSlash(pattern), "/")
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+			return true
+		}
+		if strings.HasPrefix(rel, pattern+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// changedFiles lists, sorted, the files created or modified between two scans
+func changedFiles(before map[string]time.Time, after map[string]time.Time) []string {
+	var changed []string
+	for file, modTime := range after {
+		if previous, ok := before[file]; !ok || !previous.Equal(modTime) {
+			changed = append(changed, file)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// archiveFiles builds the tar archive of the changed files to be extracted in the containers at the returned destination
+func archiveFiles(trigger compose.Trigger, changed []string) (io.Reader, string, error) {
+	info, err := os.Stat(trigger.Path)
+	if err != nil {
+		return nil, "", err
+	}
+	destination := trigger.Target
+	names := map[string]string{}
+	for _, file := range changed {
+		if !info.IsDir() {
+			// a watched file is synced to the target file path
+			destination = path.Dir(trigger.Target)
+			names[file] = path.Base(trigger.Target)
+			continue
+		}
+		rel, err := filepath.Rel(trigger.Path, file)
+		if err != nil {
+			return nil, "", err
+		}
+		names[file] = filepath.ToSlash(rel)
+	}
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, file := range changed {
+		if err := addToArchive(tw, file, names[file]); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, destination, nil
+}
+
+func addToArchive(tw *tar.Writer, file string, name string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint:errcheck
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
@@ -0,0 +1,86 @@
+// +build local
+
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestIgnored(t *testing.T) {
+	patterns := []string{"node_modules/", "*.log"}
+	assert.Assert(t, ignored(patterns, "node_modules"))
+	assert.Assert(t, ignored(patterns, filepath.Join("node_modules", "lib", "index.js")))
+	assert.Assert(t, ignored(patterns, filepath.Join("logs", "debug.log")))
+	assert.Assert(t, !ignored(patterns, filepath.Join("src", "index.js")))
+}
+
+func TestScanChangedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "node_modules"), 0755))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "node_modules", "lib.js"), []byte("lib"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "index.js"), []byte("index"), 0644))
+
+	trigger := compose.Trigger{Path: dir, Ignore: []string{"node_modules"}}
+	before, err := scanFiles(trigger)
+	assert.NilError(t, err)
+	assert.Equal(t, len(before), 1)
+
+	later := time.Now().Add(time.Minute)
+	assert.NilError(t, os.Chtimes(filepath.Join(dir, "index.js"), later, later))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "app.js"), []byte("app"), 0644))
+	after, err := scanFiles(trigger)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, changedFiles(before, after), []string{filepath.Join(dir, "app.js"), filepath.Join(dir, "index.js")})
+}
+
+func TestArchiveFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	assert.NilError(t, os.Mkdir(filepath.Join(dir, "src"), 0755))
+	file := filepath.Join(dir, "src", "index.js")
+	assert.NilError(t, ioutil.WriteFile(file, []byte("index"), 0644))
+
+	archive, destination, err := archiveFiles(compose.Trigger{Path: dir, Target: "/app"}, []string{file})
+	assert.NilError(t, err)
+	assert.Equal(t, destination, "/app")
+	header, err := tar.NewReader(archive).Next()
+	assert.NilError(t, err)
+	assert.Equal(t, header.Name, "src/index.js")
+
+	archive, destination, err = archiveFiles(compose.Trigger{Path: file, Target: "/app/main.js"}, []string{file})
+	assert.NilError(t, err)
+	assert.Equal(t, destination, "/app")
+	header, err = tar.NewReader(archive).Next()
+	assert.NilError(t, err)
+	assert.Equal(t, header.Name, "main.js")
+}
@@ -32,9 +32,11 @@ type client struct {
 // Command is a command
 type Command struct {
 	Command string `json:"command"`
-	Context string `json:"context"`
-	Source  string `json:"source"`
-	Status  string `json:"status"`
+	// Context is the type of the context (backend) the command ran against
+	Context  string `json:"context"`
+	Source   string `json:"source"`
+	Status   string `json:"status"`
+	Duration int64  `json:"durationMs,omitempty"`
 }
 
 const (
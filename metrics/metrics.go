@@ -18,21 +18,30 @@ package metrics
 
 import (
 	"strings"
+	"time"
 
 	"github.com/docker/compose-cli/utils"
 )
 
-// Track sends the tracking analytics to Docker Desktop
+// commandStart is the time the CLI process started, commands are tracked once
+// per process so this is used to compute the command duration
+var commandStart = time.Now()
+
+// Track sends the tracking analytics to Docker Desktop, and records them in
+// the local summary if enabled
 func Track(context string, args []string, status string) {
 	command := GetCommand(args)
 	if command != "" {
+		cmd := Command{
+			Command:  command,
+			Context:  context,
+			Source:   CLISource,
+			Status:   status,
+			Duration: time.Since(commandStart).Milliseconds(),
+		}
 		c := NewClient()
-		c.Send(Command{
-			Command: command,
-			Context: context,
-			Source:  CLISource,
-			Status:  status,
-		})
+		c.Send(cmd)
+		recordSummary(cmd)
 	}
 }
 
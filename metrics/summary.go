@@ -0,0 +1,131 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/docker/compose-cli/utils"
+)
+
+// SummaryFileName is the name of the file, in the config directory, holding
+// the local aggregates of tracked commands
+const SummaryFileName = "metrics-summary.json"
+
+const (
+	summaryLockFileName = ".metrics-summary.lock"
+	summaryLockTimeout  = 2 * time.Second
+)
+
+var summaryDir string
+
+// EnableSummary aggregates tracked commands in the config directory dir
+func EnableSummary(dir string) {
+	summaryDir = dir
+}
+
+// Summary aggregates the executions of a command against a context type
+type Summary struct {
+	Command  string
+	Context  string
+	Count    int
+	Failures int
+	// TotalDuration and MaxDuration are in milliseconds
+	TotalDuration int64
+	MaxDuration   int64
+}
+
+// AverageDuration returns the average execution time in milliseconds
+func (s Summary) AverageDuration() int64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.TotalDuration / int64(s.Count)
+}
+
+// LoadSummary reads the local aggregates stored in the config directory dir,
+// sorted by context type and command
+func LoadSummary(dir string) ([]Summary, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, SummaryFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var summaries []Summary
+	if err := json.Unmarshal(data, &summaries); err != nil {
+		return nil, err
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Context != summaries[j].Context {
+			return summaries[i].Context < summaries[j].Context
+		}
+		return summaries[i].Command < summaries[j].Command
+	})
+	return summaries, nil
+}
+
+func recordSummary(cmd Command) {
+	if summaryDir == "" {
+		return
+	}
+	// concurrent CLI runs update the summary one at a time, not to lose their changes
+	unlock, err := utils.LockFile(filepath.Join(summaryDir, summaryLockFileName), summaryLockTimeout)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	summaries, err := LoadSummary(summaryDir)
+	if err != nil {
+		// a corrupted summary is reset, this is only a developer aid
+		summaries = nil
+	}
+	summaries = addToSummary(summaries, cmd)
+	data, err := json.Marshal(summaries)
+	if err != nil {
+		return
+	}
+	_ = utils.AtomicWriteFile(filepath.Join(summaryDir, SummaryFileName), data, 0644)
+}
+
+func addToSummary(summaries []Summary, cmd Command) []Summary {
+	i := 0
+	for ; i < len(summaries); i++ {
+		if summaries[i].Command == cmd.Command && summaries[i].Context == cmd.Context {
+			break
+		}
+	}
+	if i == len(summaries) {
+		summaries = append(summaries, Summary{Command: cmd.Command, Context: cmd.Context})
+	}
+	s := &summaries[i]
+	s.Count++
+	if cmd.Status == FailureStatus {
+		s.Failures++
+	}
+	s.TotalDuration += cmd.Duration
+	if cmd.Duration > s.MaxDuration {
+		s.MaxDuration = cmd.Duration
+	}
+	return summaries
+}
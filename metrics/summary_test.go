@@ -0,0 +1,84 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package metrics
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAddToSummary(t *testing.T) {
+	var summaries []Summary
+	summaries = addToSummary(summaries, Command{Command: "ps", Context: "aci", Status: SuccessStatus, Duration: 100})
+	summaries = addToSummary(summaries, Command{Command: "ps", Context: "aci", Status: FailureStatus, Duration: 300})
+	summaries = addToSummary(summaries, Command{Command: "ps", Context: "moby", Status: SuccessStatus, Duration: 10})
+
+	assert.DeepEqual(t, summaries, []Summary{
+		{Command: "ps", Context: "aci", Count: 2, Failures: 1, TotalDuration: 400, MaxDuration: 300},
+		{Command: "ps", Context: "moby", Count: 1, TotalDuration: 10, MaxDuration: 10},
+	})
+	assert.Equal(t, summaries[0].AverageDuration(), int64(200))
+}
+
+func TestRecordSummary(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	summaries, err := LoadSummary(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 0)
+
+	EnableSummary(dir)
+	defer EnableSummary("")
+	recordSummary(Command{Command: "up", Context: "moby", Status: SuccessStatus, Duration: 5})
+	recordSummary(Command{Command: "compose up", Context: "ecs", Status: SuccessStatus, Duration: 7})
+
+	summaries, err = LoadSummary(dir)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, summaries, []Summary{
+		{Command: "compose up", Context: "ecs", Count: 1, TotalDuration: 7, MaxDuration: 7},
+		{Command: "up", Context: "moby", Count: 1, TotalDuration: 5, MaxDuration: 5},
+	})
+}
+
+func TestRecordSummaryConcurrently(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	EnableSummary(dir)
+	defer EnableSummary("")
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordSummary(Command{Command: "ps", Context: "moby", Status: SuccessStatus, Duration: 1})
+		}()
+	}
+	wg.Wait()
+
+	summaries, err := LoadSummary(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(summaries), 1)
+	assert.Equal(t, summaries[0].Count, 10)
+}
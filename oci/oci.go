@@ -0,0 +1,221 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/containerd/containerd/content"
+	cerrdefs "github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/remotes"
+	"github.com/containerd/containerd/remotes/docker"
+	"github.com/docker/cli/cli/config"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Scheme prefixes references to compose projects published as OCI artifacts
+	Scheme = "oci://"
+	// ConfigMediaType is the media type of the artifact config
+	ConfigMediaType = "application/vnd.docker.compose.config.v1+json"
+	// ComposeFileMediaType is the media type of the layer holding the resolved compose file
+	ComposeFileMediaType = "application/vnd.docker.compose.file+yaml"
+	// EnvFileMediaType is the media type of the layer holding the env defaults
+	EnvFileMediaType = "application/vnd.docker.compose.envfile"
+	// ProjectNameAnnotation is the manifest annotation holding the project name
+	ProjectNameAnnotation = "com.docker.compose.project"
+
+	dockerHubConfigKey = "https://index.docker.io/v1/"
+)
+
+// Artifact is a compose project published to a registry
+type Artifact struct {
+	ProjectName string
+	// ComposeFile is the resolved compose file, in YAML
+	ComposeFile []byte
+	// EnvFile holds the env defaults of the project, if any
+	EnvFile []byte
+}
+
+// IsReference returns true when s references an OCI artifact
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, Scheme)
+}
+
+// Push publishes the artifact to the registry as ref and returns the digest of its manifest
+func Push(ctx context.Context, ref string, artifact Artifact) (digest.Digest, error) {
+	ref = strings.TrimPrefix(ref, Scheme)
+	pusher, err := newResolver().Pusher(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	configDesc, err := push(ctx, pusher, ConfigMediaType, []byte("{}"), nil)
+	if err != nil {
+		return "", err
+	}
+	layers := []ocispec.Descriptor{}
+	layer, err := push(ctx, pusher, ComposeFileMediaType, artifact.ComposeFile, map[string]string{
+		ocispec.AnnotationTitle: "compose.yaml",
+	})
+	if err != nil {
+		return "", err
+	}
+	layers = append(layers, layer)
+	if len(artifact.EnvFile) > 0 {
+		layer, err = push(ctx, pusher, EnvFileMediaType, artifact.EnvFile, map[string]string{
+			ocispec.AnnotationTitle: ".env",
+		})
+		if err != nil {
+			return "", err
+		}
+		layers = append(layers, layer)
+	}
+
+	manifest, err := json.Marshal(ocispec.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		Config:    configDesc,
+		Layers:    layers,
+		Annotations: map[string]string{
+			ProjectNameAnnotation: artifact.ProjectName,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	manifestDesc, err := push(ctx, pusher, ocispec.MediaTypeImageManifest, manifest, nil)
+	if err != nil {
+		return "", err
+	}
+	return manifestDesc.Digest, nil
+}
+
+func push(ctx context.Context, pusher remotes.Pusher, mediaType string, data []byte, annotations map[string]string) (ocispec.Descriptor, error) {
+	desc := ocispec.Descriptor{
+		MediaType:   mediaType,
+		Digest:      digest.FromBytes(data),
+		Size:        int64(len(data)),
+		Annotations: annotations,
+	}
+	w, err := pusher.Push(ctx, desc)
+	if cerrdefs.IsAlreadyExists(err) {
+		return desc, nil
+	}
+	if err != nil {
+		return desc, err
+	}
+	defer w.Close() // nolint:errcheck
+	if err := content.Copy(ctx, w, bytes.NewReader(data), desc.Size, desc.Digest); err != nil && !cerrdefs.IsAlreadyExists(err) {
+		return desc, err
+	}
+	return desc, nil
+}
+
+// Pull fetches the artifact published as ref
+func Pull(ctx context.Context, ref string) (Artifact, error) {
+	ref = strings.TrimPrefix(ref, Scheme)
+	resolver := newResolver()
+	name, desc, err := resolver.Resolve(ctx, ref)
+	if err != nil {
+		return Artifact{}, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return Artifact{}, err
+	}
+
+	data, err := fetch(ctx, fetcher, desc)
+	if err != nil {
+		return Artifact{}, err
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Artifact{}, err
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return Artifact{}, errors.Errorf("%s is not a compose project artifact", ref)
+	}
+
+	artifact := Artifact{
+		ProjectName: manifest.Annotations[ProjectNameAnnotation],
+	}
+	for _, layer := range manifest.Layers {
+		data, err := fetch(ctx, fetcher, layer)
+		if err != nil {
+			return Artifact{}, err
+		}
+		switch layer.MediaType {
+		case ComposeFileMediaType:
+			artifact.ComposeFile = data
+		case EnvFileMediaType:
+			artifact.EnvFile = data
+		}
+	}
+	if artifact.ComposeFile == nil {
+		return Artifact{}, errors.Errorf("%s does not contain a compose file", ref)
+	}
+	return artifact, nil
+}
+
+func fetch(ctx context.Context, fetcher remotes.Fetcher, desc ocispec.Descriptor) ([]byte, error) {
+	r, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close() // nolint:errcheck
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if digest.FromBytes(data) != desc.Digest {
+		return nil, errors.Errorf("digest mismatch for %s", desc.Digest)
+	}
+	return data, nil
+}
+
+// newResolver returns a registry resolver authenticating with the docker CLI credentials
+func newResolver() remotes.Resolver {
+	cfg := config.LoadDefaultConfigFile(os.Stderr)
+	return docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(
+			docker.WithAuthorizer(docker.NewDockerAuthorizer(
+				docker.WithAuthCreds(func(host string) (string, string, error) {
+					key := host
+					if host == "registry-1.docker.io" {
+						key = dockerHubConfigKey
+					}
+					auth, err := cfg.GetAuthConfig(key)
+					if err != nil {
+						return "", "", err
+					}
+					if auth.IdentityToken != "" {
+						return "", auth.IdentityToken, nil
+					}
+					return auth.Username, auth.Password, nil
+				}),
+			)),
+		),
+	})
+}
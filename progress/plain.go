@@ -20,11 +20,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"sync"
 )
 
 type plainWriter struct {
 	out  io.Writer
 	done chan bool
+	mtx  *sync.Mutex
 }
 
 func (p *plainWriter) Start(ctx context.Context) error {
@@ -37,7 +39,9 @@ func (p *plainWriter) Start(ctx context.Context) error {
 }
 
 func (p *plainWriter) Event(e Event) {
-	fmt.Println(e.ID, e.Text, e.StatusText)
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	fmt.Fprintln(p.out, e.displayName(), e.Text, e.StatusText)
 }
 
 func (p *plainWriter) Stop() {
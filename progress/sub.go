@@ -0,0 +1,69 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"context"
+)
+
+type subWriter struct {
+	parent Writer
+	id     string
+}
+
+// SubWriter returns a writer forwarding its events to the parent, nested under
+// the parent event `id`. Each goroutine reporting progress concurrently, like
+// parallel image pulls, should own its sub writer: event IDs are scoped to the
+// sub writer so identical IDs reported by different goroutines, like a layer
+// shared by two images, don't overwrite each other when rendered by the parent.
+func SubWriter(parent Writer, id string) Writer {
+	return &subWriter{
+		parent: parent,
+		id:     id,
+	}
+}
+
+// WithSubWriter adds a sub writer of the context writer to the context
+func WithSubWriter(ctx context.Context, id string) context.Context {
+	return WithContextWriter(ctx, SubWriter(ContextWriter(ctx), id))
+}
+
+// Start is a no-op, the parent writer is the only one rendering events
+func (w *subWriter) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop is a no-op, the parent writer is stopped by its owner
+func (w *subWriter) Stop() {
+}
+
+func (w *subWriter) Event(e Event) {
+	if e.name == "" {
+		e.name = e.ID
+	}
+	e.ID = w.scoped(e.ID)
+	if e.ParentID == "" {
+		e.ParentID = w.id
+	} else {
+		e.ParentID = w.scoped(e.ParentID)
+	}
+	w.parent.Event(e)
+}
+
+func (w *subWriter) scoped(id string) string {
+	return w.id + "/" + id
+}
@@ -0,0 +1,72 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestSubWriterScopesEvents(t *testing.T) {
+	w := &ttyWriter{
+		events: map[string]Event{},
+		mtx:    &sync.RWMutex{},
+	}
+	w.Event(Event{ID: "web"})
+	w.Event(Event{ID: "db"})
+
+	var wg sync.WaitGroup
+	for _, service := range []string{"web", "db"} {
+		sub := SubWriter(w, service)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				sub.Event(Event{ID: "layer", Text: fmt.Sprintf("Downloading %d", i)})
+			}
+			sub.Event(Event{ID: "layer", Status: Done})
+		}()
+	}
+	wg.Wait()
+
+	ids, depths := w.treeOrder()
+	assert.DeepEqual(t, ids, []string{"web", "web/layer", "db", "db/layer"})
+	assert.DeepEqual(t, depths, []int{0, 1, 0, 1})
+	layer := w.events["db/layer"]
+	assert.Equal(t, layer.displayName(), "layer")
+	assert.Equal(t, layer.Status, Done)
+}
+
+func TestNestedSubWriters(t *testing.T) {
+	w := &ttyWriter{
+		events: map[string]Event{},
+		mtx:    &sync.RWMutex{},
+	}
+	w.Event(Event{ID: "web"})
+	build := SubWriter(w, "web")
+	build.Event(Event{ID: "build"})
+	SubWriter(build, "build").Event(Event{ID: "step"})
+
+	ids, depths := w.treeOrder()
+	assert.DeepEqual(t, ids, []string{"web", "web/build", "web/build/step"})
+	assert.DeepEqual(t, depths, []int{0, 1, 2})
+	step := w.events["web/build/step"]
+	assert.Equal(t, step.displayName(), "step")
+}
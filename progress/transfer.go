@@ -0,0 +1,51 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+)
+
+// startTransfer records when the event started transferring bytes, to compute the transfer rate
+func (e *Event) startTransfer() {
+	if e.Current > 0 && e.transferStart.IsZero() {
+		e.transferStart = time.Now()
+	}
+}
+
+// transferText returns the transferred bytes of the event, with the transfer
+// rate and the estimated time remaining while it is working
+func transferText(e Event, now time.Time) string {
+	text := fmt.Sprintf("%s/%s", units.HumanSize(float64(e.Current)), units.HumanSize(float64(e.Total)))
+	if e.Status != Working || e.transferStart.IsZero() {
+		return text
+	}
+	elapsed := now.Sub(e.transferStart).Seconds()
+	if elapsed <= 0 || e.Current <= 0 {
+		return text
+	}
+	rate := float64(e.Current) / elapsed
+	text = fmt.Sprintf("%s %s/s", text, units.HumanSize(rate))
+	if remaining := e.Total - e.Current; remaining > 0 {
+		eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+		text = fmt.Sprintf("%s %s left", text, eta.Round(time.Second))
+	}
+	return text
+}
@@ -0,0 +1,64 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestTransferText(t *testing.T) {
+	now := time.Now()
+	ev := Event{
+		ID:            "layer",
+		Text:          "Downloading",
+		Status:        Working,
+		Current:       2000000,
+		Total:         10000000,
+		transferStart: now.Add(-2 * time.Second),
+	}
+	assert.Equal(t, transferText(ev, now), "2MB/10MB 1MB/s 8s left")
+
+	ev.transferStart = time.Time{}
+	assert.Equal(t, transferText(ev, now), "2MB/10MB")
+
+	ev.Status = Done
+	ev.Current = ev.Total
+	assert.Equal(t, transferText(ev, now), "10MB/10MB")
+}
+
+func TestTransferStartResetOnNewPhase(t *testing.T) {
+	w := &ttyWriter{
+		events: map[string]Event{},
+		mtx:    &sync.RWMutex{},
+	}
+	w.Event(Event{ID: "layer", Text: "Waiting", Status: Working})
+	assert.Assert(t, w.events["layer"].transferStart.IsZero())
+
+	w.Event(Event{ID: "layer", Text: "Downloading", Status: Working, Current: 10, Total: 100})
+	downloadStart := w.events["layer"].transferStart
+	assert.Assert(t, !downloadStart.IsZero())
+
+	w.Event(Event{ID: "layer", Text: "Downloading", Status: Working, Current: 50, Total: 100})
+	assert.Equal(t, w.events["layer"].transferStart, downloadStart)
+
+	w.Event(Event{ID: "layer", Text: "Extracting", Status: Working, Total: 100})
+	assert.Assert(t, w.events["layer"].transferStart.IsZero())
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/docker/compose-cli/formatter"
 	"github.com/docker/compose-cli/utils"
 
 	"github.com/buger/goterm"
@@ -39,6 +40,8 @@ type ttyWriter struct {
 	numLines int
 	done     chan bool
 	mtx      *sync.RWMutex
+	// width is the terminal width the events were last printed with
+	width int
 }
 
 func (w *ttyWriter) Start(ctx context.Context) error {
@@ -76,13 +79,24 @@ func (w *ttyWriter) Event(e Event) {
 				last.stop()
 			}
 		}
+		if last.Text != e.Text {
+			// a new transfer phase starts, e.g. extracting after downloading
+			last.transferStart = time.Time{}
+		}
+		if e.ParentID != "" {
+			last.ParentID = e.ParentID
+		}
 		last.Status = e.Status
 		last.Text = e.Text
 		last.StatusText = e.StatusText
+		last.Current = e.Current
+		last.Total = e.Total
+		last.startTransfer()
 		w.events[e.ID] = last
 	} else {
 		e.startTime = time.Now()
 		e.spinner = newSpinner()
+		e.startTransfer()
 		w.events[e.ID] = e
 	}
 }
@@ -93,9 +107,15 @@ func (w *ttyWriter) print() {
 	if len(w.eventIDs) == 0 {
 		return
 	}
-	terminalWidth := goterm.Width()
+	terminalWidth := currentTerminalWidth()
+	// when the terminal shrinks, the lines already printed wrap over multiple rows
+	rowsPerLine := 1
+	if w.width > terminalWidth {
+		rowsPerLine = (w.width + terminalWidth - 1) / terminalWidth
+	}
+	w.width = terminalWidth
 	b := aec.EmptyBuilder
-	for i := 0; i <= w.numLines; i++ {
+	for i := 0; i <= w.numLines*rowsPerLine; i++ {
 		b = b.Up(1)
 	}
 	if !w.repeated {
@@ -103,28 +123,41 @@ func (w *ttyWriter) print() {
 	}
 	w.repeated = true
 	fmt.Fprint(w.out, b.Column(0).ANSI)
+	if rowsPerLine > 1 {
+		fmt.Fprint(w.out, aec.EraseDisplay(aec.EraseModes.Tail))
+	}
 
 	// Hide the cursor while we are printing
 	fmt.Fprint(w.out, aec.Hide)
 	defer fmt.Fprint(w.out, aec.Show)
 
-	firstLine := fmt.Sprintf("[+] Running %d/%d", numDone(w.events), w.numLines)
-	if w.numLines != 0 && numDone(w.events) == w.numLines {
+	color := runtime.GOOS != "windows" && !formatter.NoColor()
+	firstLine := formatter.Truncate(fmt.Sprintf("[+] Running %d/%d", numDone(w.events), w.numLines), terminalWidth-1)
+	if color && w.numLines != 0 && numDone(w.events) == w.numLines {
 		firstLine = aec.Apply(firstLine, aec.BlueF)
 	}
 	fmt.Fprintln(w.out, firstLine)
 
+	ids, depths := w.treeOrder()
+	events := make([]Event, len(ids))
+	for i, id := range ids {
+		// indent the event under its parent
+		e := w.events[id]
+		e.ID = strings.Repeat("  ", depths[i]) + e.displayName()
+		events[i] = e
+	}
+
 	var statusPadding int
-	for _, v := range w.eventIDs {
-		l := len(fmt.Sprintf("%s %s", w.events[v].ID, w.events[v].Text))
+	for _, e := range events {
+		l := len(fmt.Sprintf("%s %s", e.ID, e.Text))
 		if statusPadding < l {
 			statusPadding = l
 		}
 	}
 
 	numLines := 0
-	for _, v := range w.eventIDs {
-		line := lineText(w.events[v], terminalWidth, statusPadding, runtime.GOOS != "windows")
+	for _, e := range events {
+		line := lineText(e, terminalWidth, statusPadding, color)
 		// nolint: errcheck
 		fmt.Fprint(w.out, line)
 		numLines++
@@ -133,6 +166,45 @@ func (w *ttyWriter) print() {
 	w.numLines = numLines
 }
 
+// treeOrder returns the event IDs in the order they were received, each
+// event being followed by its children, along with their depth in the tree
+func (w *ttyWriter) treeOrder() ([]string, []int) {
+	children := map[string][]string{}
+	var roots []string
+	for _, id := range w.eventIDs {
+		parent := w.events[id].ParentID
+		if _, ok := w.events[parent]; ok && parent != id {
+			children[parent] = append(children[parent], id)
+		} else {
+			roots = append(roots, id)
+		}
+	}
+
+	ids := make([]string, 0, len(w.eventIDs))
+	depths := make([]int, 0, len(w.eventIDs))
+	visited := map[string]bool{}
+	var visit func(id string, depth int)
+	visit = func(id string, depth int) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		ids = append(ids, id)
+		depths = append(depths, depth)
+		for _, child := range children[id] {
+			visit(child, depth+1)
+		}
+	}
+	for _, id := range roots {
+		visit(id, 0)
+	}
+	// events with cyclic parents are not reachable from a root
+	for _, id := range w.eventIDs {
+		visit(id, 0)
+	}
+	return ids, depths
+}
+
 func lineText(event Event, terminalWidth, statusPadding int, color bool) string {
 	endTime := time.Now()
 	if event.Status != Working {
@@ -150,7 +222,12 @@ func lineText(event Event, terminalWidth, statusPadding int, color bool) string
 	// is 2-3 lines long and breaks the line formating
 	maxStatusLen := terminalWidth - textLen - statusPadding - 15
 	status := event.StatusText
-	if len(status) > maxStatusLen {
+	if event.Total > 0 {
+		status = transferText(event, endTime)
+	}
+	if maxStatusLen <= 0 {
+		status = ""
+	} else if len(status) > maxStatusLen {
 		status = status[:maxStatusLen] + "..."
 	}
 	text := fmt.Sprintf(" %s %s %s%s %s",
@@ -161,6 +238,8 @@ func lineText(event Event, terminalWidth, statusPadding int, color bool) string
 		status,
 	)
 	timer := fmt.Sprintf("%.1fs\n", elapsed)
+	// lines wider than the terminal would wrap and break the rewriting of the events
+	text = formatter.Truncate(text, terminalWidth-len(timer)-1)
 	o := align(text, timer, terminalWidth)
 
 	if color {
@@ -177,6 +256,16 @@ func lineText(event Event, terminalWidth, statusPadding int, color bool) string
 	return o
 }
 
+// currentTerminalWidth returns the width of the terminal, read on each print to follow resizes
+func currentTerminalWidth() int {
+	width := goterm.Width()
+	if width <= 0 {
+		// not a terminal, or its size is unknown
+		return 80
+	}
+	return width
+}
+
 func numDone(events map[string]Event) int {
 	i := 0
 	for _, e := range events {
@@ -56,6 +56,27 @@ func TestLineText(t *testing.T) {
 	assert.Equal(t, out, "\x1b[31m . id Text Status                            0.0s\n\x1b[0m")
 }
 
+func TestLineTextNarrowTerminal(t *testing.T) {
+	now := time.Now()
+	ev := Event{
+		ID:         "a-service-with-a-long-name",
+		Text:       "Creating",
+		Status:     Working,
+		StatusText: "pulling the image of the service",
+		endTime:    now,
+		startTime:  now,
+		spinner: &spinner{
+			chars: []string{"."},
+		},
+	}
+
+	lineWidth := len(fmt.Sprintf("%s %s", ev.ID, ev.Text))
+
+	out := lineText(ev, 20, lineWidth, false)
+	assert.Equal(t, out, " . a-servic... 0.0s\n")
+	assert.Equal(t, len(out), 20)
+}
+
 func TestErrorEvent(t *testing.T) {
 	w := &ttyWriter{
 		events: map[string]Event{},
@@ -84,3 +105,21 @@ func TestErrorEvent(t *testing.T) {
 	assert.Assert(t, ok)
 	assert.Assert(t, event.endTime.After(time.Now().Add(-10*time.Second)))
 }
+
+func TestTreeOrder(t *testing.T) {
+	w := &ttyWriter{
+		events: map[string]Event{},
+		mtx:    &sync.RWMutex{},
+	}
+	w.Event(Event{ID: "db", ParentID: "web"})
+	w.Event(Event{ID: "cache", ParentID: "web"})
+	w.Event(Event{ID: "web"})
+	w.Event(Event{ID: "volume", ParentID: "db"})
+	w.Event(Event{ID: "orphan", ParentID: "unknown"})
+	// parent is kept when not repeated
+	w.Event(Event{ID: "db", Status: Done})
+
+	ids, depths := w.treeOrder()
+	assert.DeepEqual(t, ids, []string{"web", "db", "volume", "cache", "orphan"})
+	assert.DeepEqual(t, depths, []int{0, 1, 2, 1, 0})
+}
@@ -0,0 +1,61 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+// WalkStatusFunc renders the status of the services walked by compose.Walk as progress events, services
+// being rendered under the service parents maps them to. Running and done services are reported by the
+// walked function itself, which knows what it is doing.
+func WalkStatusFunc(w Writer, parents map[string]string) compose.WalkStatusFunc {
+	return func(service string, status compose.WalkStatus, err error) {
+		id := fmt.Sprintf("Service %q", service)
+		switch status {
+		case compose.WalkQueued:
+			parentID := ""
+			if parent, ok := parents[service]; ok {
+				parentID = fmt.Sprintf("Service %q", parent)
+			}
+			w.Event(Event{
+				ID:         id,
+				ParentID:   parentID,
+				Status:     Working,
+				StatusText: "Waiting",
+			})
+		case compose.WalkSkipped:
+			text := "Skipped"
+			if err != nil {
+				text = fmt.Sprintf("Skipped: %v", err)
+			}
+			w.Event(Event{
+				ID:         id,
+				Status:     Done,
+				StatusText: text,
+			})
+		case compose.WalkRetrying:
+			w.Event(Event{
+				ID:         id,
+				Status:     Working,
+				StatusText: fmt.Sprintf("Retrying: %v", err),
+			})
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package progress
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+type recordingWriter struct {
+	events []Event
+}
+
+func (w *recordingWriter) Start(context.Context) error {
+	return nil
+}
+
+func (w *recordingWriter) Stop() {}
+
+func (w *recordingWriter) Event(e Event) {
+	w.events = append(w.events, e)
+}
+
+func TestWalkStatusFunc(t *testing.T) {
+	w := &recordingWriter{}
+	onStatus := WalkStatusFunc(w, map[string]string{"db": "web"})
+
+	onStatus("db", compose.WalkQueued, nil)
+	onStatus("db", compose.WalkRunning, nil)
+	onStatus("db", compose.WalkRetrying, errors.New("port is already allocated"))
+	onStatus("db", compose.WalkDone, nil)
+	onStatus("web", compose.WalkSkipped, errors.New(`volume "data" failed`))
+
+	assert.DeepEqual(t, w.events, []Event{
+		{ID: `Service "db"`, ParentID: `Service "web"`, Status: Working, StatusText: "Waiting"},
+		{ID: `Service "db"`, Status: Working, StatusText: "Retrying: port is already allocated"},
+		{ID: `Service "web"`, Status: Done, StatusText: `Skipped: volume "data" failed`},
+	}, cmpopts.IgnoreUnexported(Event{}))
+}
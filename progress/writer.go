@@ -45,10 +45,28 @@ type Event struct {
 	Text       string
 	Status     EventStatus
 	StatusText string
+	// ParentID nests the event under another one when rendered as a tree, it
+	// is kept by the writer once set so following events don't need to repeat it
+	ParentID string
+	// Current and Total are the transferred and expected bytes of events
+	// tracking a transfer, like pulling an image layer
+	Current int64
+	Total   int64
+
+	// name is the ID displayed for the event, events sent through a sub writer
+	// have their ID scoped to it
+	name          string
+	startTime     time.Time
+	endTime       time.Time
+	transferStart time.Time
+	spinner       *spinner
+}
 
-	startTime time.Time
-	endTime   time.Time
-	spinner   *spinner
+func (e *Event) displayName() string {
+	if e.name != "" {
+		return e.name
+	}
+	return e.ID
 }
 
 func (e *Event) stop() {
@@ -132,5 +150,6 @@ func NewWriter(out console.File) (Writer, error) {
 	return &plainWriter{
 		out:  out,
 		done: make(chan bool),
+		mtx:  &sync.Mutex{},
 	}, nil
 }
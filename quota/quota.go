@@ -0,0 +1,195 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+
+	"github.com/docker/compose-cli/context/store"
+	"github.com/docker/compose-cli/utils"
+)
+
+// hoursPerMonth is the average number of hours in a month cloud providers bill
+const hoursPerMonth = 730
+
+// Pricing is the on-demand price of running containers on a cloud backend, in USD
+type Pricing struct {
+	CPUHour    float64
+	MemoryHour float64
+}
+
+// pricing holds the Linux list prices of the cloud backends in their US East regions
+var pricing = map[string]Pricing{
+	// Azure Container Instances bills $0.0000135 per vCPU second and $0.0000015 per GB second
+	store.AciContextType: {CPUHour: 0.0486, MemoryHour: 0.0054},
+	// AWS Fargate bills $0.04048 per vCPU hour and $0.004445 per GB hour
+	store.EcsContextType: {CPUHour: 0.04048, MemoryHour: 0.004445},
+}
+
+// ServiceUsage is the resources used by the replicas of a service
+type ServiceUsage struct {
+	Service  string
+	Replicas int
+	CPUs     float64
+	Memory   int64
+	// unbounded lists the resources the service doesn't limit
+	unbounded []string
+}
+
+// Usage is the resources used by a project
+type Usage struct {
+	Services    []ServiceUsage
+	CPUs        float64
+	Memory      int64
+	MonthlyCost float64
+}
+
+// ProjectUsage computes the resources used by the services of a project, from their limits or,
+// when they don't declare any, their reservations. The monthly cost is only estimated for cloud backends.
+func ProjectUsage(project *types.Project, contextType string) (Usage, error) {
+	usage := Usage{}
+	for _, s := range project.Services {
+		service := ServiceUsage{
+			Service:  s.Name,
+			Replicas: replicas(s),
+		}
+		cpus, memory := resources(s)
+		if cpus == "" {
+			service.unbounded = append(service.unbounded, "cpus")
+		} else {
+			c, err := strconv.ParseFloat(cpus, 64)
+			if err != nil {
+				return Usage{}, errors.Wrapf(err, "service %q: invalid cpus %q", s.Name, cpus)
+			}
+			service.CPUs = c * float64(service.Replicas)
+		}
+		if memory == 0 {
+			service.unbounded = append(service.unbounded, "memory")
+		} else {
+			service.Memory = memory * int64(service.Replicas)
+		}
+		usage.Services = append(usage.Services, service)
+		usage.CPUs += service.CPUs
+		usage.Memory += service.Memory
+	}
+	sort.Slice(usage.Services, func(i, j int) bool {
+		return usage.Services[i].Service < usage.Services[j].Service
+	})
+	if p, ok := pricing[contextType]; ok {
+		usage.MonthlyCost = (usage.CPUs*p.CPUHour + float64(usage.Memory)/units.GiB*p.MemoryHour) * hoursPerMonth
+	}
+	return usage, nil
+}
+
+// Check validates the project against the quotas of the context it is deployed with, the
+// returned error details the resources used by every service
+func Check(project *types.Project, contextType string, quotas *store.Quotas) error {
+	if quotas == nil {
+		return nil
+	}
+	usage, err := ProjectUsage(project, contextType)
+	if err != nil {
+		return err
+	}
+
+	_, priced := pricing[contextType]
+	checkCost := priced && quotas.MaxMonthlyCost > 0
+	// resources which are not limited can't be checked against the quotas
+	checked := map[string]bool{
+		"cpus":   quotas.MaxCPUs > 0 || checkCost,
+		"memory": quotas.MaxMemory > 0 || checkCost,
+	}
+	var violations []string
+	for _, s := range usage.Services {
+		for _, resource := range s.unbounded {
+			if checked[resource] {
+				violations = append(violations, fmt.Sprintf("service %q doesn't limit its %s", s.Service, resource))
+			}
+		}
+	}
+	if quotas.MaxCPUs > 0 && usage.CPUs > quotas.MaxCPUs {
+		violations = append(violations, fmt.Sprintf("%g CPUs exceed the quota of %g", usage.CPUs, quotas.MaxCPUs))
+	}
+	if quotas.MaxMemory > 0 && usage.Memory > quotas.MaxMemory {
+		violations = append(violations, fmt.Sprintf("%s of memory exceed the quota of %s", units.BytesSize(float64(usage.Memory)), units.BytesSize(float64(quotas.MaxMemory))))
+	}
+	if checkCost && usage.MonthlyCost > quotas.MaxMonthlyCost {
+		violations = append(violations, fmt.Sprintf("estimated monthly cost of $%.2f exceeds the quota of $%.2f", usage.MonthlyCost, quotas.MaxMonthlyCost))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.Errorf("project %q exceeds the context quotas: %s\n%s", project.Name, strings.Join(violations, ", "), breakdown(usage))
+}
+
+// breakdown renders the resources used by every service
+func breakdown(usage Usage) string {
+	var b strings.Builder
+	for _, s := range usage.Services {
+		cpus, memory := "unlimited", "unlimited"
+		if !utils.StringContains(s.unbounded, "cpus") {
+			cpus = strconv.FormatFloat(s.CPUs, 'g', -1, 64)
+		}
+		if !utils.StringContains(s.unbounded, "memory") {
+			memory = units.BytesSize(float64(s.Memory))
+		}
+		fmt.Fprintf(&b, "  %s: %d replica(s), %s CPUs, %s memory\n", s.Service, s.Replicas, cpus, memory)
+	}
+	fmt.Fprintf(&b, "  total: %s CPUs, %s memory", strconv.FormatFloat(usage.CPUs, 'g', -1, 64), units.BytesSize(float64(usage.Memory)))
+	if usage.MonthlyCost > 0 {
+		fmt.Fprintf(&b, ", $%.2f per month", usage.MonthlyCost)
+	}
+	return b.String()
+}
+
+func replicas(s types.ServiceConfig) int {
+	if s.Deploy != nil && s.Deploy.Replicas != nil {
+		return int(*s.Deploy.Replicas)
+	}
+	if s.Scale != 0 {
+		return s.Scale
+	}
+	return 1
+}
+
+// resources returns the CPUs and memory of a service, its limits prevailing over its reservations
+func resources(s types.ServiceConfig) (string, int64) {
+	if s.Deploy == nil {
+		return "", 0
+	}
+	var cpus string
+	var memory int64
+	if r := s.Deploy.Resources.Reservations; r != nil {
+		cpus, memory = r.NanoCPUs, int64(r.MemoryBytes)
+	}
+	if l := s.Deploy.Resources.Limits; l != nil {
+		if l.NanoCPUs != "" {
+			cpus = l.NanoCPUs
+		}
+		if l.MemoryBytes != 0 {
+			memory = int64(l.MemoryBytes)
+		}
+	}
+	return cpus, memory
+}
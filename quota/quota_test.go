@@ -0,0 +1,85 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/docker/go-units"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/context/store"
+)
+
+func testProject() *types.Project {
+	replicas := uint64(2)
+	return &types.Project{
+		Name: "shop",
+		Services: types.Services{
+			{
+				Name: "web",
+				Deploy: &types.DeployConfig{
+					Replicas: &replicas,
+					Resources: types.Resources{
+						Limits: &types.Resource{NanoCPUs: "0.5", MemoryBytes: units.GiB},
+					},
+				},
+			},
+			{
+				Name: "db",
+				Deploy: &types.DeployConfig{
+					Resources: types.Resources{
+						Reservations: &types.Resource{NanoCPUs: "1", MemoryBytes: 2 * units.GiB},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestProjectUsage(t *testing.T) {
+	usage, err := ProjectUsage(testProject(), store.AciContextType)
+	assert.NilError(t, err)
+	assert.Equal(t, usage.CPUs, 2.0)
+	assert.Equal(t, usage.Memory, int64(4*units.GiB))
+	assert.Equal(t, int(usage.MonthlyCost*100), 8672)
+
+	usage, err = ProjectUsage(testProject(), store.LocalContextType)
+	assert.NilError(t, err)
+	assert.Equal(t, usage.MonthlyCost, 0.0)
+}
+
+func TestCheck(t *testing.T) {
+	project := testProject()
+	assert.NilError(t, Check(project, store.AciContextType, nil))
+	assert.NilError(t, Check(project, store.AciContextType, &store.Quotas{MaxCPUs: 2, MaxMemory: 4 * units.GiB, MaxMonthlyCost: 100}))
+
+	err := Check(project, store.AciContextType, &store.Quotas{MaxCPUs: 1.5, MaxMonthlyCost: 50})
+	assert.Error(t, err, `project "shop" exceeds the context quotas: 2 CPUs exceed the quota of 1.5, estimated monthly cost of $86.72 exceeds the quota of $50.00
+  db: 1 replica(s), 1 CPUs, 2GiB memory
+  web: 2 replica(s), 1 CPUs, 2GiB memory
+  total: 2 CPUs, 4GiB memory, $86.72 per month`)
+
+	// the cost of local projects is not estimated
+	assert.NilError(t, Check(project, store.LocalContextType, &store.Quotas{MaxMonthlyCost: 50}))
+
+	project.Services = append(project.Services, types.ServiceConfig{Name: "cache"})
+	err = Check(project, store.LocalContextType, &store.Quotas{MaxMemory: 8 * units.GiB})
+	assert.ErrorContains(t, err, `service "cache" doesn't limit its memory`)
+	assert.ErrorContains(t, err, "  cache: 1 replica(s), unlimited CPUs, unlimited memory\n")
+}
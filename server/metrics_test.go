@@ -121,6 +121,7 @@ func (noopService) ComposeService() compose.Service      { return nil }
 func (noopService) SecretsService() secrets.Service      { return nil }
 func (noopService) VolumeService() volumes.Service       { return nil }
 func (noopService) ResourceService() resources.Service   { return nil }
+func (noopService) Ping(ctx context.Context) error       { return nil }
 
 type mockMetricsClient struct {
 	mock.Mock
@@ -221,7 +221,7 @@ func TestContextDuplicateACI(t *testing.T) {
 	c.RunDockerCmd("context", "create", "mycontext", "--from", "default")
 	res := c.RunDockerOrExitError("context", "create", "aci", "mycontext")
 	res.Assert(t, icmd.Expected{
-		ExitCode: 1,
+		ExitCode: 7,
 		Err:      "context mycontext: already exists",
 	})
 }
@@ -524,15 +524,13 @@ func TestVersion(t *testing.T) {
 
 func TestMockBackend(t *testing.T) {
 	c := NewParallelE2eCLI(t, binDir)
-	c.RunDockerCmd("context", "create", "example", "test-example")
-	res := c.RunDockerCmd("context", "use", "test-example")
-	res.Assert(t, icmd.Expected{Out: "test-example"})
+	c.UseContext("example", "test-example")
 
 	t.Run("use", func(t *testing.T) {
 		res := c.RunDockerCmd("context", "show")
 		res.Assert(t, icmd.Expected{Out: "test-example"})
 		res = c.RunDockerCmd("context", "ls")
-		golden.Assert(t, res.Stdout(), GoldenFile("ls-out-test-example"))
+		AssertGolden(t, res, "ls-out-test-example")
 	})
 
 	t.Run("ps", func(t *testing.T) {
@@ -569,6 +567,11 @@ func TestMockBackend(t *testing.T) {
 	})
 }
 
+func TestExampleConformance(t *testing.T) {
+	c := NewParallelE2eCLI(t, binDir)
+	RunConformanceSuite(t, c, Backend{ContextType: "example", Image: "nginx"})
+}
+
 func TestFailOnEcsUsageAsPlugin(t *testing.T) {
 	c := NewParallelE2eCLI(t, binDir)
 	res := c.RunDockerCmd("context", "create", "local", "local")
@@ -0,0 +1,94 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/icmd"
+
+	"github.com/docker/compose-cli/utils"
+)
+
+// Backend describes a backend for the conformance suite
+type Backend struct {
+	// ContextType is the type given to `docker context create`
+	ContextType string
+	// ContextArgs are additional arguments given to `docker context create`
+	ContextArgs []string
+	// Image is the image used to run containers, running containers is not
+	// checked if empty
+	Image string
+}
+
+// RunConformanceSuite checks the behaviour every backend is expected to share.
+// Each CLI invocation runs in its own process, so the checks don't rely on
+// state being kept by the backend between two commands.
+func RunConformanceSuite(t *testing.T, c *E2eCLI, backend Backend) {
+	name := "conformance-" + backend.ContextType
+	c.UseContext(backend.ContextType, name, backend.ContextArgs...)
+
+	t.Run("context show", func(t *testing.T) {
+		res := c.RunDockerCmd("context", "show")
+		res.Assert(t, icmd.Expected{Out: name})
+	})
+
+	t.Run("ps json", func(t *testing.T) {
+		res := c.RunDockerCmd("ps", "--all", "--format", "json")
+		var containers []map[string]interface{}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &containers))
+	})
+
+	t.Run("ps all", func(t *testing.T) {
+		running := lines(c.RunDockerCmd("ps", "-q").Stdout())
+		all := lines(c.RunDockerCmd("ps", "-q", "--all").Stdout())
+		for _, id := range running {
+			assert.Assert(t, utils.StringContains(all, id), "running container %q not listed with --all", id)
+		}
+	})
+
+	t.Run("inspect unknown", func(t *testing.T) {
+		res := c.RunDockerOrExitError("inspect", "conformance-unknown-container")
+		assert.Assert(t, res.ExitCode != 0)
+	})
+
+	t.Run("compose ls json", func(t *testing.T) {
+		res := c.RunDockerCmd("compose", "ls", "--format", "json")
+		var stacks []map[string]interface{}
+		assert.NilError(t, json.Unmarshal([]byte(res.Stdout()), &stacks))
+	})
+
+	if backend.Image != "" {
+		t.Run("run", func(t *testing.T) {
+			c.RunDockerCmd("run", "-d", "--name", "conformance", backend.Image)
+			c.RunDockerOrExitError("rm", "--force", "conformance")
+		})
+	}
+}
+
+func lines(s string) []string {
+	var res []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			res = append(res, l)
+		}
+	}
+	return res
+}
@@ -97,6 +97,13 @@ func dirContents(dir string) []string {
 // SetupExistingCLI copies the existing CLI in a temporary directory so that the
 // new CLI can be configured to use it
 func SetupExistingCLI() (string, func(), error) {
+	return SetupCLI("../../bin/" + DockerExecutableName)
+}
+
+// SetupCLI copies the existing CLI and the CLI binary found at `bin` in a
+// temporary directory, it allows backends living outside of this repository to
+// run the e2e suite against their own build of the CLI
+func SetupCLI(bin string) (string, func(), error) {
 	p, err := exec.LookPath(existingExectuableName)
 	if err != nil {
 		p, err = exec.LookPath(DockerExecutableName)
@@ -111,7 +118,7 @@ func SetupExistingCLI() (string, func(), error) {
 	if err := CopyFile(p, filepath.Join(d, existingExectuableName)); err != nil {
 		return "", nil, err
 	}
-	bin, err := filepath.Abs("../../bin/" + DockerExecutableName)
+	bin, err = filepath.Abs(bin)
 	if err != nil {
 		return "", nil, err
 	}
@@ -184,10 +191,14 @@ func (c *E2eCLI) PathEnvVar() string {
 	return path
 }
 
-// GoldenFile golden file specific to platform
+// GoldenFile golden file specific to platform, the windows variant is used
+// only when it exists in the testdata directory
 func GoldenFile(name string) string {
 	if runtime.GOOS == "windows" {
-		return name + "-windows.golden"
+		windows := name + "-windows.golden"
+		if _, err := os.Stat(filepath.Join("testdata", windows)); err == nil {
+			return windows
+		}
 	}
 	return name + ".golden"
 }
@@ -0,0 +1,44 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"gotest.tools/v3/golden"
+	"gotest.tools/v3/icmd"
+)
+
+// CreateContext creates a context of the given type, additional arguments are
+// passed as is to `docker context create`
+func (c *E2eCLI) CreateContext(contextType string, name string, args ...string) *icmd.Result {
+	return c.RunDockerCmd(append([]string{"context", "create", contextType, name}, args...)...)
+}
+
+// UseContext creates a context of the given type and makes it the current one
+func (c *E2eCLI) UseContext(contextType string, name string, args ...string) *icmd.Result {
+	c.CreateContext(contextType, name, args...)
+	res := c.RunDockerCmd("context", "use", name)
+	res.Assert(c.test, icmd.Expected{Out: name})
+	return res
+}
+
+// AssertGolden compares the standard output of a command with the platform
+// specific golden file `name` of the testdata directory
+func AssertGolden(t *testing.T, res *icmd.Result, name string) {
+	golden.Assert(t, res.Stdout(), GoldenFile(name))
+}
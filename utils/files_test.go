@@ -0,0 +1,46 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAtomicWriteFile(t *testing.T) {
+	d, err := ioutil.TempDir("", "atomic")
+	assert.NilError(t, err)
+	defer os.RemoveAll(d) // nolint errcheck
+
+	path := filepath.Join(d, "file.json")
+	err = AtomicWriteFile(path, []byte("first"), 0644)
+	assert.NilError(t, err)
+	err = AtomicWriteFile(path, []byte("second"), 0644)
+	assert.NilError(t, err)
+
+	content, err := ioutil.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "second")
+
+	files, err := ioutil.ReadDir(d)
+	assert.NilError(t, err)
+	assert.Equal(t, len(files), 1)
+}
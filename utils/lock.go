@@ -0,0 +1,59 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const lockRetryInterval = 50 * time.Millisecond
+
+// errLocked is returned by tryLockFile when another process or goroutine holds the lock
+var errLocked = errors.New("locked")
+
+// LockFile acquires an exclusive advisory lock on path, shared by all processes, waiting at most timeout.
+// The returned function releases the lock.
+// The lock is held on the open file rather than by its existence, so the OS releases it when a process
+// crashes and there is no stale lock to take over.
+func LockFile(path string, timeout time.Duration) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		err := tryLockFile(f)
+		if err == nil {
+			return func() {
+				_ = unlockFile(f)
+				_ = f.Close()
+			}, nil
+		}
+		if err != errLocked {
+			_ = f.Close()
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, errors.Errorf("timeout waiting for lock %s", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestLockFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lock")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir) // nolint:errcheck
+	path := filepath.Join(dir, ".lock")
+
+	unlock, err := LockFile(path, time.Second)
+	assert.NilError(t, err)
+
+	// another process opens the lock file separately
+	f, err := os.Open(path)
+	assert.NilError(t, err)
+	defer f.Close() // nolint:errcheck
+	assert.Equal(t, tryLockFile(f), errLocked)
+
+	_, err = LockFile(path, 0)
+	assert.Error(t, err, "timeout waiting for lock "+path)
+
+	unlock()
+	assert.NilError(t, tryLockFile(f))
+	assert.NilError(t, unlockFile(f))
+
+	// the lock file left over doesn't block
+	unlock, err = LockFile(path, 0)
+	assert.NilError(t, err)
+	unlock()
+}
@@ -0,0 +1,260 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/compose-spec/compose-go/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/docker/compose-cli/api/compose"
+	"github.com/docker/compose-cli/utils"
+)
+
+// Extension declares the webhooks of a compose project, as a sequence of mappings with the
+// webhook `url` and the `events` it is notified of, all events when empty
+const Extension = "x-webhooks"
+
+const (
+	// EventStart is sent when a service container starts running
+	EventStart = "start"
+	// EventUnhealthy is sent when a service container healthcheck reports it unhealthy
+	EventUnhealthy = "unhealthy"
+	// EventExit is sent when a service container stops running
+	EventExit = "exit"
+)
+
+var knownEvents = []string{EventStart, EventUnhealthy, EventExit}
+
+// Hook is a webhook notified of lifecycle events
+type Hook struct {
+	URL    string
+	Events []string
+}
+
+// Event is the JSON payload posted to webhooks
+type Event struct {
+	Project   string    `json:"project"`
+	Service   string    `json:"service"`
+	Container string    `json:"container,omitempty"`
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+}
+
+// Load returns the webhooks declared by the project
+func Load(project *types.Project) ([]Hook, error) {
+	x, ok := project.Extensions[Extension]
+	if !ok {
+		return nil, nil
+	}
+	entries, ok := x.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("invalid %s: expected a sequence", Extension)
+	}
+	var hooks []Hook
+	for _, entry := range entries {
+		m, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("invalid %s entry: expected a mapping", Extension)
+		}
+		hook := Hook{}
+		hook.URL, _ = m["url"].(string)
+		u, err := url.Parse(hook.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, errors.Errorf("invalid %s url %q: expected an http or https URL", Extension, hook.URL)
+		}
+		if events, ok := m["events"].([]interface{}); ok {
+			for _, e := range events {
+				event := fmt.Sprint(e)
+				if !utils.StringContains(knownEvents, event) {
+					return nil, errors.Errorf("invalid %s event %q for %s, expected one of %v", Extension, event, hook.URL, knownEvents)
+				}
+				hook.Events = append(hook.Events, event)
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
+
+// Accepts returns true if the webhook is notified of the event type
+func (h Hook) Accepts(eventType string) bool {
+	return len(h.Events) == 0 || utils.StringContains(h.Events, eventType)
+}
+
+// Send posts the event to the webhook
+func Send(ctx context.Context, client *http.Client, hook Hook, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook %s responded with status %s", hook.URL, resp.Status)
+	}
+	return nil
+}
+
+// WatchOptions group options of Watch
+type WatchOptions struct {
+	// Interval between two status polls
+	Interval time.Duration
+	// Baseline is the project status events are computed from, the first polled status when nil
+	Baseline []compose.ServiceStatus
+}
+
+// Watch polls the project status until the context is done and notifies the webhooks of the lifecycle events
+func Watch(ctx context.Context, service compose.Service, project string, hooks []Hook, options WatchOptions) error {
+	if options.Interval == 0 {
+		options.Interval = 2 * time.Second
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	var previous map[string]containerState
+	if options.Baseline != nil {
+		previous = snapshot(options.Baseline)
+	}
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+	for {
+		status, err := service.Ps(ctx, project)
+		if err != nil {
+			logrus.Debugf("webhooks: cannot get %s status: %v", project, err)
+		} else {
+			current := snapshot(status)
+			if previous != nil {
+				for _, event := range diff(project, previous, current) {
+					notify(ctx, client, hooks, event)
+				}
+			}
+			previous = current
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func notify(ctx context.Context, client *http.Client, hooks []Hook, event Event) {
+	for _, hook := range hooks {
+		if !hook.Accepts(event.Type) {
+			continue
+		}
+		if err := Send(ctx, client, hook, event); err != nil {
+			logrus.Warnf("cannot notify webhook of %s %s: %v", event.Service, event.Type, err)
+		}
+	}
+}
+
+type containerState struct {
+	service   string
+	container string
+	running   bool
+	unhealthy bool
+}
+
+// snapshot indexes the state of the project containers, services exposing no container are
+// considered as a whole
+func snapshot(services []compose.ServiceStatus) map[string]containerState {
+	states := map[string]containerState{}
+	for _, s := range services {
+		if len(s.Containers) == 0 {
+			states[s.Name] = containerState{
+				service:   s.Name,
+				running:   s.Replicas > 0,
+				unhealthy: s.Unhealthy > 0,
+			}
+			continue
+		}
+		for _, c := range s.Containers {
+			states[s.Name+"/"+c.ID] = containerState{
+				service:   s.Name,
+				container: c.Name,
+				running:   c.State == "running",
+				unhealthy: c.Health == "unhealthy",
+			}
+		}
+	}
+	return states
+}
+
+// diff computes the lifecycle events between two snapshots
+func diff(project string, previous, current map[string]containerState) []Event {
+	now := time.Now()
+	var events []Event
+	add := func(s containerState, eventType string) {
+		events = append(events, Event{
+			Project:   project,
+			Service:   s.service,
+			Container: s.container,
+			Type:      eventType,
+			Time:      now,
+		})
+	}
+	for _, key := range sortedKeys(previous, current) {
+		before, known := previous[key]
+		after, exists := current[key]
+		switch {
+		case !exists:
+			if before.running {
+				add(before, EventExit)
+			}
+		case after.running && (!known || !before.running):
+			add(after, EventStart)
+		case !after.running && known && before.running:
+			add(after, EventExit)
+		}
+		if exists && after.running && after.unhealthy && (!known || !before.unhealthy) {
+			add(after, EventUnhealthy)
+		}
+	}
+	return events
+}
+
+func sortedKeys(maps ...map[string]containerState) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, m := range maps {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
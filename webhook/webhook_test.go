@@ -0,0 +1,120 @@
+/*
+   Copyright 2020 Docker Compose CLI authors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/compose-spec/compose-go/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/docker/compose-cli/api/compose"
+)
+
+func TestLoad(t *testing.T) {
+	hooks, err := Load(&types.Project{
+		Extensions: map[string]interface{}{
+			Extension: []interface{}{
+				map[string]interface{}{"url": "https://hooks.example.com/alerts", "events": []interface{}{"unhealthy", "exit"}},
+				map[string]interface{}{"url": "http://localhost:8080"},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, hooks, []Hook{
+		{URL: "https://hooks.example.com/alerts", Events: []string{"unhealthy", "exit"}},
+		{URL: "http://localhost:8080"},
+	})
+	assert.Assert(t, !hooks[0].Accepts(EventStart))
+	assert.Assert(t, hooks[0].Accepts(EventExit))
+	assert.Assert(t, hooks[1].Accepts(EventStart))
+
+	hooks, err = Load(&types.Project{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(hooks), 0)
+
+	_, err = Load(&types.Project{
+		Extensions: map[string]interface{}{
+			Extension: []interface{}{map[string]interface{}{"url": "ftp://example.com"}},
+		},
+	})
+	assert.Error(t, err, `invalid x-webhooks url "ftp://example.com": expected an http or https URL`)
+
+	_, err = Load(&types.Project{
+		Extensions: map[string]interface{}{
+			Extension: []interface{}{map[string]interface{}{"url": "https://example.com", "events": []interface{}{"stop"}}},
+		},
+	})
+	assert.Error(t, err, `invalid x-webhooks event "stop" for https://example.com, expected one of [start unhealthy exit]`)
+}
+
+func TestDiff(t *testing.T) {
+	previous := snapshot([]compose.ServiceStatus{
+		{Name: "db", Containers: []compose.ContainerSummary{{ID: "1", Name: "app_db_1", State: "running"}}},
+		{Name: "web", Containers: []compose.ContainerSummary{{ID: "2", Name: "app_web_1", State: "running"}}},
+		{Name: "worker", Containers: []compose.ContainerSummary{{ID: "3", Name: "app_worker_1", State: "running"}}},
+	})
+	current := snapshot([]compose.ServiceStatus{
+		{Name: "db", Containers: []compose.ContainerSummary{{ID: "1", Name: "app_db_1", State: "running", Health: "unhealthy"}}},
+		{Name: "web", Containers: []compose.ContainerSummary{
+			{ID: "2", Name: "app_web_1", State: "exited"},
+			{ID: "4", Name: "app_web_2", State: "running"},
+		}},
+		{Name: "front", Replicas: 1, Desired: 1},
+	})
+
+	var got []string
+	for _, e := range diff("app", previous, current) {
+		assert.Equal(t, e.Project, "app")
+		got = append(got, e.Service+" "+e.Container+" "+e.Type)
+	}
+	assert.DeepEqual(t, got, []string{
+		"db app_db_1 unhealthy",
+		"front  start",
+		"web app_web_1 exit",
+		"web app_web_2 start",
+		"worker app_worker_1 exit",
+	})
+
+	assert.Equal(t, len(diff("app", current, current)), 0)
+}
+
+func TestSend(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, r.Method, http.MethodPost)
+		assert.Equal(t, r.Header.Get("Content-Type"), "application/json")
+		assert.NilError(t, json.NewDecoder(r.Body).Decode(&received))
+		if received.Type == EventExit {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	hook := Hook{URL: server.URL}
+	err := Send(context.Background(), server.Client(), hook, Event{Project: "app", Service: "web", Type: EventStart})
+	assert.NilError(t, err)
+	assert.Equal(t, received.Service, "web")
+	assert.Equal(t, received.Type, EventStart)
+
+	err = Send(context.Background(), server.Client(), hook, Event{Project: "app", Service: "web", Type: EventExit})
+	assert.ErrorContains(t, err, "responded with status 500")
+}